@@ -2,4 +2,9 @@
 SELECT 'CREATE DATABASE weatherdb_test'
 WHERE NOT EXISTS (
   SELECT FROM pg_database WHERE datname = 'weatherdb_test'
-)\gexec
\ No newline at end of file
+)\gexec
+
+SELECT 'CREATE DATABASE weather_history'
+WHERE NOT EXISTS (
+  SELECT FROM pg_database WHERE datname = 'weather_history'
+)\gexec
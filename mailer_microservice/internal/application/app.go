@@ -39,6 +39,9 @@ func NewApp() *App {
 	// Mailer service
 	mailer := mailer_service.NewMailerService(sender, cfg.AppBaseURL)
 	mailer.SetTemplateDir(cfg.TemplateDir)
+	mailerMetrics := mailer_service.NewPrometheusMetrics()
+	mailerMetrics.Register()
+	mailer.SetMetrics(mailerMetrics)
 
 // Connect to NATS
 nc, err := nats.Connect(cfg.NATSUrl)
@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"html/template"
 	"log"
+	"math"
 	"path/filepath"
+	"time"
 
 	"mailer_microservice/internal/contracts"
 )
@@ -23,6 +25,7 @@ type MailerService struct {
 	emailSender contracts.EmailSenderProvider
 	appBaseURL  string
 	TemplateDir string
+	metrics     Metrics
 }
 
 // NewMailerService creates a new mailer service.
@@ -31,6 +34,14 @@ func NewMailerService(emailSender contracts.EmailSenderProvider, baseURL string)
 		emailSender: emailSender,
 		appBaseURL:  baseURL,
 		TemplateDir: "internal/templates", // default template directory
+		metrics:     NoopMetrics{},
+	}
+}
+
+// SetMetrics enables email delivery metrics.
+func (s *MailerService) SetMetrics(metrics Metrics) {
+	if metrics != nil {
+		s.metrics = metrics
 	}
 }
 
@@ -60,7 +71,7 @@ func (s *MailerService) SendConfirmationEmail(ctx context.Context, email, token
 		return fmt.Errorf("failed to render confirmation template: %w", err)
 	}
 	log.Printf("[MailerService] 📩 sending confirmation email to %s with link: %s", email, link)
-	if err := s.emailSender.Send(email, "Confirm your subscription", body); err != nil {
+	if err := s.send("confirmation", email, "Confirm your subscription", body); err != nil {
 		log.Printf("[MailerService] ❌ failed to send confirmation email to %s: %v", email, err)
 		return err
 	}
@@ -75,12 +86,45 @@ func (s *MailerService) SendWeatherEmail(ctx context.Context, email, city string
 		Description    string
 		Temperature    float64
 		Humidity       float64
+		Condition      string
+		Severity       string
+		Icon           string
+		IconEmoji      string
+		Details        bool
+		FeelsLike      float64
+		WindSpeed      float64
+		WindDirection  string
+		Pressure       float64
+		Precipitation  float64
+		CloudCover     float64
+		UVIndex        string
+		Visibility     float64
+		Sunrise        string
+		Sunset         string
+		ObservedAt     string
 		UnsubscribeURL string
 	}{
-		City:           city,
-		Description:    weather.Description,
-		Temperature:    weather.Temperature,
-		Humidity:       weather.Humidity,
+		City:        city,
+		Description: weather.Description,
+		Temperature: weather.Temperature,
+		Humidity:    weather.Humidity,
+		Condition:   weather.Condition,
+		Severity:    weather.Severity,
+		Icon:        weather.Icon,
+		IconEmoji:   iconEmoji[weather.Icon],
+		// Старі повідомлення та gRPC-запити не містять розширених даних.
+		Details:        !weather.ObservedAt.IsZero() || weather.Pressure != 0,
+		FeelsLike:      weather.FeelsLike,
+		WindSpeed:      weather.WindSpeed,
+		WindDirection:  compassDirection(weather.WindDirection),
+		Pressure:       weather.Pressure,
+		Precipitation:  weather.Precipitation,
+		CloudCover:     weather.CloudCover,
+		UVIndex:        formatUVIndex(weather.UVIndex),
+		Visibility:     weather.Visibility,
+		Sunrise:        formatLocalTime(weather.Sunrise, weather.UTCOffset),
+		Sunset:         formatLocalTime(weather.Sunset, weather.UTCOffset),
+		ObservedAt:     formatLocalTime(weather.ObservedAt, weather.UTCOffset),
 		UnsubscribeURL: fmt.Sprintf("%s/api/unsubscribe/%s", s.appBaseURL, token),
 	}
 
@@ -93,7 +137,7 @@ func (s *MailerService) SendWeatherEmail(ctx context.Context, email, city string
 	subject := fmt.Sprintf("Weather Update for %s", city)
 	log.Printf("[MailerService] 📩 sending weather email to %s for city %s", email, city)
 
-	if err := s.emailSender.Send(email, subject, body); err != nil {
+	if err := s.send("weather", email, subject, body); err != nil {
 		log.Printf("[MailerService] ❌ failed to send weather email to %s: %v", email, err)
 		return err
 	}
@@ -102,8 +146,65 @@ func (s *MailerService) SendWeatherEmail(ctx context.Context, email, city string
 	return nil
 }
 
+// iconEmoji maps weather service icon identifiers to emoji, since emails
+// cannot rely on external images being loaded.
+var iconEmoji = map[string]string{
+	"clear-day":           "☀️",
+	"clear-night":         "🌙",
+	"partly-cloudy-day":   "⛅",
+	"partly-cloudy-night": "☁️",
+	"cloudy":              "☁️",
+	"fog":                 "🌫️",
+	"haze":                "🌫️",
+	"drizzle":             "🌦️",
+	"rain":                "🌧️",
+	"freezing-rain":       "🌧️",
+	"sleet":               "🌨️",
+	"snow":                "❄️",
+	"thunderstorm":        "⛈️",
+	"squall":              "🌪️",
+}
+
+// compassDirection turns wind direction in degrees into one of 8 compass points.
+func compassDirection(degrees float64) string {
+	points := [...]string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+	i := int(math.Round(math.Mod(degrees, 360)/45)) % len(points)
+	if i < 0 {
+		i += len(points)
+	}
+	return points[i]
+}
+
+// formatUVIndex formats the UV index, empty when the provider did not report it.
+func formatUVIndex(uv *float64) string {
+	if uv == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.1f", *uv)
+}
+
+// formatLocalTime formats time for the email in the city's local time, empty
+// when unknown. Without the city's UTC offset the time is shown in UTC.
+func formatLocalTime(t time.Time, utcOffset *int) string {
+	if t.IsZero() {
+		return ""
+	}
+	if utcOffset == nil {
+		return t.UTC().Format("15:04 UTC")
+	}
+	return t.In(time.FixedZone("", *utcOffset)).Format("15:04")
+}
+
 func (s *MailerService) SendEmail(ctx context.Context, to, subject, html string) error {
-	return s.emailSender.Send(to, subject, html)
+	return s.send("custom", to, subject, html)
+}
+
+// send delivers the email and records its metrics.
+func (s *MailerService) send(kind, to, subject, body string) error {
+	start := time.Now()
+	err := s.emailSender.Send(to, subject, body)
+	s.metrics.ObserveEmailSend(kind, time.Since(start), err)
+	return err
 }
 
 // renderTemplate renders HTML template with data.
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -107,6 +108,74 @@ func TestSendWeatherEmail(t *testing.T) {
 	assert.Contains(t, mockSender.LastBody, fmt.Sprintf("%s/api/unsubscribe/xyz789", testBaseURL))
 }
 
+// Реальний шаблон показує розширені дані лише тоді, коли вони є.
+func TestSendWeatherEmail_DetailedTemplate(t *testing.T) {
+	sender := mailer_service.NewMockSender()
+	svc := mailer_service.NewMailerService(sender, testBaseURL)
+	svc.SetTemplateDir("../templates")
+
+	uv, kyivOffset := 6.0, 3*60*60
+	detailed := contracts.WeatherData{
+		Description:   "Sunny",
+		Condition:     "clear",
+		Severity:      "none",
+		Icon:          "clear-day",
+		Temperature:   24,
+		Humidity:      40,
+		FeelsLike:     25.3,
+		WindSpeed:     3.4,
+		WindDirection: 225,
+		Pressure:      1016,
+		CloudCover:    10,
+		UVIndex:       &uv,
+		Visibility:    10,
+		Sunrise:       time.Date(2024, 6, 3, 1, 47, 0, 0, time.UTC),
+		Sunset:        time.Date(2024, 6, 3, 18, 5, 0, 0, time.UTC),
+		ObservedAt:    time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC),
+		UTCOffset:     &kyivOffset,
+	}
+	err := svc.SendWeatherEmail(context.Background(), "user@example.com", "Kyiv", detailed, "tok")
+	assert.NoError(t, err)
+	assert.Contains(t, sender.LastBody, "☀️ Weather Update for Kyiv")
+	assert.NotContains(t, sender.LastBody, `class="alert`)
+	assert.Contains(t, sender.LastBody, "25.3 °C")
+	assert.Contains(t, sender.LastBody, "3.4 m/s SW")
+	assert.Contains(t, sender.LastBody, "1016 hPa")
+	assert.Contains(t, sender.LastBody, "UV index:</strong> 6.0")
+	// Times are shown in Kyiv time.
+	assert.Contains(t, sender.LastBody, "04:47 / 21:05")
+	assert.Contains(t, sender.LastBody, "Observed at 12:00")
+
+	// Messages without the offset fall back to UTC.
+	detailed.UTCOffset = nil
+	err = svc.SendWeatherEmail(context.Background(), "user@example.com", "Kyiv", detailed, "tok")
+	assert.NoError(t, err)
+	assert.Contains(t, sender.LastBody, "01:47 UTC / 18:05 UTC")
+
+	err = svc.SendWeatherEmail(context.Background(), "user@example.com", "Kyiv", weatherData, "tok")
+	assert.NoError(t, err)
+	assert.Contains(t, sender.LastBody, "Cloudy")
+	assert.NotContains(t, sender.LastBody, "Pressure")
+	assert.NotContains(t, sender.LastBody, "Observed at")
+}
+
+func TestSendWeatherEmail_SevereWeatherAlert(t *testing.T) {
+	sender := mailer_service.NewMockSender()
+	svc := mailer_service.NewMailerService(sender, testBaseURL)
+	svc.SetTemplateDir("../templates")
+
+	storm := contracts.WeatherData{
+		Description: "Thunderstorm with heavy hail",
+		Condition:   "thunderstorm",
+		Severity:    "severe",
+		Icon:        "thunderstorm",
+	}
+	err := svc.SendWeatherEmail(context.Background(), "user@example.com", "Kyiv", storm, "tok")
+	assert.NoError(t, err)
+	assert.Contains(t, sender.LastBody, "⛈️ Weather Update for Kyiv")
+	assert.Contains(t, sender.LastBody, "Severe weather: Thunderstorm with heavy hail")
+}
+
 func TestSendWeatherEmailWithTestUser(t *testing.T) {
 	resetMockSender()
 	testEmail := "test@example.com"
@@ -169,6 +238,32 @@ func TestMockSenderErrorHandling(t *testing.T) {
 	assert.Equal(t, 0, mockSender.GetSentEmailsCount())
 }
 
+// recordingMetrics remembers observed email sends.
+type recordingMetrics struct {
+	sends []string
+}
+
+func (m *recordingMetrics) ObserveEmailSend(kind string, duration time.Duration, err error) {
+	result := "sent"
+	if err != nil {
+		result = "failed"
+	}
+	m.sends = append(m.sends, kind+"/"+result)
+}
+
+func TestSendEmailRecordsMetrics(t *testing.T) {
+	resetMockSender()
+	metrics := &recordingMetrics{}
+	service.SetMetrics(metrics)
+	defer service.SetMetrics(mailer_service.NoopMetrics{})
+
+	assert.NoError(t, service.SendWeatherEmail(context.Background(), "user@example.com", "Kyiv", weatherData, "token"))
+	mockSender.SetShouldFail(true)
+	assert.Error(t, service.SendConfirmationEmail(context.Background(), "user@example.com", "token"))
+
+	assert.Equal(t, []string{"weather/sent", "confirmation/failed"}, metrics.sends)
+}
+
 func TestMockSenderReset(t *testing.T) {
 	resetMockSender()
 
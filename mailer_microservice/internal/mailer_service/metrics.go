@@ -0,0 +1,14 @@
+package mailer_service
+
+import "time"
+
+// Metrics collects email delivery metrics.
+type Metrics interface {
+	// ObserveEmailSend records a send attempt; kind is "confirmation", "weather" or "custom".
+	ObserveEmailSend(kind string, duration time.Duration, err error)
+}
+
+// NoopMetrics is an empty implementation used when metrics are not needed.
+type NoopMetrics struct{}
+
+func (NoopMetrics) ObserveEmailSend(kind string, duration time.Duration, err error) {}
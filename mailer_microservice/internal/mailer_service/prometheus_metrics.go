@@ -0,0 +1,42 @@
+package mailer_service
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type PrometheusMetrics struct {
+	emails       *prometheus.CounterVec
+	sendDuration *prometheus.HistogramVec
+}
+
+func NewPrometheusMetrics() *PrometheusMetrics {
+	return &PrometheusMetrics{
+		emails: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mailer_emails_total",
+			Help: "Total number of emails by type and result (sent, failed)",
+		}, []string{"type", "result"}),
+		sendDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "mailer_email_send_duration_seconds",
+			Help:    "Duration of sending an email through SMTP",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"type"}),
+	}
+}
+
+func (m *PrometheusMetrics) Register() {
+	prometheus.MustRegister(
+		m.emails,
+		m.sendDuration,
+	)
+}
+
+func (m *PrometheusMetrics) ObserveEmailSend(kind string, duration time.Duration, err error) {
+	result := "sent"
+	if err != nil {
+		result = "failed"
+	}
+	m.emails.WithLabelValues(kind, result).Inc()
+	m.sendDuration.WithLabelValues(kind).Observe(duration.Seconds())
+}
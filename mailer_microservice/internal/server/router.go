@@ -4,6 +4,8 @@ import (
 	"mailer_microservice/gen/go/mailer/v1/mailerv1connect"
 	"mailer_microservice/internal/mailer_service"
 	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 func NewRouter(service *mailer_service.MailerService) *http.ServeMux {
@@ -13,5 +15,6 @@ func NewRouter(service *mailer_service.MailerService) *http.ServeMux {
 	path, handler := mailerv1connect.NewMailerServiceHandler(srv)
 
 	mux.Handle(path, handler)
+	mux.Handle("/metrics", promhttp.Handler())
 	return mux
 }
@@ -11,7 +11,7 @@ import (
 
 func main() {
 	app := application.NewApp()
-	// Start /health endpoint on 8092
+	// Start /health and /metrics endpoints on 8092
 	health.StartHealthServer(app.GetConfig().Port)
 	
 	app.Run()
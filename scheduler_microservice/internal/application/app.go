@@ -25,7 +25,7 @@ func NewApp() *App {
 	httpClient := http.DefaultClient
 
 	subClient := clients.NewSubscriptionClient(httpClient, cfg.SubscriptionURL)
-	weatherClient := clients.NewWeatherHttpClient(cfg.WeatherServiceURL)
+	weatherClient := clients.NewWeatherHttpClient(cfg.WeatherServiceURL, cfg.WeatherAPIKey)
 
 	// 🔄 Замість mailerClient — підключення до NATS
 	natsClient, err := broker.NewNATSClient(cfg.NATSUrl)
@@ -35,6 +35,9 @@ func NewApp() *App {
 
 	// 🆕 передаємо NATS publisher замість mailer
 	s := scheduler.NewScheduler(subClient, natsClient, weatherClient)
+	metrics := scheduler.NewPrometheusMetrics()
+	metrics.Register()
+	s.SetMetrics(metrics)
 
 	return &App{
 		scheduler:  s,
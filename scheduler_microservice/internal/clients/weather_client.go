@@ -12,12 +12,16 @@ import (
 
 type weatherHttpClient struct {
 	baseURL    string
+	apiKey     string
 	httpClient *http.Client
 }
 
-func NewWeatherHttpClient(baseURL string) *weatherHttpClient {
+// NewWeatherHttpClient creates a weather service client. apiKey is sent in
+// X-API-Key when the weather service requires API keys; empty sends none.
+func NewWeatherHttpClient(baseURL, apiKey string) *weatherHttpClient {
 	return &weatherHttpClient{
 		baseURL:    baseURL,
+		apiKey:     apiKey,
 		httpClient: &http.Client{Timeout: 10 * time.Second},
 	}
 }
@@ -27,6 +31,9 @@ func (c *weatherHttpClient) GetWeather(ctx context.Context, city string) (*contr
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
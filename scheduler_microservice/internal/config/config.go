@@ -9,6 +9,7 @@ type Config struct {
 	MailerServiceURL  string
 	SubscriptionURL   string
 	WeatherServiceURL string
+	WeatherAPIKey     string
 	NATSUrl           string
 }
 
@@ -18,6 +19,7 @@ func Load() (*Config, error) {
 		MailerServiceURL:  getEnv("MAILER_SERVICE_URL", "http://mailer_service:8089"),
 		SubscriptionURL:   getEnv("SUBSCRIPTION_SERVICE_URL", "http://subscription_service:8091"),
 		WeatherServiceURL: getEnv("WEATHER_SERVICE_URL", "http://weather_service:8080"),
+		WeatherAPIKey:     getEnv("WEATHER_SERVICE_API_KEY", ""),
 		NATSUrl:           getEnv("NATS_URL", "nats://localhost:4222"),
 	}
 	return cfg, nil
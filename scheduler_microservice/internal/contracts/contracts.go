@@ -11,6 +11,24 @@ type WeatherData struct {
 	Temperature float64 `json:"temperature"`
 	Humidity    float64 `json:"humidity"`
 	Description string  `json:"description"`
+	// Нормалізовані умови: condition (rain, snow…), severity (none…severe) та іконка.
+	Condition string `json:"condition,omitempty"`
+	Severity  string `json:"severity,omitempty"`
+	Icon      string `json:"icon,omitempty"`
+	// Розширені дані погоди; відсутні у старих повідомленнях.
+	FeelsLike     float64   `json:"feels_like"`
+	WindSpeed     float64   `json:"wind_speed"`     // м/с
+	WindDirection float64   `json:"wind_direction"` // градуси
+	Pressure      float64   `json:"pressure"`       // гПа
+	Precipitation float64   `json:"precipitation"`  // мм
+	CloudCover    float64   `json:"cloud_cover"`    // %
+	UVIndex       *float64  `json:"uv_index,omitempty"`
+	Visibility    float64   `json:"visibility"` // км
+	Sunrise       time.Time `json:"sunrise"`
+	Sunset        time.Time `json:"sunset"`
+	ObservedAt    time.Time `json:"observed_at"`
+	// Зсув міста від UTC у секундах; відсутній у старих повідомленнях.
+	UTCOffset *int `json:"utc_offset,omitempty"`
 }
 
 type Subscription struct {
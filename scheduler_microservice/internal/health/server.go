@@ -3,13 +3,17 @@ package health
 import (
 	"fmt"
 	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+// StartHealthServer serves /health and the Prometheus /metrics endpoint.
 func StartHealthServer(port string) {
 	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = fmt.Fprintln(w, `{"status":"ok"}`)
 	})
+	http.Handle("/metrics", promhttp.Handler())
 	go func() {
 		if err := http.ListenAndServe(":"+port, nil); err != nil && err != http.ErrServerClosed {
 			fmt.Printf("Health server failed: %v\n", err)
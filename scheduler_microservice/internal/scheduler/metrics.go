@@ -0,0 +1,17 @@
+package scheduler
+
+import "time"
+
+// Metrics collects scheduled send metrics.
+type Metrics interface {
+	// ObserveRun records a scheduled send; err is set when subscriptions could not be loaded.
+	ObserveRun(frequency string, duration time.Duration, err error)
+	// IncNotifications counts processed subscriptions by result.
+	IncNotifications(frequency, result string)
+}
+
+// NoopMetrics is an empty implementation used when metrics are not needed.
+type NoopMetrics struct{}
+
+func (NoopMetrics) ObserveRun(frequency string, duration time.Duration, err error) {}
+func (NoopMetrics) IncNotifications(frequency, result string)                      {}
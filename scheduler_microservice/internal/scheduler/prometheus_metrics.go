@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type PrometheusMetrics struct {
+	runs          *prometheus.CounterVec
+	runDuration   *prometheus.HistogramVec
+	notifications *prometheus.CounterVec
+}
+
+func NewPrometheusMetrics() *PrometheusMetrics {
+	return &PrometheusMetrics{
+		runs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "scheduler_runs_total",
+			Help: "Total number of scheduled sends by frequency and result",
+		}, []string{"frequency", "result"}),
+		runDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "scheduler_run_duration_seconds",
+			Help:    "Duration of scheduled sends",
+			Buckets: []float64{0.1, 0.5, 1, 2.5, 5, 10, 30},
+		}, []string{"frequency"}),
+		notifications: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "scheduler_notifications_total",
+			Help: "Total number of processed subscriptions by result (published, weather_error, marshal_error, publish_error)",
+		}, []string{"frequency", "result"}),
+	}
+}
+
+func (m *PrometheusMetrics) Register() {
+	prometheus.MustRegister(
+		m.runs,
+		m.runDuration,
+		m.notifications,
+	)
+}
+
+func (m *PrometheusMetrics) ObserveRun(frequency string, duration time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	m.runs.WithLabelValues(frequency, result).Inc()
+	m.runDuration.WithLabelValues(frequency).Observe(duration.Seconds())
+}
+
+func (m *PrometheusMetrics) IncNotifications(frequency, result string) {
+	m.notifications.WithLabelValues(frequency, result).Inc()
+}
@@ -26,6 +26,7 @@ type Scheduler struct {
 	subSvc     SubscriptionService
 	mailPub    MailPublisher
 	weatherSvc WeatherService
+	metrics    Metrics
 	stopChan   chan struct{}
 }
 
@@ -34,10 +35,18 @@ func NewScheduler(subSvc SubscriptionService, mailPub MailPublisher, weatherSvc
 		subSvc:     subSvc,
 		mailPub:    mailPub,
 		weatherSvc: weatherSvc,
+		metrics:    NoopMetrics{},
 		stopChan:   make(chan struct{}),
 	}
 }
 
+// SetMetrics enables scheduled send metrics.
+func (s *Scheduler) SetMetrics(metrics Metrics) {
+	if metrics != nil {
+		s.metrics = metrics
+	}
+}
+
 func (s *Scheduler) Start() {
 	go s.run()
 }
@@ -70,11 +79,14 @@ func (s *Scheduler) Send(freq string) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	start := time.Now()
 	subs, err := s.subSvc.GetConfirmed(ctx, freq)
 	if err != nil {
 		log.Printf("Error getting subscriptions: %v", err)
+		s.metrics.ObserveRun(freq, time.Since(start), err)
 		return
 	}
+	defer func() { s.metrics.ObserveRun(freq, time.Since(start), nil) }()
 
 	const maxWorkers = 10
 	sem := make(chan struct{}, maxWorkers)
@@ -100,6 +112,7 @@ func (s *Scheduler) processSubscription(ctx context.Context, sub *contracts.Subs
 	weather, err := s.weatherSvc.GetWeather(ctx, sub.City)
 	if err != nil {
 		log.Printf("Weather error for %s: %v", sub.City, err)
+		s.metrics.IncNotifications(freq, "weather_error")
 		return
 	}
 
@@ -114,13 +127,16 @@ func (s *Scheduler) processSubscription(ctx context.Context, sub *contracts.Subs
 	payload, err := json.Marshal(msg)
 	if err != nil {
 		log.Printf("Failed to marshal notification for %s: %v", sub.Email, err)
+		s.metrics.IncNotifications(freq, "marshal_error")
 		return
 	}
 
 	if err := s.mailPub.Publish("mailer.notifications", payload); err != nil {
 		log.Printf("Failed to publish notification to %s: %v", sub.Email, err)
+		s.metrics.IncNotifications(freq, "publish_error")
 		return
 	}
+	s.metrics.IncNotifications(freq, "published")
 
 	log.Printf("📤 Published %s weather update for %s", freq, sub.Email)
 }
@@ -4,7 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"scheduler_microservice/internal/contracts"
 	"scheduler_microservice/internal/scheduler"
@@ -94,3 +97,54 @@ func TestScheduler_Send_WeatherError(t *testing.T) {
 	weatherSvc.AssertExpectations(t)
 	mailPub.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
 }
+
+// countingMetrics counts scheduled runs and notification results.
+type countingMetrics struct {
+	mu            sync.Mutex
+	runs          int
+	notifications map[string]int
+}
+
+func (m *countingMetrics) ObserveRun(frequency string, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.runs++
+}
+
+func (m *countingMetrics) IncNotifications(frequency, result string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.notifications == nil {
+		m.notifications = make(map[string]int)
+	}
+	m.notifications[frequency+"/"+result]++
+}
+
+func TestScheduler_Send_RecordsMetrics(t *testing.T) {
+	subs := []*contracts.Subscription{
+		{Email: "ok@example.com", City: "Kyiv", Token: "ok"},
+		{Email: "fail@example.com", City: "Odesa", Token: "fail"},
+	}
+
+	subSvc := new(mockSubSvc)
+	weatherSvc := new(mockWeatherSvc)
+	mailPub := new(mockPublisher)
+
+	subSvc.On("GetConfirmed", mock.Anything, "hourly").Return(subs, nil)
+	weatherSvc.On("GetWeather", mock.Anything, "Kyiv").Return(&contracts.WeatherData{Description: "Clear sky"}, nil)
+	weatherSvc.On("GetWeather", mock.Anything, "Odesa").Return(nil, errors.New("weather error"))
+	mailPub.On("Publish", "mailer.notifications", mock.Anything).Return(nil)
+
+	metrics := &countingMetrics{}
+	s := scheduler.NewScheduler(subSvc, mailPub, weatherSvc)
+	s.SetMetrics(metrics)
+	s.Send("hourly")
+
+	if metrics.runs != 1 {
+		t.Errorf("expected 1 run, got %d", metrics.runs)
+	}
+	want := map[string]int{"hourly/published": 1, "hourly/weather_error": 1}
+	if !reflect.DeepEqual(metrics.notifications, want) {
+		t.Errorf("expected notifications %v, got %v", want, metrics.notifications)
+	}
+}
@@ -23,7 +23,9 @@ import (
 
 	subscriptionv1 "subscription_microservice/gen/go/subscription/v1/subscriptionv1connect"
 
+	"connectrpc.com/connect"
 	"connectrpc.com/grpcreflect"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 type App struct {
@@ -56,14 +58,23 @@ func NewApp(cfg *config.Config) (*App, error) {
 	// Repositories & Services
 	subRepo := repositories.NewSubscriptionRepo(db)
 	subService := subscription_service.New(subRepo, natsClient)
+	serviceMetrics := subscription_service.NewPrometheusMetrics()
+	serviceMetrics.Register()
+	subService.SetMetrics(serviceMetrics)
 
 	// Handlers
 	grpcServer := grpc.NewServer()
 	reflection.Register(grpcServer)
 
 	httpMux := http.NewServeMux()
-	path, connectHandler := subscriptionv1.NewSubscriptionServiceHandler(handler.NewHandler(&subService))
+	rpcMetrics := handler.NewPrometheusMetrics()
+	rpcMetrics.Register()
+	path, connectHandler := subscriptionv1.NewSubscriptionServiceHandler(
+		handler.NewHandler(&subService),
+		connect.WithInterceptors(handler.MetricsInterceptor(rpcMetrics)),
+	)
 	httpMux.Handle(path, connectHandler)
+	httpMux.Handle("/metrics", promhttp.Handler())
 
 	reflectPath, reflectHandler := grpcreflect.NewHandlerV1(
 		grpcreflect.NewStaticReflector("subscription.v1.SubscriptionService"),
@@ -34,6 +34,16 @@ func (r *SubscriptionRepo) GetConfirmed(ctx context.Context, frequency string) (
 	return subs, err
 }
 
+func (r *SubscriptionRepo) ListCities(ctx context.Context, frequency string) ([]string, error) {
+	var cities []string
+	q := r.db.NewSelect().Model((*models.Subscription)(nil)).Distinct().Column("city").Where("confirmed = TRUE")
+	if frequency != "" {
+		q = q.Where("frequency = ?", frequency)
+	}
+	err := q.Order("city").Scan(ctx, &cities)
+	return cities, err
+}
+
 func (r *SubscriptionRepo) Create(ctx context.Context, data models.Subscription) error {
 	_, err := r.db.NewInsert().Model(&data).Exec(ctx)
 	return err
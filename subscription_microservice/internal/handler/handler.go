@@ -86,3 +86,16 @@ func (h *SubscriptionHandler) GetConfirmed(
 		Subscriptions: result,
 	}), nil
 }
+
+func (h *SubscriptionHandler) ListCities(
+	ctx context.Context,
+	req *connect.Request[subscriptionv1.ListCitiesRequest],
+) (*connect.Response[subscriptionv1.ListCitiesResponse], error) {
+	cities, err := h.impl.ListCities(ctx, req.Msg.Frequency)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&subscriptionv1.ListCitiesResponse{
+		Cities: cities,
+	}), nil
+}
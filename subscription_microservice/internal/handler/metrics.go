@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+// Metrics collects RPC request metrics.
+type Metrics interface {
+	// ObserveRPC records a served call; code is "ok" or the Connect error code.
+	ObserveRPC(procedure, code string, duration time.Duration)
+}
+
+// NoopMetrics is an empty implementation used when metrics are not needed.
+type NoopMetrics struct{}
+
+func (NoopMetrics) ObserveRPC(procedure, code string, duration time.Duration) {}
+
+// MetricsInterceptor записує тривалість і код відповіді кожного виклику.
+func MetricsInterceptor(metrics Metrics) connect.UnaryInterceptorFunc {
+	if metrics == nil {
+		metrics = NoopMetrics{}
+	}
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			start := time.Now()
+			res, err := next(ctx, req)
+			code := "ok"
+			if err != nil {
+				code = connect.CodeOf(err).String()
+			}
+			metrics.ObserveRPC(req.Spec().Procedure, code, time.Since(start))
+			return res, err
+		}
+	}
+}
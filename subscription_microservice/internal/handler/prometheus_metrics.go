@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type PrometheusMetrics struct {
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+}
+
+func NewPrometheusMetrics() *PrometheusMetrics {
+	return &PrometheusMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "subscription_rpc_requests_total",
+			Help: "Total number of RPC requests by procedure and code",
+		}, []string{"procedure", "code"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "subscription_rpc_request_duration_seconds",
+			Help:    "Duration of RPC requests",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"procedure"}),
+	}
+}
+
+func (m *PrometheusMetrics) Register() {
+	prometheus.MustRegister(
+		m.requests,
+		m.requestDuration,
+	)
+}
+
+func (m *PrometheusMetrics) ObserveRPC(procedure, code string, duration time.Duration) {
+	m.requests.WithLabelValues(procedure, code).Inc()
+	m.requestDuration.WithLabelValues(procedure).Observe(duration.Seconds())
+}
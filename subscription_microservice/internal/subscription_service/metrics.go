@@ -0,0 +1,12 @@
+package subscription_service
+
+// Metrics collects subscription lifecycle metrics.
+type Metrics interface {
+	// IncSubscriptionEvents counts subscriptions created, confirmed and unsubscribed.
+	IncSubscriptionEvents(event string)
+}
+
+// NoopMetrics is an empty implementation used when metrics are not needed.
+type NoopMetrics struct{}
+
+func (NoopMetrics) IncSubscriptionEvents(event string) {}
@@ -0,0 +1,24 @@
+package subscription_service
+
+import "github.com/prometheus/client_golang/prometheus"
+
+type PrometheusMetrics struct {
+	events *prometheus.CounterVec
+}
+
+func NewPrometheusMetrics() *PrometheusMetrics {
+	return &PrometheusMetrics{
+		events: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "subscription_events_total",
+			Help: "Total number of subscription lifecycle events (created, confirmed, unsubscribed)",
+		}, []string{"event"}),
+	}
+}
+
+func (m *PrometheusMetrics) Register() {
+	prometheus.MustRegister(m.events)
+}
+
+func (m *PrometheusMetrics) IncSubscriptionEvents(event string) {
+	m.events.WithLabelValues(event).Inc()
+}
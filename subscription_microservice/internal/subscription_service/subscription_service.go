@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"log"
 	"net/mail"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,6 +19,7 @@ type subscriptionRepo interface {
 	GetByEmail(ctx context.Context, email string) (models.Subscription, error)
 	GetByToken(ctx context.Context, token string) (models.Subscription, error)
 	GetConfirmed(ctx context.Context, frequency string) ([]models.Subscription, error)
+	ListCities(ctx context.Context, frequency string) ([]string, error)
 	Create(ctx context.Context, data models.Subscription) error
 	Update(ctx context.Context, data models.Subscription) error
 	Delete(ctx context.Context, token string) error
@@ -27,15 +29,26 @@ type messageBroker interface {
 	Publish(subject string, data []byte) error
 }
 
+var validFrequencies = map[string]bool{"daily": true, "hourly": true}
+
 type SubscriptionService struct {
 	subRepo subscriptionRepo
 	broker  messageBroker
+	metrics Metrics
 }
 
 func New(sr subscriptionRepo, broker messageBroker) SubscriptionService {
 	return SubscriptionService{
 		subRepo: sr,
 		broker:  broker,
+		metrics: NoopMetrics{},
+	}
+}
+
+// SetMetrics вмикає метрики підписок.
+func (s *SubscriptionService) SetMetrics(metrics Metrics) {
+	if metrics != nil {
+		s.metrics = metrics
 	}
 }
 
@@ -53,8 +66,7 @@ func (s SubscriptionService) Create(ctx context.Context, email, city, frequency
 	}
 
 	// Валідація frequency
-	validFreq := map[string]bool{"daily": true, "hourly": true}
-	if !validFreq[frequency] {
+	if !validFrequencies[frequency] {
 		return apierrors.ErrInvalidFrequency
 	}
 	existing, err := s.subRepo.GetByEmail(ctx, email)
@@ -77,6 +89,7 @@ func (s SubscriptionService) Create(ctx context.Context, email, city, frequency
 	if err := s.subRepo.Create(ctx, subscription); err != nil {
 		return err
 	}
+	s.metrics.IncSubscriptionEvents("created")
 
 	notif := contracts.NotificationMessage{
 		Type:  "confirmation",
@@ -112,7 +125,11 @@ func (s SubscriptionService) Confirm(ctx context.Context, token string) error {
 	subscription.Confirmed = true
 	subscription.ConfirmedAt = time.Now()
 
-	return s.subRepo.Update(ctx, subscription)
+	if err := s.subRepo.Update(ctx, subscription); err != nil {
+		return err
+	}
+	s.metrics.IncSubscriptionEvents("confirmed")
+	return nil
 }
 
 func (s SubscriptionService) Delete(ctx context.Context, token string) error {
@@ -124,6 +141,7 @@ func (s SubscriptionService) Delete(ctx context.Context, token string) error {
 	if err != nil {
 		return err
 	}
+	s.metrics.IncSubscriptionEvents("unsubscribed")
 
 	return nil
 }
@@ -151,3 +169,30 @@ func (s SubscriptionService) GetConfirmed(ctx context.Context, frequency string)
 
 	return converted, nil
 }
+
+// ListCities повертає унікальні міста підтверджених підписок.
+// Порожня frequency означає всі частоти; назви, що відрізняються лише регістром
+// чи пробілами, вважаються одним містом.
+func (s SubscriptionService) ListCities(ctx context.Context, frequency string) ([]string, error) {
+	if frequency != "" && !validFrequencies[frequency] {
+		return nil, apierrors.ErrInvalidFrequency
+	}
+
+	cities, err := s.subRepo.ListCities(ctx, frequency)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(cities))
+	unique := make([]string, 0, len(cities))
+	for _, city := range cities {
+		city = strings.TrimSpace(city)
+		key := strings.ToLower(city)
+		if city == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, city)
+	}
+	return unique, nil
+}
@@ -29,6 +29,18 @@ func (m *messageBrokerMock) SendConfirmationEmail(ctx context.Context, email, to
 	return args.Error(0)
 }
 
+// countingMetrics counts subscription events.
+type countingMetrics struct {
+	events map[string]int
+}
+
+func (m *countingMetrics) IncSubscriptionEvents(event string) {
+	if m.events == nil {
+		m.events = make(map[string]int)
+	}
+	m.events[event]++
+}
+
 // subscriptionRepoMock implements the subscription repository interface.
 type subscriptionRepoMock struct {
 	mock.Mock
@@ -58,6 +70,14 @@ func (m *subscriptionRepoMock) GetConfirmed(ctx context.Context, frequency strin
 	return nil, args.Error(1)
 }
 
+func (m *subscriptionRepoMock) ListCities(ctx context.Context, frequency string) ([]string, error) {
+	args := m.Called(ctx, frequency)
+	if cities, ok := args.Get(0).([]string); ok {
+		return cities, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *subscriptionRepoMock) Create(ctx context.Context, data models.Subscription) error {
 	args := m.Called(ctx, data)
 	return args.Error(0)
@@ -277,22 +297,28 @@ func TestDeleteSubscription(t *testing.T) {
 		repo := &subscriptionRepoMock{}
 		broker := &messageBrokerMock{}
 		svc := New(repo, broker)
+		metrics := &countingMetrics{}
+		svc.SetMetrics(metrics)
 		repo.On("Delete", ctx, validToken).Return(errors.New("delete error"))
 
 		err := svc.Delete(ctx, validToken)
 		require.EqualError(t, err, "delete error")
 		repo.AssertCalled(t, "Delete", ctx, validToken)
+		require.Empty(t, metrics.events)
 	})
 
 	t.Run("OK", func(t *testing.T) {
 		repo := &subscriptionRepoMock{}
 		broker := &messageBrokerMock{}
 		svc := New(repo, broker)
+		metrics := &countingMetrics{}
+		svc.SetMetrics(metrics)
 		repo.On("Delete", ctx, validToken).Return(nil)
 
 		err := svc.Delete(ctx, validToken)
 		require.NoError(t, err)
 		repo.AssertCalled(t, "Delete", ctx, validToken)
+		require.Equal(t, map[string]int{"unsubscribed": 1}, metrics.events)
 	})
 }
 
@@ -362,3 +388,39 @@ func TestGetConfirmedSubscription(t *testing.T) {
 		repo.AssertCalled(t, "GetConfirmed", ctx, "daily")
 	})
 }
+
+func TestListCities(t *testing.T) {
+	t.Run("InvalidFrequency", func(t *testing.T) {
+		ctx := context.Background()
+		repo := &subscriptionRepoMock{}
+		svc := New(repo, &messageBrokerMock{})
+
+		cities, err := svc.ListCities(ctx, "weekly")
+		require.ErrorIs(t, err, apierrors.ErrInvalidFrequency)
+		require.Nil(t, cities)
+		repo.AssertNotCalled(t, "ListCities", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		ctx := context.Background()
+		repo := &subscriptionRepoMock{}
+		svc := New(repo, &messageBrokerMock{})
+
+		repo.On("ListCities", ctx, "hourly").Return(nil, errors.New("db error"))
+
+		_, err := svc.ListCities(ctx, "hourly")
+		require.Error(t, err)
+	})
+
+	t.Run("OK_Deduplicated", func(t *testing.T) {
+		ctx := context.Background()
+		repo := &subscriptionRepoMock{}
+		svc := New(repo, &messageBrokerMock{})
+
+		repo.On("ListCities", ctx, "").Return([]string{"Kyiv", "kyiv ", "Lviv", " "}, nil)
+
+		cities, err := svc.ListCities(ctx, "")
+		require.NoError(t, err)
+		require.Equal(t, []string{"Kyiv", "Lviv"}, cities)
+	})
+}
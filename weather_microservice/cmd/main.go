@@ -11,12 +11,14 @@ import (
 	"syscall"
 	"time"
 
+	"connectrpc.com/connect"
 	"golang.org/x/net/http2"
 
 	"weather_microservice/gen/go/weather/v1/weatherv1connect"
 	"weather_microservice/internal/bootstrap"
 	"weather_microservice/internal/config"
 	"weather_microservice/internal/server"
+	"weather_microservice/internal/server/middleware"
 )
 
 func main() {
@@ -26,8 +28,14 @@ func main() {
 		log.Fatalf("Failed to initialize weather service: %v", err)
 	}
 
+	auth, err := bootstrap.InitAuthenticator(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize API key authentication: %v", err)
+	}
+
 	// HTTP API
-	httpRouter := server.NewRouter(cfg, weatherService)
+	rateLimitStore := bootstrap.InitRateLimitStore(cfg)
+	httpRouter := server.NewRouter(cfg, weatherService, rateLimitStore, auth)
 	httpSrv := &http.Server{
 		Addr:         ":" + cfg.Port,
 		Handler:      httpRouter,
@@ -39,9 +47,17 @@ func main() {
 	// gRPC (ConnectRPC) API over HTTP/2 prior knowledge (no TLS)
 	path, handler := weatherv1connect.NewWeatherServiceHandler(
 		server.NewGRPCWeatherServer(weatherService),
+		connect.WithInterceptors(server.NewAPIKeyInterceptor(cfg, rateLimitStore, auth)),
 	)
 	grpcMux := http.NewServeMux()
 	grpcMux.Handle(path, handler)
+	// Watch streams outlive the server write timeout.
+	grpcMux.Handle(weatherv1connect.WeatherServiceWatchWeatherProcedure, middleware.NoWriteTimeout()(handler))
+	adminPath, adminHandler := weatherv1connect.NewWeatherAdminServiceHandler(
+		server.NewGRPCAdminServer(weatherService),
+		connect.WithInterceptors(middleware.AdminAuthInterceptor(cfg.AdminToken)),
+	)
+	grpcMux.Handle(adminPath, adminHandler)
 	grpcSrv := &http.Server{
 		Handler: grpcMux,
 		ReadTimeout:  10 * time.Second,
@@ -59,6 +75,11 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	// Warm the cache of subscribed cities before scheduled sends
+	if warmer := bootstrap.InitCacheWarmer(cfg, weatherService); warmer != nil {
+		go warmer.Run(ctx)
+	}
+
 	// Start HTTP API
 	go func() {
 		log.Println("Starting HTTP service on:", cfg.Port)
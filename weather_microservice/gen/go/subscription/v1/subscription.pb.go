@@ -366,6 +366,95 @@ func (x *GetConfirmedResponse) GetSubscriptions() []*Subscription {
 	return nil
 }
 
+// Empty frequency lists cities of all confirmed subscriptions.
+type ListCitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Frequency     string                 `protobuf:"bytes,1,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCitiesRequest) Reset() {
+	*x = ListCitiesRequest{}
+	mi := &file_subscription_v1_subscription_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCitiesRequest) ProtoMessage() {}
+
+func (x *ListCitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscription_v1_subscription_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCitiesRequest.ProtoReflect.Descriptor instead.
+func (*ListCitiesRequest) Descriptor() ([]byte, []int) {
+	return file_subscription_v1_subscription_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListCitiesRequest) GetFrequency() string {
+	if x != nil {
+		return x.Frequency
+	}
+	return ""
+}
+
+type ListCitiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cities        []string               `protobuf:"bytes,1,rep,name=cities,proto3" json:"cities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCitiesResponse) Reset() {
+	*x = ListCitiesResponse{}
+	mi := &file_subscription_v1_subscription_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCitiesResponse) ProtoMessage() {}
+
+func (x *ListCitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscription_v1_subscription_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCitiesResponse.ProtoReflect.Descriptor instead.
+func (*ListCitiesResponse) Descriptor() ([]byte, []int) {
+	return file_subscription_v1_subscription_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListCitiesResponse) GetCities() []string {
+	if x != nil {
+		return x.Cities
+	}
+	return nil
+}
+
 type Subscription struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -382,7 +471,7 @@ type Subscription struct {
 
 func (x *Subscription) Reset() {
 	*x = Subscription{}
-	mi := &file_subscription_v1_subscription_proto_msgTypes[8]
+	mi := &file_subscription_v1_subscription_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -394,7 +483,7 @@ func (x *Subscription) String() string {
 func (*Subscription) ProtoMessage() {}
 
 func (x *Subscription) ProtoReflect() protoreflect.Message {
-	mi := &file_subscription_v1_subscription_proto_msgTypes[8]
+	mi := &file_subscription_v1_subscription_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -407,7 +496,7 @@ func (x *Subscription) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Subscription.ProtoReflect.Descriptor instead.
 func (*Subscription) Descriptor() ([]byte, []int) {
-	return file_subscription_v1_subscription_proto_rawDescGZIP(), []int{8}
+	return file_subscription_v1_subscription_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Subscription) GetId() uint64 {
@@ -485,7 +574,11 @@ const file_subscription_v1_subscription_proto_rawDesc = "" +
 	"\x13GetConfirmedRequest\x12\x1c\n" +
 	"\tfrequency\x18\x01 \x01(\tR\tfrequency\"[\n" +
 	"\x14GetConfirmedResponse\x12C\n" +
-	"\rsubscriptions\x18\x01 \x03(\v2\x1d.subscription.v1.SubscriptionR\rsubscriptions\"\x94\x02\n" +
+	"\rsubscriptions\x18\x01 \x03(\v2\x1d.subscription.v1.SubscriptionR\rsubscriptions\"1\n" +
+	"\x11ListCitiesRequest\x12\x1c\n" +
+	"\tfrequency\x18\x01 \x01(\tR\tfrequency\",\n" +
+	"\x12ListCitiesResponse\x12\x16\n" +
+	"\x06cities\x18\x01 \x03(\tR\x06cities\"\x94\x02\n" +
 	"\fSubscription\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
@@ -495,12 +588,14 @@ const file_subscription_v1_subscription_proto_rawDesc = "" +
 	"\tconfirmed\x18\x06 \x01(\bR\tconfirmed\x129\n" +
 	"\n" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
-	"\fconfirmed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vconfirmedAt2\xde\x02\n" +
+	"\fconfirmed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vconfirmedAt2\xb7\x03\n" +
 	"\x13SubscriptionService\x12K\n" +
 	"\x06Create\x12\x1e.subscription.v1.CreateRequest\x1a\x1f.subscription.v1.CreateResponse\"\x00\x12N\n" +
 	"\aConfirm\x12\x1f.subscription.v1.ConfirmRequest\x1a .subscription.v1.ConfirmResponse\"\x00\x12K\n" +
 	"\x06Delete\x12\x1e.subscription.v1.DeleteRequest\x1a\x1f.subscription.v1.DeleteResponse\"\x00\x12]\n" +
-	"\fGetConfirmed\x12$.subscription.v1.GetConfirmedRequest\x1a%.subscription.v1.GetConfirmedResponse\"\x00B<Z:weather_microservice/gen/go/subscription/v1;subscriptionv1b\x06proto3"
+	"\fGetConfirmed\x12$.subscription.v1.GetConfirmedRequest\x1a%.subscription.v1.GetConfirmedResponse\"\x00\x12W\n" +
+	"\n" +
+	"ListCities\x12\".subscription.v1.ListCitiesRequest\x1a#.subscription.v1.ListCitiesResponse\"\x00B<Z:weather_microservice/gen/go/subscription/v1;subscriptionv1b\x06proto3"
 
 var (
 	file_subscription_v1_subscription_proto_rawDescOnce sync.Once
@@ -514,7 +609,7 @@ func file_subscription_v1_subscription_proto_rawDescGZIP() []byte {
 	return file_subscription_v1_subscription_proto_rawDescData
 }
 
-var file_subscription_v1_subscription_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_subscription_v1_subscription_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_subscription_v1_subscription_proto_goTypes = []any{
 	(*CreateRequest)(nil),         // 0: subscription.v1.CreateRequest
 	(*CreateResponse)(nil),        // 1: subscription.v1.CreateResponse
@@ -524,26 +619,30 @@ var file_subscription_v1_subscription_proto_goTypes = []any{
 	(*DeleteResponse)(nil),        // 5: subscription.v1.DeleteResponse
 	(*GetConfirmedRequest)(nil),   // 6: subscription.v1.GetConfirmedRequest
 	(*GetConfirmedResponse)(nil),  // 7: subscription.v1.GetConfirmedResponse
-	(*Subscription)(nil),          // 8: subscription.v1.Subscription
-	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
+	(*ListCitiesRequest)(nil),     // 8: subscription.v1.ListCitiesRequest
+	(*ListCitiesResponse)(nil),    // 9: subscription.v1.ListCitiesResponse
+	(*Subscription)(nil),          // 10: subscription.v1.Subscription
+	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
 }
 var file_subscription_v1_subscription_proto_depIdxs = []int32{
-	8, // 0: subscription.v1.GetConfirmedResponse.subscriptions:type_name -> subscription.v1.Subscription
-	9, // 1: subscription.v1.Subscription.created_at:type_name -> google.protobuf.Timestamp
-	9, // 2: subscription.v1.Subscription.confirmed_at:type_name -> google.protobuf.Timestamp
-	0, // 3: subscription.v1.SubscriptionService.Create:input_type -> subscription.v1.CreateRequest
-	2, // 4: subscription.v1.SubscriptionService.Confirm:input_type -> subscription.v1.ConfirmRequest
-	4, // 5: subscription.v1.SubscriptionService.Delete:input_type -> subscription.v1.DeleteRequest
-	6, // 6: subscription.v1.SubscriptionService.GetConfirmed:input_type -> subscription.v1.GetConfirmedRequest
-	1, // 7: subscription.v1.SubscriptionService.Create:output_type -> subscription.v1.CreateResponse
-	3, // 8: subscription.v1.SubscriptionService.Confirm:output_type -> subscription.v1.ConfirmResponse
-	5, // 9: subscription.v1.SubscriptionService.Delete:output_type -> subscription.v1.DeleteResponse
-	7, // 10: subscription.v1.SubscriptionService.GetConfirmed:output_type -> subscription.v1.GetConfirmedResponse
-	7, // [7:11] is the sub-list for method output_type
-	3, // [3:7] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	10, // 0: subscription.v1.GetConfirmedResponse.subscriptions:type_name -> subscription.v1.Subscription
+	11, // 1: subscription.v1.Subscription.created_at:type_name -> google.protobuf.Timestamp
+	11, // 2: subscription.v1.Subscription.confirmed_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: subscription.v1.SubscriptionService.Create:input_type -> subscription.v1.CreateRequest
+	2,  // 4: subscription.v1.SubscriptionService.Confirm:input_type -> subscription.v1.ConfirmRequest
+	4,  // 5: subscription.v1.SubscriptionService.Delete:input_type -> subscription.v1.DeleteRequest
+	6,  // 6: subscription.v1.SubscriptionService.GetConfirmed:input_type -> subscription.v1.GetConfirmedRequest
+	8,  // 7: subscription.v1.SubscriptionService.ListCities:input_type -> subscription.v1.ListCitiesRequest
+	1,  // 8: subscription.v1.SubscriptionService.Create:output_type -> subscription.v1.CreateResponse
+	3,  // 9: subscription.v1.SubscriptionService.Confirm:output_type -> subscription.v1.ConfirmResponse
+	5,  // 10: subscription.v1.SubscriptionService.Delete:output_type -> subscription.v1.DeleteResponse
+	7,  // 11: subscription.v1.SubscriptionService.GetConfirmed:output_type -> subscription.v1.GetConfirmedResponse
+	9,  // 12: subscription.v1.SubscriptionService.ListCities:output_type -> subscription.v1.ListCitiesResponse
+	8,  // [8:13] is the sub-list for method output_type
+	3,  // [3:8] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_subscription_v1_subscription_proto_init() }
@@ -557,7 +656,7 @@ func file_subscription_v1_subscription_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_subscription_v1_subscription_proto_rawDesc), len(file_subscription_v1_subscription_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -45,6 +45,9 @@ const (
 	// SubscriptionServiceGetConfirmedProcedure is the fully-qualified name of the SubscriptionService's
 	// GetConfirmed RPC.
 	SubscriptionServiceGetConfirmedProcedure = "/subscription.v1.SubscriptionService/GetConfirmed"
+	// SubscriptionServiceListCitiesProcedure is the fully-qualified name of the SubscriptionService's
+	// ListCities RPC.
+	SubscriptionServiceListCitiesProcedure = "/subscription.v1.SubscriptionService/ListCities"
 )
 
 // SubscriptionServiceClient is a client for the subscription.v1.SubscriptionService service.
@@ -53,6 +56,7 @@ type SubscriptionServiceClient interface {
 	Confirm(context.Context, *connect.Request[v1.ConfirmRequest]) (*connect.Response[v1.ConfirmResponse], error)
 	Delete(context.Context, *connect.Request[v1.DeleteRequest]) (*connect.Response[v1.DeleteResponse], error)
 	GetConfirmed(context.Context, *connect.Request[v1.GetConfirmedRequest]) (*connect.Response[v1.GetConfirmedResponse], error)
+	ListCities(context.Context, *connect.Request[v1.ListCitiesRequest]) (*connect.Response[v1.ListCitiesResponse], error)
 }
 
 // NewSubscriptionServiceClient constructs a client for the subscription.v1.SubscriptionService
@@ -90,6 +94,12 @@ func NewSubscriptionServiceClient(httpClient connect.HTTPClient, baseURL string,
 			connect.WithSchema(subscriptionServiceMethods.ByName("GetConfirmed")),
 			connect.WithClientOptions(opts...),
 		),
+		listCities: connect.NewClient[v1.ListCitiesRequest, v1.ListCitiesResponse](
+			httpClient,
+			baseURL+SubscriptionServiceListCitiesProcedure,
+			connect.WithSchema(subscriptionServiceMethods.ByName("ListCities")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -99,6 +109,7 @@ type subscriptionServiceClient struct {
 	confirm      *connect.Client[v1.ConfirmRequest, v1.ConfirmResponse]
 	delete       *connect.Client[v1.DeleteRequest, v1.DeleteResponse]
 	getConfirmed *connect.Client[v1.GetConfirmedRequest, v1.GetConfirmedResponse]
+	listCities   *connect.Client[v1.ListCitiesRequest, v1.ListCitiesResponse]
 }
 
 // Create calls subscription.v1.SubscriptionService.Create.
@@ -121,6 +132,11 @@ func (c *subscriptionServiceClient) GetConfirmed(ctx context.Context, req *conne
 	return c.getConfirmed.CallUnary(ctx, req)
 }
 
+// ListCities calls subscription.v1.SubscriptionService.ListCities.
+func (c *subscriptionServiceClient) ListCities(ctx context.Context, req *connect.Request[v1.ListCitiesRequest]) (*connect.Response[v1.ListCitiesResponse], error) {
+	return c.listCities.CallUnary(ctx, req)
+}
+
 // SubscriptionServiceHandler is an implementation of the subscription.v1.SubscriptionService
 // service.
 type SubscriptionServiceHandler interface {
@@ -128,6 +144,7 @@ type SubscriptionServiceHandler interface {
 	Confirm(context.Context, *connect.Request[v1.ConfirmRequest]) (*connect.Response[v1.ConfirmResponse], error)
 	Delete(context.Context, *connect.Request[v1.DeleteRequest]) (*connect.Response[v1.DeleteResponse], error)
 	GetConfirmed(context.Context, *connect.Request[v1.GetConfirmedRequest]) (*connect.Response[v1.GetConfirmedResponse], error)
+	ListCities(context.Context, *connect.Request[v1.ListCitiesRequest]) (*connect.Response[v1.ListCitiesResponse], error)
 }
 
 // NewSubscriptionServiceHandler builds an HTTP handler from the service implementation. It returns
@@ -161,6 +178,12 @@ func NewSubscriptionServiceHandler(svc SubscriptionServiceHandler, opts ...conne
 		connect.WithSchema(subscriptionServiceMethods.ByName("GetConfirmed")),
 		connect.WithHandlerOptions(opts...),
 	)
+	subscriptionServiceListCitiesHandler := connect.NewUnaryHandler(
+		SubscriptionServiceListCitiesProcedure,
+		svc.ListCities,
+		connect.WithSchema(subscriptionServiceMethods.ByName("ListCities")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/subscription.v1.SubscriptionService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case SubscriptionServiceCreateProcedure:
@@ -171,6 +194,8 @@ func NewSubscriptionServiceHandler(svc SubscriptionServiceHandler, opts ...conne
 			subscriptionServiceDeleteHandler.ServeHTTP(w, r)
 		case SubscriptionServiceGetConfirmedProcedure:
 			subscriptionServiceGetConfirmedHandler.ServeHTTP(w, r)
+		case SubscriptionServiceListCitiesProcedure:
+			subscriptionServiceListCitiesHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -195,3 +220,7 @@ func (UnimplementedSubscriptionServiceHandler) Delete(context.Context, *connect.
 func (UnimplementedSubscriptionServiceHandler) GetConfirmed(context.Context, *connect.Request[v1.GetConfirmedRequest]) (*connect.Response[v1.GetConfirmedResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("subscription.v1.SubscriptionService.GetConfirmed is not implemented"))
 }
+
+func (UnimplementedSubscriptionServiceHandler) ListCities(context.Context, *connect.Request[v1.ListCitiesRequest]) (*connect.Response[v1.ListCitiesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("subscription.v1.SubscriptionService.ListCities is not implemented"))
+}
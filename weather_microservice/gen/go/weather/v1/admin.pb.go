@@ -0,0 +1,631 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: weather/v1/admin.proto
+
+package weatherv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetCacheStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCacheStatsRequest) Reset() {
+	*x = GetCacheStatsRequest{}
+	mi := &file_weather_v1_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCacheStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCacheStatsRequest) ProtoMessage() {}
+
+func (x *GetCacheStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCacheStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetCacheStatsRequest) Descriptor() ([]byte, []int) {
+	return file_weather_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+type GetCacheStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         *structpb.Struct       `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCacheStatsResponse) Reset() {
+	*x = GetCacheStatsResponse{}
+	mi := &file_weather_v1_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCacheStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCacheStatsResponse) ProtoMessage() {}
+
+func (x *GetCacheStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCacheStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetCacheStatsResponse) Descriptor() ([]byte, []int) {
+	return file_weather_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetCacheStatsResponse) GetStats() *structpb.Struct {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type InspectCacheEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	CountryCode   string                 `protobuf:"bytes,2,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	Coordinates   *Coordinates           `protobuf:"bytes,3,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InspectCacheEntryRequest) Reset() {
+	*x = InspectCacheEntryRequest{}
+	mi := &file_weather_v1_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InspectCacheEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InspectCacheEntryRequest) ProtoMessage() {}
+
+func (x *InspectCacheEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InspectCacheEntryRequest.ProtoReflect.Descriptor instead.
+func (*InspectCacheEntryRequest) Descriptor() ([]byte, []int) {
+	return file_weather_v1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *InspectCacheEntryRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *InspectCacheEntryRequest) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *InspectCacheEntryRequest) GetCoordinates() *Coordinates {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+type InspectCacheEntryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Data  *GetWeatherResponse    `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// Remaining time to live; zero for entries without expiration.
+	Ttl           *durationpb.Duration `protobuf:"bytes,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InspectCacheEntryResponse) Reset() {
+	*x = InspectCacheEntryResponse{}
+	mi := &file_weather_v1_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InspectCacheEntryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InspectCacheEntryResponse) ProtoMessage() {}
+
+func (x *InspectCacheEntryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InspectCacheEntryResponse.ProtoReflect.Descriptor instead.
+func (*InspectCacheEntryResponse) Descriptor() ([]byte, []int) {
+	return file_weather_v1_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *InspectCacheEntryResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *InspectCacheEntryResponse) GetData() *GetWeatherResponse {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *InspectCacheEntryResponse) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+type InvalidateCacheRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Target:
+	//
+	//	*InvalidateCacheRequest_Location
+	//	*InvalidateCacheRequest_Pattern
+	Target        isInvalidateCacheRequest_Target `protobuf_oneof:"target"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvalidateCacheRequest) Reset() {
+	*x = InvalidateCacheRequest{}
+	mi := &file_weather_v1_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidateCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidateCacheRequest) ProtoMessage() {}
+
+func (x *InvalidateCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidateCacheRequest.ProtoReflect.Descriptor instead.
+func (*InvalidateCacheRequest) Descriptor() ([]byte, []int) {
+	return file_weather_v1_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *InvalidateCacheRequest) GetTarget() isInvalidateCacheRequest_Target {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *InvalidateCacheRequest) GetLocation() *GetWeatherRequest {
+	if x != nil {
+		if x, ok := x.Target.(*InvalidateCacheRequest_Location); ok {
+			return x.Location
+		}
+	}
+	return nil
+}
+
+func (x *InvalidateCacheRequest) GetPattern() string {
+	if x != nil {
+		if x, ok := x.Target.(*InvalidateCacheRequest_Pattern); ok {
+			return x.Pattern
+		}
+	}
+	return ""
+}
+
+type isInvalidateCacheRequest_Target interface {
+	isInvalidateCacheRequest_Target()
+}
+
+type InvalidateCacheRequest_Location struct {
+	// Removes weather, forecasts and the not found marker of one location.
+	Location *GetWeatherRequest `protobuf:"bytes,1,opt,name=location,proto3,oneof"`
+}
+
+type InvalidateCacheRequest_Pattern struct {
+	// Glob pattern matched against location keys, e.g. "kyiv*".
+	Pattern string `protobuf:"bytes,2,opt,name=pattern,proto3,oneof"`
+}
+
+func (*InvalidateCacheRequest_Location) isInvalidateCacheRequest_Target() {}
+
+func (*InvalidateCacheRequest_Pattern) isInvalidateCacheRequest_Target() {}
+
+type InvalidateCacheResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       int64                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvalidateCacheResponse) Reset() {
+	*x = InvalidateCacheResponse{}
+	mi := &file_weather_v1_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidateCacheResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidateCacheResponse) ProtoMessage() {}
+
+func (x *InvalidateCacheResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidateCacheResponse.ProtoReflect.Descriptor instead.
+func (*InvalidateCacheResponse) Descriptor() ([]byte, []int) {
+	return file_weather_v1_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *InvalidateCacheResponse) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+type FlushCacheRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlushCacheRequest) Reset() {
+	*x = FlushCacheRequest{}
+	mi := &file_weather_v1_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlushCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlushCacheRequest) ProtoMessage() {}
+
+func (x *FlushCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlushCacheRequest.ProtoReflect.Descriptor instead.
+func (*FlushCacheRequest) Descriptor() ([]byte, []int) {
+	return file_weather_v1_admin_proto_rawDescGZIP(), []int{6}
+}
+
+type FlushCacheResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       int64                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlushCacheResponse) Reset() {
+	*x = FlushCacheResponse{}
+	mi := &file_weather_v1_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlushCacheResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlushCacheResponse) ProtoMessage() {}
+
+func (x *FlushCacheResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlushCacheResponse.ProtoReflect.Descriptor instead.
+func (*FlushCacheResponse) Descriptor() ([]byte, []int) {
+	return file_weather_v1_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FlushCacheResponse) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+type PurgeNotFoundRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeNotFoundRequest) Reset() {
+	*x = PurgeNotFoundRequest{}
+	mi := &file_weather_v1_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeNotFoundRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeNotFoundRequest) ProtoMessage() {}
+
+func (x *PurgeNotFoundRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeNotFoundRequest.ProtoReflect.Descriptor instead.
+func (*PurgeNotFoundRequest) Descriptor() ([]byte, []int) {
+	return file_weather_v1_admin_proto_rawDescGZIP(), []int{8}
+}
+
+type PurgeNotFoundResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Purged        int64                  `protobuf:"varint,1,opt,name=purged,proto3" json:"purged,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeNotFoundResponse) Reset() {
+	*x = PurgeNotFoundResponse{}
+	mi := &file_weather_v1_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeNotFoundResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeNotFoundResponse) ProtoMessage() {}
+
+func (x *PurgeNotFoundResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeNotFoundResponse.ProtoReflect.Descriptor instead.
+func (*PurgeNotFoundResponse) Descriptor() ([]byte, []int) {
+	return file_weather_v1_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PurgeNotFoundResponse) GetPurged() int64 {
+	if x != nil {
+		return x.Purged
+	}
+	return 0
+}
+
+var File_weather_v1_admin_proto protoreflect.FileDescriptor
+
+const file_weather_v1_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x16weather/v1/admin.proto\x12\aweather\x1a\x1egoogle/protobuf/duration.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x18weather/v1/weather.proto\"\x16\n" +
+	"\x14GetCacheStatsRequest\"F\n" +
+	"\x15GetCacheStatsResponse\x12-\n" +
+	"\x05stats\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x05stats\"\x89\x01\n" +
+	"\x18InspectCacheEntryRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12!\n" +
+	"\fcountry_code\x18\x02 \x01(\tR\vcountryCode\x126\n" +
+	"\vcoordinates\x18\x03 \x01(\v2\x14.weather.CoordinatesR\vcoordinates\"\x8b\x01\n" +
+	"\x19InspectCacheEntryResponse\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12/\n" +
+	"\x04data\x18\x02 \x01(\v2\x1b.weather.GetWeatherResponseR\x04data\x12+\n" +
+	"\x03ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\"x\n" +
+	"\x16InvalidateCacheRequest\x128\n" +
+	"\blocation\x18\x01 \x01(\v2\x1a.weather.GetWeatherRequestH\x00R\blocation\x12\x1a\n" +
+	"\apattern\x18\x02 \x01(\tH\x00R\apatternB\b\n" +
+	"\x06target\"3\n" +
+	"\x17InvalidateCacheResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x03R\adeleted\"\x13\n" +
+	"\x11FlushCacheRequest\".\n" +
+	"\x12FlushCacheResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x03R\adeleted\"\x16\n" +
+	"\x14PurgeNotFoundRequest\"/\n" +
+	"\x15PurgeNotFoundResponse\x12\x16\n" +
+	"\x06purged\x18\x01 \x01(\x03R\x06purged2\xae\x03\n" +
+	"\x13WeatherAdminService\x12N\n" +
+	"\rGetCacheStats\x12\x1d.weather.GetCacheStatsRequest\x1a\x1e.weather.GetCacheStatsResponse\x12Z\n" +
+	"\x11InspectCacheEntry\x12!.weather.InspectCacheEntryRequest\x1a\".weather.InspectCacheEntryResponse\x12T\n" +
+	"\x0fInvalidateCache\x12\x1f.weather.InvalidateCacheRequest\x1a .weather.InvalidateCacheResponse\x12E\n" +
+	"\n" +
+	"FlushCache\x12\x1a.weather.FlushCacheRequest\x1a\x1b.weather.FlushCacheResponse\x12N\n" +
+	"\rPurgeNotFound\x12\x1d.weather.PurgeNotFoundRequest\x1a\x1e.weather.PurgeNotFoundResponseB2Z0weather_microservice/gen/go/weather/v1;weatherv1b\x06proto3"
+
+var (
+	file_weather_v1_admin_proto_rawDescOnce sync.Once
+	file_weather_v1_admin_proto_rawDescData []byte
+)
+
+func file_weather_v1_admin_proto_rawDescGZIP() []byte {
+	file_weather_v1_admin_proto_rawDescOnce.Do(func() {
+		file_weather_v1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_weather_v1_admin_proto_rawDesc), len(file_weather_v1_admin_proto_rawDesc)))
+	})
+	return file_weather_v1_admin_proto_rawDescData
+}
+
+var file_weather_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_weather_v1_admin_proto_goTypes = []any{
+	(*GetCacheStatsRequest)(nil),      // 0: weather.GetCacheStatsRequest
+	(*GetCacheStatsResponse)(nil),     // 1: weather.GetCacheStatsResponse
+	(*InspectCacheEntryRequest)(nil),  // 2: weather.InspectCacheEntryRequest
+	(*InspectCacheEntryResponse)(nil), // 3: weather.InspectCacheEntryResponse
+	(*InvalidateCacheRequest)(nil),    // 4: weather.InvalidateCacheRequest
+	(*InvalidateCacheResponse)(nil),   // 5: weather.InvalidateCacheResponse
+	(*FlushCacheRequest)(nil),         // 6: weather.FlushCacheRequest
+	(*FlushCacheResponse)(nil),        // 7: weather.FlushCacheResponse
+	(*PurgeNotFoundRequest)(nil),      // 8: weather.PurgeNotFoundRequest
+	(*PurgeNotFoundResponse)(nil),     // 9: weather.PurgeNotFoundResponse
+	(*structpb.Struct)(nil),           // 10: google.protobuf.Struct
+	(*Coordinates)(nil),               // 11: weather.Coordinates
+	(*GetWeatherResponse)(nil),        // 12: weather.GetWeatherResponse
+	(*durationpb.Duration)(nil),       // 13: google.protobuf.Duration
+	(*GetWeatherRequest)(nil),         // 14: weather.GetWeatherRequest
+}
+var file_weather_v1_admin_proto_depIdxs = []int32{
+	10, // 0: weather.GetCacheStatsResponse.stats:type_name -> google.protobuf.Struct
+	11, // 1: weather.InspectCacheEntryRequest.coordinates:type_name -> weather.Coordinates
+	12, // 2: weather.InspectCacheEntryResponse.data:type_name -> weather.GetWeatherResponse
+	13, // 3: weather.InspectCacheEntryResponse.ttl:type_name -> google.protobuf.Duration
+	14, // 4: weather.InvalidateCacheRequest.location:type_name -> weather.GetWeatherRequest
+	0,  // 5: weather.WeatherAdminService.GetCacheStats:input_type -> weather.GetCacheStatsRequest
+	2,  // 6: weather.WeatherAdminService.InspectCacheEntry:input_type -> weather.InspectCacheEntryRequest
+	4,  // 7: weather.WeatherAdminService.InvalidateCache:input_type -> weather.InvalidateCacheRequest
+	6,  // 8: weather.WeatherAdminService.FlushCache:input_type -> weather.FlushCacheRequest
+	8,  // 9: weather.WeatherAdminService.PurgeNotFound:input_type -> weather.PurgeNotFoundRequest
+	1,  // 10: weather.WeatherAdminService.GetCacheStats:output_type -> weather.GetCacheStatsResponse
+	3,  // 11: weather.WeatherAdminService.InspectCacheEntry:output_type -> weather.InspectCacheEntryResponse
+	5,  // 12: weather.WeatherAdminService.InvalidateCache:output_type -> weather.InvalidateCacheResponse
+	7,  // 13: weather.WeatherAdminService.FlushCache:output_type -> weather.FlushCacheResponse
+	9,  // 14: weather.WeatherAdminService.PurgeNotFound:output_type -> weather.PurgeNotFoundResponse
+	10, // [10:15] is the sub-list for method output_type
+	5,  // [5:10] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_weather_v1_admin_proto_init() }
+func file_weather_v1_admin_proto_init() {
+	if File_weather_v1_admin_proto != nil {
+		return
+	}
+	file_weather_v1_weather_proto_init()
+	file_weather_v1_admin_proto_msgTypes[4].OneofWrappers = []any{
+		(*InvalidateCacheRequest_Location)(nil),
+		(*InvalidateCacheRequest_Pattern)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_weather_v1_admin_proto_rawDesc), len(file_weather_v1_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_weather_v1_admin_proto_goTypes,
+		DependencyIndexes: file_weather_v1_admin_proto_depIdxs,
+		MessageInfos:      file_weather_v1_admin_proto_msgTypes,
+	}.Build()
+	File_weather_v1_admin_proto = out.File
+	file_weather_v1_admin_proto_goTypes = nil
+	file_weather_v1_admin_proto_depIdxs = nil
+}
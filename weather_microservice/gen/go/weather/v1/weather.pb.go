@@ -9,6 +9,7 @@ package weatherv1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,16 +22,71 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Coordinates pin an exact place; they take precedence over the city name.
+type Coordinates struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lat           float64                `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon           float64                `protobuf:"fixed64,2,opt,name=lon,proto3" json:"lon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Coordinates) Reset() {
+	*x = Coordinates{}
+	mi := &file_weather_v1_weather_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Coordinates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coordinates) ProtoMessage() {}
+
+func (x *Coordinates) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_weather_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coordinates.ProtoReflect.Descriptor instead.
+func (*Coordinates) Descriptor() ([]byte, []int) {
+	return file_weather_v1_weather_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Coordinates) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *Coordinates) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
 type GetWeatherRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	CountryCode   string                 `protobuf:"bytes,2,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	Coordinates   *Coordinates           `protobuf:"bytes,3,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetWeatherRequest) Reset() {
 	*x = GetWeatherRequest{}
-	mi := &file_weather_v1_weather_proto_msgTypes[0]
+	mi := &file_weather_v1_weather_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -42,7 +98,7 @@ func (x *GetWeatherRequest) String() string {
 func (*GetWeatherRequest) ProtoMessage() {}
 
 func (x *GetWeatherRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_weather_v1_weather_proto_msgTypes[0]
+	mi := &file_weather_v1_weather_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -55,7 +111,7 @@ func (x *GetWeatherRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetWeatherRequest.ProtoReflect.Descriptor instead.
 func (*GetWeatherRequest) Descriptor() ([]byte, []int) {
-	return file_weather_v1_weather_proto_rawDescGZIP(), []int{0}
+	return file_weather_v1_weather_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *GetWeatherRequest) GetCity() string {
@@ -65,18 +121,65 @@ func (x *GetWeatherRequest) GetCity() string {
 	return ""
 }
 
+func (x *GetWeatherRequest) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *GetWeatherRequest) GetCoordinates() *Coordinates {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
 type GetWeatherResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Temperature   float64                `protobuf:"fixed64,1,opt,name=temperature,proto3" json:"temperature,omitempty"`
-	Humidity      float64                `protobuf:"fixed64,2,opt,name=humidity,proto3" json:"humidity,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Temperature float64                `protobuf:"fixed64,1,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	Humidity    float64                `protobuf:"fixed64,2,opt,name=humidity,proto3" json:"humidity,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Set when the data is served from cache past its expiration.
+	Stale bool `protobuf:"varint,4,opt,name=stale,proto3" json:"stale,omitempty"`
+	// When the data was received from a provider.
+	FetchedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=fetched_at,json=fetchedAt,proto3" json:"fetched_at,omitempty"`
+	// Apparent temperature, °C.
+	FeelsLike float64 `protobuf:"fixed64,6,opt,name=feels_like,json=feelsLike,proto3" json:"feels_like,omitempty"`
+	// Wind speed in m/s and the direction it blows from in degrees.
+	WindSpeed     float64 `protobuf:"fixed64,7,opt,name=wind_speed,json=windSpeed,proto3" json:"wind_speed,omitempty"`
+	WindDirection float64 `protobuf:"fixed64,8,opt,name=wind_direction,json=windDirection,proto3" json:"wind_direction,omitempty"`
+	// Sea-level pressure, hPa.
+	Pressure float64 `protobuf:"fixed64,9,opt,name=pressure,proto3" json:"pressure,omitempty"`
+	// Recent rain and snow amount, mm.
+	Precipitation float64 `protobuf:"fixed64,10,opt,name=precipitation,proto3" json:"precipitation,omitempty"`
+	// Sky coverage, %.
+	CloudCover float64 `protobuf:"fixed64,11,opt,name=cloud_cover,json=cloudCover,proto3" json:"cloud_cover,omitempty"`
+	// Unset when the provider does not report it.
+	UvIndex *float64 `protobuf:"fixed64,12,opt,name=uv_index,json=uvIndex,proto3,oneof" json:"uv_index,omitempty"`
+	// Visibility, km.
+	Visibility float64                `protobuf:"fixed64,13,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	Sunrise    *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=sunrise,proto3" json:"sunrise,omitempty"`
+	Sunset     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=sunset,proto3" json:"sunset,omitempty"`
+	// When the provider measured the conditions.
+	ObservedAt *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=observed_at,json=observedAt,proto3" json:"observed_at,omitempty"`
+	// Provider-independent condition: clear, partly_cloudy, cloudy, fog, haze,
+	// drizzle, rain, freezing_rain, sleet, snow, thunderstorm, squall or unknown.
+	Condition string `protobuf:"bytes,17,opt,name=condition,proto3" json:"condition,omitempty"`
+	// none, minor, moderate or severe.
+	Severity string `protobuf:"bytes,18,opt,name=severity,proto3" json:"severity,omitempty"`
+	// Icon identifier, e.g. "rain" or "clear-night".
+	Icon string `protobuf:"bytes,19,opt,name=icon,proto3" json:"icon,omitempty"`
+	// Providers merged into a consensus answer, and those discarded as outliers.
+	Providers     []string `protobuf:"bytes,20,rep,name=providers,proto3" json:"providers,omitempty"`
+	Outliers      []string `protobuf:"bytes,21,rep,name=outliers,proto3" json:"outliers,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetWeatherResponse) Reset() {
 	*x = GetWeatherResponse{}
-	mi := &file_weather_v1_weather_proto_msgTypes[1]
+	mi := &file_weather_v1_weather_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -88,7 +191,7 @@ func (x *GetWeatherResponse) String() string {
 func (*GetWeatherResponse) ProtoMessage() {}
 
 func (x *GetWeatherResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_weather_v1_weather_proto_msgTypes[1]
+	mi := &file_weather_v1_weather_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -101,7 +204,7 @@ func (x *GetWeatherResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetWeatherResponse.ProtoReflect.Descriptor instead.
 func (*GetWeatherResponse) Descriptor() ([]byte, []int) {
-	return file_weather_v1_weather_proto_rawDescGZIP(), []int{1}
+	return file_weather_v1_weather_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *GetWeatherResponse) GetTemperature() float64 {
@@ -125,20 +228,1137 @@ func (x *GetWeatherResponse) GetDescription() string {
 	return ""
 }
 
-var File_weather_v1_weather_proto protoreflect.FileDescriptor
+func (x *GetWeatherResponse) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
 
-const file_weather_v1_weather_proto_rawDesc = "" +
-	"\n" +
-	"\x18weather/v1/weather.proto\x12\aweather\"'\n" +
-	"\x11GetWeatherRequest\x12\x12\n" +
-	"\x04city\x18\x01 \x01(\tR\x04city\"t\n" +
-	"\x12GetWeatherResponse\x12 \n" +
-	"\vtemperature\x18\x01 \x01(\x01R\vtemperature\x12\x1a\n" +
-	"\bhumidity\x18\x02 \x01(\x01R\bhumidity\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription2W\n" +
+func (x *GetWeatherResponse) GetFetchedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FetchedAt
+	}
+	return nil
+}
+
+func (x *GetWeatherResponse) GetFeelsLike() float64 {
+	if x != nil {
+		return x.FeelsLike
+	}
+	return 0
+}
+
+func (x *GetWeatherResponse) GetWindSpeed() float64 {
+	if x != nil {
+		return x.WindSpeed
+	}
+	return 0
+}
+
+func (x *GetWeatherResponse) GetWindDirection() float64 {
+	if x != nil {
+		return x.WindDirection
+	}
+	return 0
+}
+
+func (x *GetWeatherResponse) GetPressure() float64 {
+	if x != nil {
+		return x.Pressure
+	}
+	return 0
+}
+
+func (x *GetWeatherResponse) GetPrecipitation() float64 {
+	if x != nil {
+		return x.Precipitation
+	}
+	return 0
+}
+
+func (x *GetWeatherResponse) GetCloudCover() float64 {
+	if x != nil {
+		return x.CloudCover
+	}
+	return 0
+}
+
+func (x *GetWeatherResponse) GetUvIndex() float64 {
+	if x != nil && x.UvIndex != nil {
+		return *x.UvIndex
+	}
+	return 0
+}
+
+func (x *GetWeatherResponse) GetVisibility() float64 {
+	if x != nil {
+		return x.Visibility
+	}
+	return 0
+}
+
+func (x *GetWeatherResponse) GetSunrise() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Sunrise
+	}
+	return nil
+}
+
+func (x *GetWeatherResponse) GetSunset() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Sunset
+	}
+	return nil
+}
+
+func (x *GetWeatherResponse) GetObservedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ObservedAt
+	}
+	return nil
+}
+
+func (x *GetWeatherResponse) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+func (x *GetWeatherResponse) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *GetWeatherResponse) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+func (x *GetWeatherResponse) GetProviders() []string {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+func (x *GetWeatherResponse) GetOutliers() []string {
+	if x != nil {
+		return x.Outliers
+	}
+	return nil
+}
+
+type GetForecastRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Days          int32                  `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`
+	CountryCode   string                 `protobuf:"bytes,3,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	Coordinates   *Coordinates           `protobuf:"bytes,4,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetForecastRequest) Reset() {
+	*x = GetForecastRequest{}
+	mi := &file_weather_v1_weather_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetForecastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetForecastRequest) ProtoMessage() {}
+
+func (x *GetForecastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_weather_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetForecastRequest.ProtoReflect.Descriptor instead.
+func (*GetForecastRequest) Descriptor() ([]byte, []int) {
+	return file_weather_v1_weather_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetForecastRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *GetForecastRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *GetForecastRequest) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *GetForecastRequest) GetCoordinates() *Coordinates {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+type HourlyForecast struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Temperature   float64                `protobuf:"fixed64,2,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	Humidity      float64                `protobuf:"fixed64,3,opt,name=humidity,proto3" json:"humidity,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HourlyForecast) Reset() {
+	*x = HourlyForecast{}
+	mi := &file_weather_v1_weather_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HourlyForecast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HourlyForecast) ProtoMessage() {}
+
+func (x *HourlyForecast) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_weather_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HourlyForecast.ProtoReflect.Descriptor instead.
+func (*HourlyForecast) Descriptor() ([]byte, []int) {
+	return file_weather_v1_weather_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HourlyForecast) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *HourlyForecast) GetTemperature() float64 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+func (x *HourlyForecast) GetHumidity() float64 {
+	if x != nil {
+		return x.Humidity
+	}
+	return 0
+}
+
+func (x *HourlyForecast) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type DailyForecast struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Date           string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	MinTemperature float64                `protobuf:"fixed64,2,opt,name=min_temperature,json=minTemperature,proto3" json:"min_temperature,omitempty"`
+	MaxTemperature float64                `protobuf:"fixed64,3,opt,name=max_temperature,json=maxTemperature,proto3" json:"max_temperature,omitempty"`
+	Humidity       float64                `protobuf:"fixed64,4,opt,name=humidity,proto3" json:"humidity,omitempty"`
+	Description    string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DailyForecast) Reset() {
+	*x = DailyForecast{}
+	mi := &file_weather_v1_weather_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyForecast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyForecast) ProtoMessage() {}
+
+func (x *DailyForecast) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_weather_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyForecast.ProtoReflect.Descriptor instead.
+func (*DailyForecast) Descriptor() ([]byte, []int) {
+	return file_weather_v1_weather_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DailyForecast) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *DailyForecast) GetMinTemperature() float64 {
+	if x != nil {
+		return x.MinTemperature
+	}
+	return 0
+}
+
+func (x *DailyForecast) GetMaxTemperature() float64 {
+	if x != nil {
+		return x.MaxTemperature
+	}
+	return 0
+}
+
+func (x *DailyForecast) GetHumidity() float64 {
+	if x != nil {
+		return x.Humidity
+	}
+	return 0
+}
+
+func (x *DailyForecast) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type GetForecastResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Hourly        []*HourlyForecast      `protobuf:"bytes,2,rep,name=hourly,proto3" json:"hourly,omitempty"`
+	Daily         []*DailyForecast       `protobuf:"bytes,3,rep,name=daily,proto3" json:"daily,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetForecastResponse) Reset() {
+	*x = GetForecastResponse{}
+	mi := &file_weather_v1_weather_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetForecastResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetForecastResponse) ProtoMessage() {}
+
+func (x *GetForecastResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_weather_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetForecastResponse.ProtoReflect.Descriptor instead.
+func (*GetForecastResponse) Descriptor() ([]byte, []int) {
+	return file_weather_v1_weather_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetForecastResponse) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *GetForecastResponse) GetHourly() []*HourlyForecast {
+	if x != nil {
+		return x.Hourly
+	}
+	return nil
+}
+
+func (x *GetForecastResponse) GetDaily() []*DailyForecast {
+	if x != nil {
+		return x.Daily
+	}
+	return nil
+}
+
+type GetWeatherHistoryRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	City        string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	CountryCode string                 `protobuf:"bytes,2,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	Coordinates *Coordinates           `protobuf:"bytes,3,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
+	// Defaults to 24 hours before `to`.
+	From *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	// Defaults to now.
+	To            *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWeatherHistoryRequest) Reset() {
+	*x = GetWeatherHistoryRequest{}
+	mi := &file_weather_v1_weather_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWeatherHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWeatherHistoryRequest) ProtoMessage() {}
+
+func (x *GetWeatherHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_weather_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWeatherHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetWeatherHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_weather_v1_weather_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetWeatherHistoryRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *GetWeatherHistoryRequest) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *GetWeatherHistoryRequest) GetCoordinates() *Coordinates {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+func (x *GetWeatherHistoryRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetWeatherHistoryRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type WeatherObservation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Provider      string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	ObservedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=observed_at,json=observedAt,proto3" json:"observed_at,omitempty"`
+	Temperature   float64                `protobuf:"fixed64,4,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	Humidity      float64                `protobuf:"fixed64,5,opt,name=humidity,proto3" json:"humidity,omitempty"`
+	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WeatherObservation) Reset() {
+	*x = WeatherObservation{}
+	mi := &file_weather_v1_weather_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WeatherObservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeatherObservation) ProtoMessage() {}
+
+func (x *WeatherObservation) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_weather_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeatherObservation.ProtoReflect.Descriptor instead.
+func (*WeatherObservation) Descriptor() ([]byte, []int) {
+	return file_weather_v1_weather_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WeatherObservation) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *WeatherObservation) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *WeatherObservation) GetObservedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ObservedAt
+	}
+	return nil
+}
+
+func (x *WeatherObservation) GetTemperature() float64 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+func (x *WeatherObservation) GetHumidity() float64 {
+	if x != nil {
+		return x.Humidity
+	}
+	return 0
+}
+
+func (x *WeatherObservation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type GetWeatherHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Observations  []*WeatherObservation  `protobuf:"bytes,1,rep,name=observations,proto3" json:"observations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWeatherHistoryResponse) Reset() {
+	*x = GetWeatherHistoryResponse{}
+	mi := &file_weather_v1_weather_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWeatherHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWeatherHistoryResponse) ProtoMessage() {}
+
+func (x *GetWeatherHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_weather_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWeatherHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetWeatherHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_weather_v1_weather_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetWeatherHistoryResponse) GetObservations() []*WeatherObservation {
+	if x != nil {
+		return x.Observations
+	}
+	return nil
+}
+
+// Cities and locations are looked up together; duplicates are looked up once.
+type GetWeatherBatchRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Cities []string               `protobuf:"bytes,1,rep,name=cities,proto3" json:"cities,omitempty"`
+	// Locations with a country code or coordinates.
+	Locations     []*GetWeatherRequest `protobuf:"bytes,2,rep,name=locations,proto3" json:"locations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWeatherBatchRequest) Reset() {
+	*x = GetWeatherBatchRequest{}
+	mi := &file_weather_v1_weather_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWeatherBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWeatherBatchRequest) ProtoMessage() {}
+
+func (x *GetWeatherBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_weather_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWeatherBatchRequest.ProtoReflect.Descriptor instead.
+func (*GetWeatherBatchRequest) Descriptor() ([]byte, []int) {
+	return file_weather_v1_weather_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetWeatherBatchRequest) GetCities() []string {
+	if x != nil {
+		return x.Cities
+	}
+	return nil
+}
+
+func (x *GetWeatherBatchRequest) GetLocations() []*GetWeatherRequest {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+type WeatherBatchResult struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Location *GetWeatherRequest     `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	// Set when the lookup succeeded.
+	Weather *GetWeatherResponse `protobuf:"bytes,2,opt,name=weather,proto3" json:"weather,omitempty"`
+	// Connect code name of the failure, e.g. "not_found"; empty on success.
+	ErrorCode     string `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Error         string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WeatherBatchResult) Reset() {
+	*x = WeatherBatchResult{}
+	mi := &file_weather_v1_weather_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WeatherBatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeatherBatchResult) ProtoMessage() {}
+
+func (x *WeatherBatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_weather_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeatherBatchResult.ProtoReflect.Descriptor instead.
+func (*WeatherBatchResult) Descriptor() ([]byte, []int) {
+	return file_weather_v1_weather_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WeatherBatchResult) GetLocation() *GetWeatherRequest {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *WeatherBatchResult) GetWeather() *GetWeatherResponse {
+	if x != nil {
+		return x.Weather
+	}
+	return nil
+}
+
+func (x *WeatherBatchResult) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *WeatherBatchResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetWeatherBatchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One result per distinct location, in the order of first appearance.
+	Results       []*WeatherBatchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWeatherBatchResponse) Reset() {
+	*x = GetWeatherBatchResponse{}
+	mi := &file_weather_v1_weather_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWeatherBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWeatherBatchResponse) ProtoMessage() {}
+
+func (x *GetWeatherBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_weather_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWeatherBatchResponse.ProtoReflect.Descriptor instead.
+func (*GetWeatherBatchResponse) Descriptor() ([]byte, []int) {
+	return file_weather_v1_weather_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetWeatherBatchResponse) GetResults() []*WeatherBatchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type WatchWeatherRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	CountryCode   string                 `protobuf:"bytes,2,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	Coordinates   *Coordinates           `protobuf:"bytes,3,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchWeatherRequest) Reset() {
+	*x = WatchWeatherRequest{}
+	mi := &file_weather_v1_weather_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchWeatherRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchWeatherRequest) ProtoMessage() {}
+
+func (x *WatchWeatherRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_weather_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchWeatherRequest.ProtoReflect.Descriptor instead.
+func (*WatchWeatherRequest) Descriptor() ([]byte, []int) {
+	return file_weather_v1_weather_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WatchWeatherRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *WatchWeatherRequest) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *WatchWeatherRequest) GetCoordinates() *Coordinates {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+type SearchCitiesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// At least 2 characters.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Defaults to 5, at most 10.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchCitiesRequest) Reset() {
+	*x = SearchCitiesRequest{}
+	mi := &file_weather_v1_weather_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchCitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCitiesRequest) ProtoMessage() {}
+
+func (x *SearchCitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_weather_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchCitiesRequest.ProtoReflect.Descriptor instead.
+func (*SearchCitiesRequest) Descriptor() ([]byte, []int) {
+	return file_weather_v1_weather_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SearchCitiesRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchCitiesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type City struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Canonical ID such as "kyiv,ua", accepted wherever a city name is.
+	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name    string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Region  string `protobuf:"bytes,3,opt,name=region,proto3" json:"region,omitempty"`
+	Country string `protobuf:"bytes,4,opt,name=country,proto3" json:"country,omitempty"`
+	// ISO 3166 code.
+	CountryCode   string       `protobuf:"bytes,5,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	Coordinates   *Coordinates `protobuf:"bytes,6,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *City) Reset() {
+	*x = City{}
+	mi := &file_weather_v1_weather_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *City) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*City) ProtoMessage() {}
+
+func (x *City) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_weather_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use City.ProtoReflect.Descriptor instead.
+func (*City) Descriptor() ([]byte, []int) {
+	return file_weather_v1_weather_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *City) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *City) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *City) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *City) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *City) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *City) GetCoordinates() *Coordinates {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+type SearchCitiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cities        []*City                `protobuf:"bytes,1,rep,name=cities,proto3" json:"cities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchCitiesResponse) Reset() {
+	*x = SearchCitiesResponse{}
+	mi := &file_weather_v1_weather_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchCitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCitiesResponse) ProtoMessage() {}
+
+func (x *SearchCitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_v1_weather_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchCitiesResponse.ProtoReflect.Descriptor instead.
+func (*SearchCitiesResponse) Descriptor() ([]byte, []int) {
+	return file_weather_v1_weather_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SearchCitiesResponse) GetCities() []*City {
+	if x != nil {
+		return x.Cities
+	}
+	return nil
+}
+
+var File_weather_v1_weather_proto protoreflect.FileDescriptor
+
+const file_weather_v1_weather_proto_rawDesc = "" +
+	"\n" +
+	"\x18weather/v1/weather.proto\x12\aweather\x1a\x1fgoogle/protobuf/timestamp.proto\"1\n" +
+	"\vCoordinates\x12\x10\n" +
+	"\x03lat\x18\x01 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x02 \x01(\x01R\x03lon\"\x82\x01\n" +
+	"\x11GetWeatherRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12!\n" +
+	"\fcountry_code\x18\x02 \x01(\tR\vcountryCode\x126\n" +
+	"\vcoordinates\x18\x03 \x01(\v2\x14.weather.CoordinatesR\vcoordinates\"\x89\x06\n" +
+	"\x12GetWeatherResponse\x12 \n" +
+	"\vtemperature\x18\x01 \x01(\x01R\vtemperature\x12\x1a\n" +
+	"\bhumidity\x18\x02 \x01(\x01R\bhumidity\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05stale\x18\x04 \x01(\bR\x05stale\x129\n" +
+	"\n" +
+	"fetched_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tfetchedAt\x12\x1d\n" +
+	"\n" +
+	"feels_like\x18\x06 \x01(\x01R\tfeelsLike\x12\x1d\n" +
+	"\n" +
+	"wind_speed\x18\a \x01(\x01R\twindSpeed\x12%\n" +
+	"\x0ewind_direction\x18\b \x01(\x01R\rwindDirection\x12\x1a\n" +
+	"\bpressure\x18\t \x01(\x01R\bpressure\x12$\n" +
+	"\rprecipitation\x18\n" +
+	" \x01(\x01R\rprecipitation\x12\x1f\n" +
+	"\vcloud_cover\x18\v \x01(\x01R\n" +
+	"cloudCover\x12\x1e\n" +
+	"\buv_index\x18\f \x01(\x01H\x00R\auvIndex\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"visibility\x18\r \x01(\x01R\n" +
+	"visibility\x124\n" +
+	"\asunrise\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\asunrise\x122\n" +
+	"\x06sunset\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\x06sunset\x12;\n" +
+	"\vobserved_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"observedAt\x12\x1c\n" +
+	"\tcondition\x18\x11 \x01(\tR\tcondition\x12\x1a\n" +
+	"\bseverity\x18\x12 \x01(\tR\bseverity\x12\x12\n" +
+	"\x04icon\x18\x13 \x01(\tR\x04icon\x12\x1c\n" +
+	"\tproviders\x18\x14 \x03(\tR\tproviders\x12\x1a\n" +
+	"\boutliers\x18\x15 \x03(\tR\boutliersB\v\n" +
+	"\t_uv_index\"\x97\x01\n" +
+	"\x12GetForecastRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x12\n" +
+	"\x04days\x18\x02 \x01(\x05R\x04days\x12!\n" +
+	"\fcountry_code\x18\x03 \x01(\tR\vcountryCode\x126\n" +
+	"\vcoordinates\x18\x04 \x01(\v2\x14.weather.CoordinatesR\vcoordinates\"\xa0\x01\n" +
+	"\x0eHourlyForecast\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12 \n" +
+	"\vtemperature\x18\x02 \x01(\x01R\vtemperature\x12\x1a\n" +
+	"\bhumidity\x18\x03 \x01(\x01R\bhumidity\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"\xb3\x01\n" +
+	"\rDailyForecast\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12'\n" +
+	"\x0fmin_temperature\x18\x02 \x01(\x01R\x0eminTemperature\x12'\n" +
+	"\x0fmax_temperature\x18\x03 \x01(\x01R\x0emaxTemperature\x12\x1a\n" +
+	"\bhumidity\x18\x04 \x01(\x01R\bhumidity\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\"\x88\x01\n" +
+	"\x13GetForecastResponse\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12/\n" +
+	"\x06hourly\x18\x02 \x03(\v2\x17.weather.HourlyForecastR\x06hourly\x12,\n" +
+	"\x05daily\x18\x03 \x03(\v2\x16.weather.DailyForecastR\x05daily\"\xe5\x01\n" +
+	"\x18GetWeatherHistoryRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12!\n" +
+	"\fcountry_code\x18\x02 \x01(\tR\vcountryCode\x126\n" +
+	"\vcoordinates\x18\x03 \x01(\v2\x14.weather.CoordinatesR\vcoordinates\x12.\n" +
+	"\x04from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"\xe1\x01\n" +
+	"\x12WeatherObservation\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x1a\n" +
+	"\bprovider\x18\x02 \x01(\tR\bprovider\x12;\n" +
+	"\vobserved_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"observedAt\x12 \n" +
+	"\vtemperature\x18\x04 \x01(\x01R\vtemperature\x12\x1a\n" +
+	"\bhumidity\x18\x05 \x01(\x01R\bhumidity\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\"\\\n" +
+	"\x19GetWeatherHistoryResponse\x12?\n" +
+	"\fobservations\x18\x01 \x03(\v2\x1b.weather.WeatherObservationR\fobservations\"j\n" +
+	"\x16GetWeatherBatchRequest\x12\x16\n" +
+	"\x06cities\x18\x01 \x03(\tR\x06cities\x128\n" +
+	"\tlocations\x18\x02 \x03(\v2\x1a.weather.GetWeatherRequestR\tlocations\"\xb8\x01\n" +
+	"\x12WeatherBatchResult\x126\n" +
+	"\blocation\x18\x01 \x01(\v2\x1a.weather.GetWeatherRequestR\blocation\x125\n" +
+	"\aweather\x18\x02 \x01(\v2\x1b.weather.GetWeatherResponseR\aweather\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"P\n" +
+	"\x17GetWeatherBatchResponse\x125\n" +
+	"\aresults\x18\x01 \x03(\v2\x1b.weather.WeatherBatchResultR\aresults\"\x84\x01\n" +
+	"\x13WatchWeatherRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12!\n" +
+	"\fcountry_code\x18\x02 \x01(\tR\vcountryCode\x126\n" +
+	"\vcoordinates\x18\x03 \x01(\v2\x14.weather.CoordinatesR\vcoordinates\"A\n" +
+	"\x13SearchCitiesRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\xb7\x01\n" +
+	"\x04City\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06region\x18\x03 \x01(\tR\x06region\x12\x18\n" +
+	"\acountry\x18\x04 \x01(\tR\acountry\x12!\n" +
+	"\fcountry_code\x18\x05 \x01(\tR\vcountryCode\x126\n" +
+	"\vcoordinates\x18\x06 \x01(\v2\x14.weather.CoordinatesR\vcoordinates\"=\n" +
+	"\x14SearchCitiesResponse\x12%\n" +
+	"\x06cities\x18\x01 \x03(\v2\r.weather.CityR\x06cities2\xed\x03\n" +
 	"\x0eWeatherService\x12E\n" +
 	"\n" +
-	"GetWeather\x12\x1a.weather.GetWeatherRequest\x1a\x1b.weather.GetWeatherResponseB2Z0weather_microservice/gen/go/weather/v1;weatherv1b\x06proto3"
+	"GetWeather\x12\x1a.weather.GetWeatherRequest\x1a\x1b.weather.GetWeatherResponse\x12H\n" +
+	"\vGetForecast\x12\x1b.weather.GetForecastRequest\x1a\x1c.weather.GetForecastResponse\x12Z\n" +
+	"\x11GetWeatherHistory\x12!.weather.GetWeatherHistoryRequest\x1a\".weather.GetWeatherHistoryResponse\x12T\n" +
+	"\x0fGetWeatherBatch\x12\x1f.weather.GetWeatherBatchRequest\x1a .weather.GetWeatherBatchResponse\x12K\n" +
+	"\fWatchWeather\x12\x1c.weather.WatchWeatherRequest\x1a\x1b.weather.GetWeatherResponse0\x01\x12K\n" +
+	"\fSearchCities\x12\x1c.weather.SearchCitiesRequest\x1a\x1d.weather.SearchCitiesResponseB2Z0weather_microservice/gen/go/weather/v1;weatherv1b\x06proto3"
 
 var (
 	file_weather_v1_weather_proto_rawDescOnce sync.Once
@@ -152,19 +1372,66 @@ func file_weather_v1_weather_proto_rawDescGZIP() []byte {
 	return file_weather_v1_weather_proto_rawDescData
 }
 
-var file_weather_v1_weather_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_weather_v1_weather_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_weather_v1_weather_proto_goTypes = []any{
-	(*GetWeatherRequest)(nil),  // 0: weather.GetWeatherRequest
-	(*GetWeatherResponse)(nil), // 1: weather.GetWeatherResponse
+	(*Coordinates)(nil),               // 0: weather.Coordinates
+	(*GetWeatherRequest)(nil),         // 1: weather.GetWeatherRequest
+	(*GetWeatherResponse)(nil),        // 2: weather.GetWeatherResponse
+	(*GetForecastRequest)(nil),        // 3: weather.GetForecastRequest
+	(*HourlyForecast)(nil),            // 4: weather.HourlyForecast
+	(*DailyForecast)(nil),             // 5: weather.DailyForecast
+	(*GetForecastResponse)(nil),       // 6: weather.GetForecastResponse
+	(*GetWeatherHistoryRequest)(nil),  // 7: weather.GetWeatherHistoryRequest
+	(*WeatherObservation)(nil),        // 8: weather.WeatherObservation
+	(*GetWeatherHistoryResponse)(nil), // 9: weather.GetWeatherHistoryResponse
+	(*GetWeatherBatchRequest)(nil),    // 10: weather.GetWeatherBatchRequest
+	(*WeatherBatchResult)(nil),        // 11: weather.WeatherBatchResult
+	(*GetWeatherBatchResponse)(nil),   // 12: weather.GetWeatherBatchResponse
+	(*WatchWeatherRequest)(nil),       // 13: weather.WatchWeatherRequest
+	(*SearchCitiesRequest)(nil),       // 14: weather.SearchCitiesRequest
+	(*City)(nil),                      // 15: weather.City
+	(*SearchCitiesResponse)(nil),      // 16: weather.SearchCitiesResponse
+	(*timestamppb.Timestamp)(nil),     // 17: google.protobuf.Timestamp
 }
 var file_weather_v1_weather_proto_depIdxs = []int32{
-	0, // 0: weather.WeatherService.GetWeather:input_type -> weather.GetWeatherRequest
-	1, // 1: weather.WeatherService.GetWeather:output_type -> weather.GetWeatherResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: weather.GetWeatherRequest.coordinates:type_name -> weather.Coordinates
+	17, // 1: weather.GetWeatherResponse.fetched_at:type_name -> google.protobuf.Timestamp
+	17, // 2: weather.GetWeatherResponse.sunrise:type_name -> google.protobuf.Timestamp
+	17, // 3: weather.GetWeatherResponse.sunset:type_name -> google.protobuf.Timestamp
+	17, // 4: weather.GetWeatherResponse.observed_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: weather.GetForecastRequest.coordinates:type_name -> weather.Coordinates
+	17, // 6: weather.HourlyForecast.time:type_name -> google.protobuf.Timestamp
+	4,  // 7: weather.GetForecastResponse.hourly:type_name -> weather.HourlyForecast
+	5,  // 8: weather.GetForecastResponse.daily:type_name -> weather.DailyForecast
+	0,  // 9: weather.GetWeatherHistoryRequest.coordinates:type_name -> weather.Coordinates
+	17, // 10: weather.GetWeatherHistoryRequest.from:type_name -> google.protobuf.Timestamp
+	17, // 11: weather.GetWeatherHistoryRequest.to:type_name -> google.protobuf.Timestamp
+	17, // 12: weather.WeatherObservation.observed_at:type_name -> google.protobuf.Timestamp
+	8,  // 13: weather.GetWeatherHistoryResponse.observations:type_name -> weather.WeatherObservation
+	1,  // 14: weather.GetWeatherBatchRequest.locations:type_name -> weather.GetWeatherRequest
+	1,  // 15: weather.WeatherBatchResult.location:type_name -> weather.GetWeatherRequest
+	2,  // 16: weather.WeatherBatchResult.weather:type_name -> weather.GetWeatherResponse
+	11, // 17: weather.GetWeatherBatchResponse.results:type_name -> weather.WeatherBatchResult
+	0,  // 18: weather.WatchWeatherRequest.coordinates:type_name -> weather.Coordinates
+	0,  // 19: weather.City.coordinates:type_name -> weather.Coordinates
+	15, // 20: weather.SearchCitiesResponse.cities:type_name -> weather.City
+	1,  // 21: weather.WeatherService.GetWeather:input_type -> weather.GetWeatherRequest
+	3,  // 22: weather.WeatherService.GetForecast:input_type -> weather.GetForecastRequest
+	7,  // 23: weather.WeatherService.GetWeatherHistory:input_type -> weather.GetWeatherHistoryRequest
+	10, // 24: weather.WeatherService.GetWeatherBatch:input_type -> weather.GetWeatherBatchRequest
+	13, // 25: weather.WeatherService.WatchWeather:input_type -> weather.WatchWeatherRequest
+	14, // 26: weather.WeatherService.SearchCities:input_type -> weather.SearchCitiesRequest
+	2,  // 27: weather.WeatherService.GetWeather:output_type -> weather.GetWeatherResponse
+	6,  // 28: weather.WeatherService.GetForecast:output_type -> weather.GetForecastResponse
+	9,  // 29: weather.WeatherService.GetWeatherHistory:output_type -> weather.GetWeatherHistoryResponse
+	12, // 30: weather.WeatherService.GetWeatherBatch:output_type -> weather.GetWeatherBatchResponse
+	2,  // 31: weather.WeatherService.WatchWeather:output_type -> weather.GetWeatherResponse
+	16, // 32: weather.WeatherService.SearchCities:output_type -> weather.SearchCitiesResponse
+	27, // [27:33] is the sub-list for method output_type
+	21, // [21:27] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_weather_v1_weather_proto_init() }
@@ -172,13 +1439,14 @@ func file_weather_v1_weather_proto_init() {
 	if File_weather_v1_weather_proto != nil {
 		return
 	}
+	file_weather_v1_weather_proto_msgTypes[2].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_weather_v1_weather_proto_rawDesc), len(file_weather_v1_weather_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
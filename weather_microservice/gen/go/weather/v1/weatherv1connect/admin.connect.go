@@ -0,0 +1,225 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: weather/v1/admin.proto
+
+package weatherv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	http "net/http"
+	strings "strings"
+	v1 "weather_microservice/gen/go/weather/v1"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// WeatherAdminServiceName is the fully-qualified name of the WeatherAdminService service.
+	WeatherAdminServiceName = "weather.WeatherAdminService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// WeatherAdminServiceGetCacheStatsProcedure is the fully-qualified name of the
+	// WeatherAdminService's GetCacheStats RPC.
+	WeatherAdminServiceGetCacheStatsProcedure = "/weather.WeatherAdminService/GetCacheStats"
+	// WeatherAdminServiceInspectCacheEntryProcedure is the fully-qualified name of the
+	// WeatherAdminService's InspectCacheEntry RPC.
+	WeatherAdminServiceInspectCacheEntryProcedure = "/weather.WeatherAdminService/InspectCacheEntry"
+	// WeatherAdminServiceInvalidateCacheProcedure is the fully-qualified name of the
+	// WeatherAdminService's InvalidateCache RPC.
+	WeatherAdminServiceInvalidateCacheProcedure = "/weather.WeatherAdminService/InvalidateCache"
+	// WeatherAdminServiceFlushCacheProcedure is the fully-qualified name of the WeatherAdminService's
+	// FlushCache RPC.
+	WeatherAdminServiceFlushCacheProcedure = "/weather.WeatherAdminService/FlushCache"
+	// WeatherAdminServicePurgeNotFoundProcedure is the fully-qualified name of the
+	// WeatherAdminService's PurgeNotFound RPC.
+	WeatherAdminServicePurgeNotFoundProcedure = "/weather.WeatherAdminService/PurgeNotFound"
+)
+
+// WeatherAdminServiceClient is a client for the weather.WeatherAdminService service.
+type WeatherAdminServiceClient interface {
+	GetCacheStats(context.Context, *connect.Request[v1.GetCacheStatsRequest]) (*connect.Response[v1.GetCacheStatsResponse], error)
+	InspectCacheEntry(context.Context, *connect.Request[v1.InspectCacheEntryRequest]) (*connect.Response[v1.InspectCacheEntryResponse], error)
+	InvalidateCache(context.Context, *connect.Request[v1.InvalidateCacheRequest]) (*connect.Response[v1.InvalidateCacheResponse], error)
+	FlushCache(context.Context, *connect.Request[v1.FlushCacheRequest]) (*connect.Response[v1.FlushCacheResponse], error)
+	PurgeNotFound(context.Context, *connect.Request[v1.PurgeNotFoundRequest]) (*connect.Response[v1.PurgeNotFoundResponse], error)
+}
+
+// NewWeatherAdminServiceClient constructs a client for the weather.WeatherAdminService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewWeatherAdminServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) WeatherAdminServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	weatherAdminServiceMethods := v1.File_weather_v1_admin_proto.Services().ByName("WeatherAdminService").Methods()
+	return &weatherAdminServiceClient{
+		getCacheStats: connect.NewClient[v1.GetCacheStatsRequest, v1.GetCacheStatsResponse](
+			httpClient,
+			baseURL+WeatherAdminServiceGetCacheStatsProcedure,
+			connect.WithSchema(weatherAdminServiceMethods.ByName("GetCacheStats")),
+			connect.WithClientOptions(opts...),
+		),
+		inspectCacheEntry: connect.NewClient[v1.InspectCacheEntryRequest, v1.InspectCacheEntryResponse](
+			httpClient,
+			baseURL+WeatherAdminServiceInspectCacheEntryProcedure,
+			connect.WithSchema(weatherAdminServiceMethods.ByName("InspectCacheEntry")),
+			connect.WithClientOptions(opts...),
+		),
+		invalidateCache: connect.NewClient[v1.InvalidateCacheRequest, v1.InvalidateCacheResponse](
+			httpClient,
+			baseURL+WeatherAdminServiceInvalidateCacheProcedure,
+			connect.WithSchema(weatherAdminServiceMethods.ByName("InvalidateCache")),
+			connect.WithClientOptions(opts...),
+		),
+		flushCache: connect.NewClient[v1.FlushCacheRequest, v1.FlushCacheResponse](
+			httpClient,
+			baseURL+WeatherAdminServiceFlushCacheProcedure,
+			connect.WithSchema(weatherAdminServiceMethods.ByName("FlushCache")),
+			connect.WithClientOptions(opts...),
+		),
+		purgeNotFound: connect.NewClient[v1.PurgeNotFoundRequest, v1.PurgeNotFoundResponse](
+			httpClient,
+			baseURL+WeatherAdminServicePurgeNotFoundProcedure,
+			connect.WithSchema(weatherAdminServiceMethods.ByName("PurgeNotFound")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// weatherAdminServiceClient implements WeatherAdminServiceClient.
+type weatherAdminServiceClient struct {
+	getCacheStats     *connect.Client[v1.GetCacheStatsRequest, v1.GetCacheStatsResponse]
+	inspectCacheEntry *connect.Client[v1.InspectCacheEntryRequest, v1.InspectCacheEntryResponse]
+	invalidateCache   *connect.Client[v1.InvalidateCacheRequest, v1.InvalidateCacheResponse]
+	flushCache        *connect.Client[v1.FlushCacheRequest, v1.FlushCacheResponse]
+	purgeNotFound     *connect.Client[v1.PurgeNotFoundRequest, v1.PurgeNotFoundResponse]
+}
+
+// GetCacheStats calls weather.WeatherAdminService.GetCacheStats.
+func (c *weatherAdminServiceClient) GetCacheStats(ctx context.Context, req *connect.Request[v1.GetCacheStatsRequest]) (*connect.Response[v1.GetCacheStatsResponse], error) {
+	return c.getCacheStats.CallUnary(ctx, req)
+}
+
+// InspectCacheEntry calls weather.WeatherAdminService.InspectCacheEntry.
+func (c *weatherAdminServiceClient) InspectCacheEntry(ctx context.Context, req *connect.Request[v1.InspectCacheEntryRequest]) (*connect.Response[v1.InspectCacheEntryResponse], error) {
+	return c.inspectCacheEntry.CallUnary(ctx, req)
+}
+
+// InvalidateCache calls weather.WeatherAdminService.InvalidateCache.
+func (c *weatherAdminServiceClient) InvalidateCache(ctx context.Context, req *connect.Request[v1.InvalidateCacheRequest]) (*connect.Response[v1.InvalidateCacheResponse], error) {
+	return c.invalidateCache.CallUnary(ctx, req)
+}
+
+// FlushCache calls weather.WeatherAdminService.FlushCache.
+func (c *weatherAdminServiceClient) FlushCache(ctx context.Context, req *connect.Request[v1.FlushCacheRequest]) (*connect.Response[v1.FlushCacheResponse], error) {
+	return c.flushCache.CallUnary(ctx, req)
+}
+
+// PurgeNotFound calls weather.WeatherAdminService.PurgeNotFound.
+func (c *weatherAdminServiceClient) PurgeNotFound(ctx context.Context, req *connect.Request[v1.PurgeNotFoundRequest]) (*connect.Response[v1.PurgeNotFoundResponse], error) {
+	return c.purgeNotFound.CallUnary(ctx, req)
+}
+
+// WeatherAdminServiceHandler is an implementation of the weather.WeatherAdminService service.
+type WeatherAdminServiceHandler interface {
+	GetCacheStats(context.Context, *connect.Request[v1.GetCacheStatsRequest]) (*connect.Response[v1.GetCacheStatsResponse], error)
+	InspectCacheEntry(context.Context, *connect.Request[v1.InspectCacheEntryRequest]) (*connect.Response[v1.InspectCacheEntryResponse], error)
+	InvalidateCache(context.Context, *connect.Request[v1.InvalidateCacheRequest]) (*connect.Response[v1.InvalidateCacheResponse], error)
+	FlushCache(context.Context, *connect.Request[v1.FlushCacheRequest]) (*connect.Response[v1.FlushCacheResponse], error)
+	PurgeNotFound(context.Context, *connect.Request[v1.PurgeNotFoundRequest]) (*connect.Response[v1.PurgeNotFoundResponse], error)
+}
+
+// NewWeatherAdminServiceHandler builds an HTTP handler from the service implementation. It returns
+// the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewWeatherAdminServiceHandler(svc WeatherAdminServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	weatherAdminServiceMethods := v1.File_weather_v1_admin_proto.Services().ByName("WeatherAdminService").Methods()
+	weatherAdminServiceGetCacheStatsHandler := connect.NewUnaryHandler(
+		WeatherAdminServiceGetCacheStatsProcedure,
+		svc.GetCacheStats,
+		connect.WithSchema(weatherAdminServiceMethods.ByName("GetCacheStats")),
+		connect.WithHandlerOptions(opts...),
+	)
+	weatherAdminServiceInspectCacheEntryHandler := connect.NewUnaryHandler(
+		WeatherAdminServiceInspectCacheEntryProcedure,
+		svc.InspectCacheEntry,
+		connect.WithSchema(weatherAdminServiceMethods.ByName("InspectCacheEntry")),
+		connect.WithHandlerOptions(opts...),
+	)
+	weatherAdminServiceInvalidateCacheHandler := connect.NewUnaryHandler(
+		WeatherAdminServiceInvalidateCacheProcedure,
+		svc.InvalidateCache,
+		connect.WithSchema(weatherAdminServiceMethods.ByName("InvalidateCache")),
+		connect.WithHandlerOptions(opts...),
+	)
+	weatherAdminServiceFlushCacheHandler := connect.NewUnaryHandler(
+		WeatherAdminServiceFlushCacheProcedure,
+		svc.FlushCache,
+		connect.WithSchema(weatherAdminServiceMethods.ByName("FlushCache")),
+		connect.WithHandlerOptions(opts...),
+	)
+	weatherAdminServicePurgeNotFoundHandler := connect.NewUnaryHandler(
+		WeatherAdminServicePurgeNotFoundProcedure,
+		svc.PurgeNotFound,
+		connect.WithSchema(weatherAdminServiceMethods.ByName("PurgeNotFound")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/weather.WeatherAdminService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case WeatherAdminServiceGetCacheStatsProcedure:
+			weatherAdminServiceGetCacheStatsHandler.ServeHTTP(w, r)
+		case WeatherAdminServiceInspectCacheEntryProcedure:
+			weatherAdminServiceInspectCacheEntryHandler.ServeHTTP(w, r)
+		case WeatherAdminServiceInvalidateCacheProcedure:
+			weatherAdminServiceInvalidateCacheHandler.ServeHTTP(w, r)
+		case WeatherAdminServiceFlushCacheProcedure:
+			weatherAdminServiceFlushCacheHandler.ServeHTTP(w, r)
+		case WeatherAdminServicePurgeNotFoundProcedure:
+			weatherAdminServicePurgeNotFoundHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedWeatherAdminServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedWeatherAdminServiceHandler struct{}
+
+func (UnimplementedWeatherAdminServiceHandler) GetCacheStats(context.Context, *connect.Request[v1.GetCacheStatsRequest]) (*connect.Response[v1.GetCacheStatsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("weather.WeatherAdminService.GetCacheStats is not implemented"))
+}
+
+func (UnimplementedWeatherAdminServiceHandler) InspectCacheEntry(context.Context, *connect.Request[v1.InspectCacheEntryRequest]) (*connect.Response[v1.InspectCacheEntryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("weather.WeatherAdminService.InspectCacheEntry is not implemented"))
+}
+
+func (UnimplementedWeatherAdminServiceHandler) InvalidateCache(context.Context, *connect.Request[v1.InvalidateCacheRequest]) (*connect.Response[v1.InvalidateCacheResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("weather.WeatherAdminService.InvalidateCache is not implemented"))
+}
+
+func (UnimplementedWeatherAdminServiceHandler) FlushCache(context.Context, *connect.Request[v1.FlushCacheRequest]) (*connect.Response[v1.FlushCacheResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("weather.WeatherAdminService.FlushCache is not implemented"))
+}
+
+func (UnimplementedWeatherAdminServiceHandler) PurgeNotFound(context.Context, *connect.Request[v1.PurgeNotFoundRequest]) (*connect.Response[v1.PurgeNotFoundResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("weather.WeatherAdminService.PurgeNotFound is not implemented"))
+}
@@ -36,11 +36,33 @@ const (
 	// WeatherServiceGetWeatherProcedure is the fully-qualified name of the WeatherService's GetWeather
 	// RPC.
 	WeatherServiceGetWeatherProcedure = "/weather.WeatherService/GetWeather"
+	// WeatherServiceGetForecastProcedure is the fully-qualified name of the WeatherService's
+	// GetForecast RPC.
+	WeatherServiceGetForecastProcedure = "/weather.WeatherService/GetForecast"
+	// WeatherServiceGetWeatherHistoryProcedure is the fully-qualified name of the WeatherService's
+	// GetWeatherHistory RPC.
+	WeatherServiceGetWeatherHistoryProcedure = "/weather.WeatherService/GetWeatherHistory"
+	// WeatherServiceGetWeatherBatchProcedure is the fully-qualified name of the WeatherService's
+	// GetWeatherBatch RPC.
+	WeatherServiceGetWeatherBatchProcedure = "/weather.WeatherService/GetWeatherBatch"
+	// WeatherServiceWatchWeatherProcedure is the fully-qualified name of the WeatherService's
+	// WatchWeather RPC.
+	WeatherServiceWatchWeatherProcedure = "/weather.WeatherService/WatchWeather"
+	// WeatherServiceSearchCitiesProcedure is the fully-qualified name of the WeatherService's
+	// SearchCities RPC.
+	WeatherServiceSearchCitiesProcedure = "/weather.WeatherService/SearchCities"
 )
 
 // WeatherServiceClient is a client for the weather.WeatherService service.
 type WeatherServiceClient interface {
 	GetWeather(context.Context, *connect.Request[v1.GetWeatherRequest]) (*connect.Response[v1.GetWeatherResponse], error)
+	GetForecast(context.Context, *connect.Request[v1.GetForecastRequest]) (*connect.Response[v1.GetForecastResponse], error)
+	GetWeatherHistory(context.Context, *connect.Request[v1.GetWeatherHistoryRequest]) (*connect.Response[v1.GetWeatherHistoryResponse], error)
+	GetWeatherBatch(context.Context, *connect.Request[v1.GetWeatherBatchRequest]) (*connect.Response[v1.GetWeatherBatchResponse], error)
+	// Sends the current weather, then a new value whenever it is refreshed or changes.
+	WatchWeather(context.Context, *connect.Request[v1.WatchWeatherRequest]) (*connect.ServerStreamForClient[v1.GetWeatherResponse], error)
+	// Suggests cities by the beginning of their name, best match first.
+	SearchCities(context.Context, *connect.Request[v1.SearchCitiesRequest]) (*connect.Response[v1.SearchCitiesResponse], error)
 }
 
 // NewWeatherServiceClient constructs a client for the weather.WeatherService service. By default,
@@ -60,12 +82,47 @@ func NewWeatherServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(weatherServiceMethods.ByName("GetWeather")),
 			connect.WithClientOptions(opts...),
 		),
+		getForecast: connect.NewClient[v1.GetForecastRequest, v1.GetForecastResponse](
+			httpClient,
+			baseURL+WeatherServiceGetForecastProcedure,
+			connect.WithSchema(weatherServiceMethods.ByName("GetForecast")),
+			connect.WithClientOptions(opts...),
+		),
+		getWeatherHistory: connect.NewClient[v1.GetWeatherHistoryRequest, v1.GetWeatherHistoryResponse](
+			httpClient,
+			baseURL+WeatherServiceGetWeatherHistoryProcedure,
+			connect.WithSchema(weatherServiceMethods.ByName("GetWeatherHistory")),
+			connect.WithClientOptions(opts...),
+		),
+		getWeatherBatch: connect.NewClient[v1.GetWeatherBatchRequest, v1.GetWeatherBatchResponse](
+			httpClient,
+			baseURL+WeatherServiceGetWeatherBatchProcedure,
+			connect.WithSchema(weatherServiceMethods.ByName("GetWeatherBatch")),
+			connect.WithClientOptions(opts...),
+		),
+		watchWeather: connect.NewClient[v1.WatchWeatherRequest, v1.GetWeatherResponse](
+			httpClient,
+			baseURL+WeatherServiceWatchWeatherProcedure,
+			connect.WithSchema(weatherServiceMethods.ByName("WatchWeather")),
+			connect.WithClientOptions(opts...),
+		),
+		searchCities: connect.NewClient[v1.SearchCitiesRequest, v1.SearchCitiesResponse](
+			httpClient,
+			baseURL+WeatherServiceSearchCitiesProcedure,
+			connect.WithSchema(weatherServiceMethods.ByName("SearchCities")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // weatherServiceClient implements WeatherServiceClient.
 type weatherServiceClient struct {
-	getWeather *connect.Client[v1.GetWeatherRequest, v1.GetWeatherResponse]
+	getWeather        *connect.Client[v1.GetWeatherRequest, v1.GetWeatherResponse]
+	getForecast       *connect.Client[v1.GetForecastRequest, v1.GetForecastResponse]
+	getWeatherHistory *connect.Client[v1.GetWeatherHistoryRequest, v1.GetWeatherHistoryResponse]
+	getWeatherBatch   *connect.Client[v1.GetWeatherBatchRequest, v1.GetWeatherBatchResponse]
+	watchWeather      *connect.Client[v1.WatchWeatherRequest, v1.GetWeatherResponse]
+	searchCities      *connect.Client[v1.SearchCitiesRequest, v1.SearchCitiesResponse]
 }
 
 // GetWeather calls weather.WeatherService.GetWeather.
@@ -73,9 +130,41 @@ func (c *weatherServiceClient) GetWeather(ctx context.Context, req *connect.Requ
 	return c.getWeather.CallUnary(ctx, req)
 }
 
+// GetForecast calls weather.WeatherService.GetForecast.
+func (c *weatherServiceClient) GetForecast(ctx context.Context, req *connect.Request[v1.GetForecastRequest]) (*connect.Response[v1.GetForecastResponse], error) {
+	return c.getForecast.CallUnary(ctx, req)
+}
+
+// GetWeatherHistory calls weather.WeatherService.GetWeatherHistory.
+func (c *weatherServiceClient) GetWeatherHistory(ctx context.Context, req *connect.Request[v1.GetWeatherHistoryRequest]) (*connect.Response[v1.GetWeatherHistoryResponse], error) {
+	return c.getWeatherHistory.CallUnary(ctx, req)
+}
+
+// GetWeatherBatch calls weather.WeatherService.GetWeatherBatch.
+func (c *weatherServiceClient) GetWeatherBatch(ctx context.Context, req *connect.Request[v1.GetWeatherBatchRequest]) (*connect.Response[v1.GetWeatherBatchResponse], error) {
+	return c.getWeatherBatch.CallUnary(ctx, req)
+}
+
+// WatchWeather calls weather.WeatherService.WatchWeather.
+func (c *weatherServiceClient) WatchWeather(ctx context.Context, req *connect.Request[v1.WatchWeatherRequest]) (*connect.ServerStreamForClient[v1.GetWeatherResponse], error) {
+	return c.watchWeather.CallServerStream(ctx, req)
+}
+
+// SearchCities calls weather.WeatherService.SearchCities.
+func (c *weatherServiceClient) SearchCities(ctx context.Context, req *connect.Request[v1.SearchCitiesRequest]) (*connect.Response[v1.SearchCitiesResponse], error) {
+	return c.searchCities.CallUnary(ctx, req)
+}
+
 // WeatherServiceHandler is an implementation of the weather.WeatherService service.
 type WeatherServiceHandler interface {
 	GetWeather(context.Context, *connect.Request[v1.GetWeatherRequest]) (*connect.Response[v1.GetWeatherResponse], error)
+	GetForecast(context.Context, *connect.Request[v1.GetForecastRequest]) (*connect.Response[v1.GetForecastResponse], error)
+	GetWeatherHistory(context.Context, *connect.Request[v1.GetWeatherHistoryRequest]) (*connect.Response[v1.GetWeatherHistoryResponse], error)
+	GetWeatherBatch(context.Context, *connect.Request[v1.GetWeatherBatchRequest]) (*connect.Response[v1.GetWeatherBatchResponse], error)
+	// Sends the current weather, then a new value whenever it is refreshed or changes.
+	WatchWeather(context.Context, *connect.Request[v1.WatchWeatherRequest], *connect.ServerStream[v1.GetWeatherResponse]) error
+	// Suggests cities by the beginning of their name, best match first.
+	SearchCities(context.Context, *connect.Request[v1.SearchCitiesRequest]) (*connect.Response[v1.SearchCitiesResponse], error)
 }
 
 // NewWeatherServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -91,10 +180,50 @@ func NewWeatherServiceHandler(svc WeatherServiceHandler, opts ...connect.Handler
 		connect.WithSchema(weatherServiceMethods.ByName("GetWeather")),
 		connect.WithHandlerOptions(opts...),
 	)
+	weatherServiceGetForecastHandler := connect.NewUnaryHandler(
+		WeatherServiceGetForecastProcedure,
+		svc.GetForecast,
+		connect.WithSchema(weatherServiceMethods.ByName("GetForecast")),
+		connect.WithHandlerOptions(opts...),
+	)
+	weatherServiceGetWeatherHistoryHandler := connect.NewUnaryHandler(
+		WeatherServiceGetWeatherHistoryProcedure,
+		svc.GetWeatherHistory,
+		connect.WithSchema(weatherServiceMethods.ByName("GetWeatherHistory")),
+		connect.WithHandlerOptions(opts...),
+	)
+	weatherServiceGetWeatherBatchHandler := connect.NewUnaryHandler(
+		WeatherServiceGetWeatherBatchProcedure,
+		svc.GetWeatherBatch,
+		connect.WithSchema(weatherServiceMethods.ByName("GetWeatherBatch")),
+		connect.WithHandlerOptions(opts...),
+	)
+	weatherServiceWatchWeatherHandler := connect.NewServerStreamHandler(
+		WeatherServiceWatchWeatherProcedure,
+		svc.WatchWeather,
+		connect.WithSchema(weatherServiceMethods.ByName("WatchWeather")),
+		connect.WithHandlerOptions(opts...),
+	)
+	weatherServiceSearchCitiesHandler := connect.NewUnaryHandler(
+		WeatherServiceSearchCitiesProcedure,
+		svc.SearchCities,
+		connect.WithSchema(weatherServiceMethods.ByName("SearchCities")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/weather.WeatherService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case WeatherServiceGetWeatherProcedure:
 			weatherServiceGetWeatherHandler.ServeHTTP(w, r)
+		case WeatherServiceGetForecastProcedure:
+			weatherServiceGetForecastHandler.ServeHTTP(w, r)
+		case WeatherServiceGetWeatherHistoryProcedure:
+			weatherServiceGetWeatherHistoryHandler.ServeHTTP(w, r)
+		case WeatherServiceGetWeatherBatchProcedure:
+			weatherServiceGetWeatherBatchHandler.ServeHTTP(w, r)
+		case WeatherServiceWatchWeatherProcedure:
+			weatherServiceWatchWeatherHandler.ServeHTTP(w, r)
+		case WeatherServiceSearchCitiesProcedure:
+			weatherServiceSearchCitiesHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -107,3 +236,23 @@ type UnimplementedWeatherServiceHandler struct{}
 func (UnimplementedWeatherServiceHandler) GetWeather(context.Context, *connect.Request[v1.GetWeatherRequest]) (*connect.Response[v1.GetWeatherResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("weather.WeatherService.GetWeather is not implemented"))
 }
+
+func (UnimplementedWeatherServiceHandler) GetForecast(context.Context, *connect.Request[v1.GetForecastRequest]) (*connect.Response[v1.GetForecastResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("weather.WeatherService.GetForecast is not implemented"))
+}
+
+func (UnimplementedWeatherServiceHandler) GetWeatherHistory(context.Context, *connect.Request[v1.GetWeatherHistoryRequest]) (*connect.Response[v1.GetWeatherHistoryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("weather.WeatherService.GetWeatherHistory is not implemented"))
+}
+
+func (UnimplementedWeatherServiceHandler) GetWeatherBatch(context.Context, *connect.Request[v1.GetWeatherBatchRequest]) (*connect.Response[v1.GetWeatherBatchResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("weather.WeatherService.GetWeatherBatch is not implemented"))
+}
+
+func (UnimplementedWeatherServiceHandler) WatchWeather(context.Context, *connect.Request[v1.WatchWeatherRequest], *connect.ServerStream[v1.GetWeatherResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("weather.WeatherService.WatchWeather is not implemented"))
+}
+
+func (UnimplementedWeatherServiceHandler) SearchCities(context.Context, *connect.Request[v1.SearchCitiesRequest]) (*connect.Response[v1.SearchCitiesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("weather.WeatherService.SearchCities is not implemented"))
+}
@@ -0,0 +1,253 @@
+// Package adaptertest records real HTTP exchanges of provider adapters into
+// fixture files and replays them offline through an http.RoundTripper.
+//
+// Tests normally replay fixtures from testdata. Setting ADAPTERS_RECORD=1
+// sends the requests to the real providers instead and rewrites the fixtures;
+// API keys are then read from the environment, see APIKey. Secrets in query
+// strings are redacted before anything is written or matched.
+package adaptertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// RecordEnv switches tests using Client from replaying to recording.
+const RecordEnv = "ADAPTERS_RECORD"
+
+// Redacted replaces the values of secret query parameters.
+const Redacted = "REDACTED"
+
+// SecretParams are query parameters carrying provider API keys.
+var SecretParams = []string{"appid", "key", "apikey", "api_key", "token"}
+
+// Exchange is one recorded request and its response.
+type Exchange struct {
+	Method string `json:"method"`
+	// URL is the request URL with secrets redacted, see RedactURL.
+	URL    string      `json:"url"`
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body"`
+}
+
+// RedactURL returns u with the values of SecretParams replaced by Redacted
+// and the query parameters sorted, so equal requests yield equal strings.
+func RedactURL(u *url.URL) string {
+	redacted := *u
+	query := u.Query()
+	for name := range query {
+		if isSecret(name) {
+			query.Set(name, Redacted)
+		}
+	}
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
+}
+
+func isSecret(param string) bool {
+	for _, secret := range SecretParams {
+		if strings.EqualFold(param, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// Recorder is a RoundTripper that passes requests on and records every exchange.
+type Recorder struct {
+	next      http.RoundTripper
+	mu        sync.Mutex
+	exchanges []Exchange
+}
+
+// NewRecorder records the exchanges of next; nil means http.DefaultTransport.
+func NewRecorder(next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{next: next}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response of %s %s: %w", req.Method, RedactURL(req.URL), err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := http.Header{}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exchanges = append(r.exchanges, Exchange{
+		Method: req.Method,
+		URL:    RedactURL(req.URL),
+		Status: resp.StatusCode,
+		Header: header,
+		Body:   string(body),
+	})
+	return resp, nil
+}
+
+// Exchanges returns the exchanges recorded so far, in request order.
+func (r *Recorder) Exchanges() []Exchange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Exchange(nil), r.exchanges...)
+}
+
+// Save writes the recorded exchanges to a fixture file, creating its directory.
+func (r *Recorder) Save(path string) error {
+	// URLs stay readable without HTML escaping of "&".
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(r.Exchanges()); err != nil {
+		return fmt.Errorf("failed to encode exchanges: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// Replayer is a RoundTripper that answers requests from recorded exchanges.
+// Requests match by method and redacted URL; repeated requests get the
+// recorded responses in order, and the last one once they run out.
+type Replayer struct {
+	mu        sync.Mutex
+	exchanges map[string][]Exchange
+}
+
+// NewReplayer replays the given exchanges.
+func NewReplayer(exchanges []Exchange) *Replayer {
+	r := &Replayer{exchanges: make(map[string][]Exchange)}
+	for _, e := range exchanges {
+		key := e.Method + " " + e.URL
+		r.exchanges[key] = append(r.exchanges[key], e)
+	}
+	return r
+}
+
+// LoadReplayer replays the exchanges of a fixture file written by Recorder.Save.
+func LoadReplayer(path string) (*Replayer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	var exchanges []Exchange
+	if err := json.Unmarshal(data, &exchanges); err != nil {
+		return nil, fmt.Errorf("failed to decode fixture %s: %w", path, err)
+	}
+	return NewReplayer(exchanges), nil
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	key := req.Method + " " + RedactURL(req.URL)
+
+	r.mu.Lock()
+	queue := r.exchanges[key]
+	if len(queue) == 0 {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("no recorded response for %s, known requests: %s", key, strings.Join(r.keys(), "; "))
+	}
+	e := queue[0]
+	if len(queue) > 1 {
+		r.exchanges[key] = queue[1:]
+	}
+	r.mu.Unlock()
+
+	header := e.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status)),
+		StatusCode:    e.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}, nil
+}
+
+// keys lists the recorded requests; the caller holds r.mu.
+func (r *Replayer) keys() []string {
+	keys := make([]string, 0, len(r.exchanges))
+	for key := range r.exchanges {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Recording reports whether RecordEnv asks to record fixtures.
+func Recording() bool {
+	return os.Getenv(RecordEnv) == "1"
+}
+
+// Client returns an HTTP client that replays the fixture file, or records it
+// from the real provider when Recording. Recorded fixtures are saved when
+// the test ends, unless it failed.
+func Client(t testing.TB, fixture string) *http.Client {
+	t.Helper()
+	if !Recording() {
+		replayer, err := LoadReplayer(fixture)
+		if err != nil {
+			t.Fatalf("%v (record it with %s=1)", err, RecordEnv)
+		}
+		return &http.Client{Transport: replayer}
+	}
+
+	recorder := NewRecorder(nil)
+	t.Cleanup(func() {
+		if t.Failed() {
+			return
+		}
+		if err := recorder.Save(fixture); err != nil {
+			t.Errorf("failed to save fixture: %v", err)
+		}
+	})
+	return &http.Client{Transport: recorder}
+}
+
+// APIKey returns the provider API key from env when Recording, skipping the
+// test if it is not set. Replayed requests are matched with the key
+// redacted, so a placeholder is returned otherwise.
+func APIKey(t testing.TB, env string) string {
+	t.Helper()
+	if !Recording() {
+		return "replay-key"
+	}
+	key := os.Getenv(env)
+	if key == "" {
+		t.Skipf("%s is required to record fixtures", env)
+	}
+	return key
+}
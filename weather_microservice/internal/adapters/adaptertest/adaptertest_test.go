@@ -0,0 +1,77 @@
+package adaptertest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactURL(t *testing.T) {
+	u, err := url.Parse("https://api.example.com/weather?q=Kyiv&appid=secret&units=metric&KEY=other")
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://api.example.com/weather?KEY=REDACTED&appid=REDACTED&q=Kyiv&units=metric", RedactURL(u))
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "dropped")
+		if r.URL.Query().Get("q") == "Atlantis" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"message":"city not found"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"temp":`+r.URL.Query().Get("n")+`}`)
+	}))
+	defer server.Close()
+
+	recorder := NewRecorder(nil)
+	client := &http.Client{Transport: recorder}
+	for _, query := range []string{"q=Kyiv&n=1&key=secret", "q=Kyiv&n=1&key=secret", "q=Atlantis&key=secret"} {
+		resp, err := client.Get(server.URL + "/weather?" + query)
+		require.NoError(t, err)
+		_, _ = io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+	}
+
+	fixture := filepath.Join(t.TempDir(), "testdata", "fixture.json")
+	require.NoError(t, recorder.Save(fixture))
+	raw, err := os.ReadFile(fixture)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "secret")
+	assert.NotContains(t, string(raw), "X-Request-Id")
+
+	replayer, err := LoadReplayer(fixture)
+	require.NoError(t, err)
+	client = &http.Client{Transport: replayer}
+
+	// The key differs from the recorded one, which does not matter once redacted.
+	for range 3 {
+		resp, err := client.Get(server.URL + "/weather?key=other&n=1&q=Kyiv")
+		require.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"temp":1}`, string(body))
+	}
+
+	resp, err := client.Get(server.URL + "/weather?q=Atlantis&key=other")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	_, err = client.Get(server.URL + "/weather?q=Lviv&key=other")
+	require.ErrorContains(t, err, "no recorded response")
+	assert.Equal(t, 3, calls)
+}
@@ -0,0 +1,24 @@
+package adapters
+
+import "weather_microservice/internal/contracts"
+
+// nativeCondition is a provider weather code resolved into the canonical taxonomy.
+type nativeCondition struct {
+	description string
+	condition   contracts.Condition
+	severity    contracts.Severity
+}
+
+// withCondition fills the canonical condition fields of data.
+func withCondition(data contracts.WeatherData, c nativeCondition, isDay bool) contracts.WeatherData {
+	data.Condition = c.condition
+	data.Severity = c.severity
+	data.Icon = contracts.ConditionIcon(c.condition, isDay)
+	return data
+}
+
+// unknownCondition is used for codes missing from the provider tables.
+var unknownCondition = nativeCondition{
+	condition: contracts.ConditionUnknown,
+	severity:  contracts.SeverityNone,
+}
@@ -0,0 +1,86 @@
+package adapters
+
+import (
+	"sort"
+	"time"
+
+	"weather_microservice/internal/contracts"
+)
+
+// aggregateDaily groups hourly forecast points into calendar days of the given
+// location and returns at most maxDays days in chronological order.
+func aggregateDaily(hourly []contracts.HourlyForecast, loc *time.Location, maxDays int) []contracts.DailyForecast {
+	type dayAcc struct {
+		min, max, humiditySum float64
+		count                 int
+		descriptions          map[string]int
+	}
+
+	days := make(map[string]*dayAcc)
+	var order []string
+
+	for _, h := range hourly {
+		date := h.Time.In(loc).Format("2006-01-02")
+		acc, ok := days[date]
+		if !ok {
+			acc = &dayAcc{
+				min:          h.Temperature,
+				max:          h.Temperature,
+				descriptions: make(map[string]int),
+			}
+			days[date] = acc
+			order = append(order, date)
+		}
+		if h.Temperature < acc.min {
+			acc.min = h.Temperature
+		}
+		if h.Temperature > acc.max {
+			acc.max = h.Temperature
+		}
+		acc.humiditySum += h.Humidity
+		acc.count++
+		acc.descriptions[h.Description]++
+	}
+
+	sort.Strings(order)
+	if len(order) > maxDays {
+		order = order[:maxDays]
+	}
+
+	daily := make([]contracts.DailyForecast, 0, len(order))
+	for _, date := range order {
+		acc := days[date]
+		daily = append(daily, contracts.DailyForecast{
+			Date:           date,
+			MinTemperature: acc.min,
+			MaxTemperature: acc.max,
+			Humidity:       acc.humiditySum / float64(acc.count),
+			Description:    mostFrequent(acc.descriptions),
+		})
+	}
+	return daily
+}
+
+// hourlyThrough drops the points after the calendar day lastDate ("2006-01-02")
+// in loc, such as the extra day fetched to complete the last requested day.
+func hourlyThrough(hourly []contracts.HourlyForecast, loc *time.Location, lastDate string) []contracts.HourlyForecast {
+	kept := hourly[:0:0]
+	for _, h := range hourly {
+		if h.Time.In(loc).Format("2006-01-02") <= lastDate {
+			kept = append(kept, h)
+		}
+	}
+	return kept
+}
+
+// mostFrequent returns the most common description, preferring the
+// lexicographically smallest one on ties to keep results deterministic.
+func mostFrequent(counts map[string]int) string {
+	best, bestCount := "", 0
+	for desc, count := range counts {
+		if count > bestCount || (count == bestCount && desc < best) {
+			best, bestCount = desc, count
+		}
+	}
+	return best
+}
@@ -0,0 +1,298 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+)
+
+const OPENMETEO_SERVER_TIMEOUT = 10 * time.Second
+
+// openMeteoMaxForecastDays is the limit of the /forecast endpoint.
+const openMeteoMaxForecastDays = 16
+
+// OpenMeteoAdapter fetches weather from Open-Meteo, which requires no API key.
+// City names are resolved to coordinates through the Open-Meteo geocoding API.
+type OpenMeteoAdapter struct {
+	client *http.Client
+}
+
+var OpenMeteoAPIBaseURL = func() string {
+	return "https://api.open-meteo.com/v1"
+}
+
+var OpenMeteoGeocodingBaseURL = func() string {
+	return "https://geocoding-api.open-meteo.com/v1"
+}
+
+func NewOpenMeteoAdapter() OpenMeteoAdapter {
+	return OpenMeteoAdapter{
+		client: &http.Client{Timeout: OPENMETEO_SERVER_TIMEOUT},
+	}
+}
+
+// SetTimeout overrides the HTTP timeout of requests to the provider.
+func (a *OpenMeteoAdapter) SetTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		client := *a.client
+		client.Timeout = timeout
+		a.client = &client
+	}
+}
+
+// SetHTTPClient replaces the client of requests to the provider, e.g. to
+// record or replay its responses in tests. The client's own timeout applies.
+func (a *OpenMeteoAdapter) SetHTTPClient(client *http.Client) {
+	if client != nil {
+		a.client = client
+	}
+}
+
+// openMeteoPlace is a location resolved to coordinates.
+type openMeteoPlace struct {
+	Name        string  `json:"name"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	Admin1      string  `json:"admin1"` // Region, e.g. a state or oblast.
+	Country     string  `json:"country"`
+	CountryCode string  `json:"country_code"`
+}
+
+func (a *OpenMeteoAdapter) FetchWeather(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error) {
+	place, err := a.resolve(ctx, loc)
+	if err != nil {
+		return contracts.WeatherData{}, err
+	}
+
+	query := url.Values{}
+	query.Set("latitude", fmt.Sprintf("%f", place.Latitude))
+	query.Set("longitude", fmt.Sprintf("%f", place.Longitude))
+	query.Set("current", "temperature_2m,relative_humidity_2m,apparent_temperature,precipitation,"+
+		"weather_code,cloud_cover,pressure_msl,wind_speed_10m,wind_direction_10m,uv_index,visibility,is_day")
+	query.Set("daily", "sunrise,sunset")
+	query.Set("forecast_days", "1")
+	query.Set("wind_speed_unit", "ms")
+	query.Set("timezone", "auto")
+	query.Set("timeformat", "unixtime")
+
+	var weatherResp struct {
+		Current struct {
+			Time          int64    `json:"time"`
+			Temperature   float64  `json:"temperature_2m"`
+			Humidity      float64  `json:"relative_humidity_2m"`
+			FeelsLike     float64  `json:"apparent_temperature"`
+			Precipitation float64  `json:"precipitation"`
+			WeatherCode   int      `json:"weather_code"`
+			CloudCover    float64  `json:"cloud_cover"`
+			Pressure      float64  `json:"pressure_msl"`
+			WindSpeed     float64  `json:"wind_speed_10m"`
+			WindDirection float64  `json:"wind_direction_10m"`
+			UVIndex       *float64 `json:"uv_index"`
+			Visibility    float64  `json:"visibility"` // Meters.
+			IsDay         int      `json:"is_day"`
+		} `json:"current"`
+		Daily struct {
+			Sunrise []int64 `json:"sunrise"`
+			Sunset  []int64 `json:"sunset"`
+		} `json:"daily"`
+		UTCOffsetSeconds int `json:"utc_offset_seconds"`
+	}
+	if err := a.getJSON(ctx, OpenMeteoAPIBaseURL()+"/forecast?"+query.Encode(), &weatherResp); err != nil {
+		return contracts.WeatherData{}, err
+	}
+
+	current := weatherResp.Current
+	data := contracts.WeatherData{
+		Temperature:   current.Temperature,
+		Humidity:      current.Humidity,
+		Description:   wmoDescription(current.WeatherCode),
+		FeelsLike:     current.FeelsLike,
+		WindSpeed:     current.WindSpeed,
+		WindDirection: current.WindDirection,
+		Pressure:      current.Pressure,
+		Precipitation: current.Precipitation,
+		CloudCover:    current.CloudCover,
+		UVIndex:       current.UVIndex,
+		Visibility:    current.Visibility / 1000,
+		ObservedAt:    unixTime(current.Time),
+		UTCOffset:     weatherResp.UTCOffsetSeconds,
+	}
+	if len(weatherResp.Daily.Sunrise) > 0 && len(weatherResp.Daily.Sunset) > 0 {
+		data.Sunrise = unixTime(weatherResp.Daily.Sunrise[0])
+		data.Sunset = unixTime(weatherResp.Daily.Sunset[0])
+	}
+	return withCondition(data, wmoCondition(current.WeatherCode), current.IsDay == 1), nil
+}
+
+// FetchForecast returns hourly and daily forecast from Open-Meteo.
+func (a *OpenMeteoAdapter) FetchForecast(ctx context.Context, loc contracts.Location, days int) (contracts.ForecastData, error) {
+	if days < 1 || days > openMeteoMaxForecastDays {
+		return contracts.ForecastData{}, fmt.Errorf("open-meteo supports 1-%d forecast days, got %d", openMeteoMaxForecastDays, days)
+	}
+	place, err := a.resolve(ctx, loc)
+	if err != nil {
+		return contracts.ForecastData{}, err
+	}
+
+	query := url.Values{}
+	query.Set("latitude", fmt.Sprintf("%f", place.Latitude))
+	query.Set("longitude", fmt.Sprintf("%f", place.Longitude))
+	query.Set("hourly", "temperature_2m,relative_humidity_2m,weather_code")
+	query.Set("forecast_days", fmt.Sprintf("%d", days))
+	query.Set("timezone", "auto")
+	query.Set("timeformat", "unixtime")
+
+	var forecastResp struct {
+		UTCOffsetSeconds int `json:"utc_offset_seconds"`
+		Hourly           struct {
+			Time        []int64   `json:"time"`
+			Temperature []float64 `json:"temperature_2m"`
+			Humidity    []float64 `json:"relative_humidity_2m"`
+			WeatherCode []int     `json:"weather_code"`
+		} `json:"hourly"`
+	}
+	if err := a.getJSON(ctx, OpenMeteoAPIBaseURL()+"/forecast?"+query.Encode(), &forecastResp); err != nil {
+		return contracts.ForecastData{}, err
+	}
+
+	hourly := forecastResp.Hourly
+	if len(hourly.Time) == 0 {
+		return contracts.ForecastData{}, fmt.Errorf("no forecast data found")
+	}
+	if len(hourly.Temperature) != len(hourly.Time) || len(hourly.Humidity) != len(hourly.Time) ||
+		len(hourly.WeatherCode) != len(hourly.Time) {
+		return contracts.ForecastData{}, fmt.Errorf("malformed open-meteo hourly forecast")
+	}
+
+	forecast := contracts.ForecastData{
+		City: place.Name,
+	}
+	for i, ts := range hourly.Time {
+		forecast.Hourly = append(forecast.Hourly, contracts.HourlyForecast{
+			Time:        time.Unix(ts, 0).UTC(),
+			Temperature: hourly.Temperature[i],
+			Humidity:    hourly.Humidity[i],
+			Description: wmoDescription(hourly.WeatherCode[i]),
+		})
+	}
+	cityZone := time.FixedZone("", forecastResp.UTCOffsetSeconds)
+	forecast.Daily = aggregateDaily(forecast.Hourly, cityZone, days)
+
+	return forecast, nil
+}
+
+// resolve turns the location into coordinates, geocoding the city name when
+// the location is not pinned by coordinates already.
+func (a *OpenMeteoAdapter) resolve(ctx context.Context, loc contracts.Location) (openMeteoPlace, error) {
+	if loc.IsEmpty() {
+		return openMeteoPlace{}, fmt.Errorf("empty location provided")
+	}
+	if loc.HasCoordinates() {
+		return openMeteoPlace{
+			Name:      loc.String(),
+			Latitude:  loc.Coordinates.Lat,
+			Longitude: loc.Coordinates.Lon,
+		}, nil
+	}
+
+	// Unlike the other providers, the geocoding API does not understand
+	// city IDs such as "kyiv,ua", so the country code is passed separately.
+	name, countryCode := loc.City, loc.CountryCode
+	if countryCode == "" {
+		name, countryCode = contracts.SplitCityID(name)
+	}
+	places, err := a.search(ctx, name, countryCode, 1)
+	if err != nil {
+		return openMeteoPlace{}, err
+	}
+	if len(places) == 0 {
+		return openMeteoPlace{}, apierrors.ErrCityNotFound
+	}
+	return places[0], nil
+}
+
+// SearchCities looks cities up through the Open-Meteo geocoding API.
+func (a *OpenMeteoAdapter) SearchCities(ctx context.Context, query string, limit int) ([]contracts.City, error) {
+	places, err := a.search(ctx, query, "", limit)
+	if err != nil {
+		return nil, err
+	}
+	cities := make([]contracts.City, 0, len(places))
+	for _, p := range places {
+		cities = append(cities, contracts.City{
+			ID:          contracts.CityID(p.Name, p.CountryCode),
+			Name:        p.Name,
+			Region:      p.Admin1,
+			Country:     p.Country,
+			CountryCode: p.CountryCode,
+			Lat:         p.Latitude,
+			Lon:         p.Longitude,
+		})
+	}
+	return cities, nil
+}
+
+// search returns up to count places matching name, best match first.
+func (a *OpenMeteoAdapter) search(ctx context.Context, name, countryCode string, count int) ([]openMeteoPlace, error) {
+	query := url.Values{}
+	query.Set("name", strings.TrimSpace(name))
+	query.Set("count", strconv.Itoa(count))
+	query.Set("language", "en")
+	query.Set("format", "json")
+	if countryCode != "" {
+		query.Set("countryCode", strings.ToUpper(countryCode))
+	}
+
+	var geoResp struct {
+		Results []openMeteoPlace `json:"results"`
+	}
+	if err := a.getJSON(ctx, OpenMeteoGeocodingBaseURL()+"/search?"+query.Encode(), &geoResp); err != nil {
+		return nil, err
+	}
+	return geoResp.Results, nil
+}
+
+// getJSON performs a GET request and decodes the JSON body into out.
+// Open-Meteo reports failures as {"error": true, "reason": "..."}.
+func (a *OpenMeteoAdapter) getJSON(ctx context.Context, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get weather from Open-Meteo: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read Open-Meteo response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Reason string `json:"reason"`
+		}
+		_ = json.Unmarshal(body, &errResp)
+		return fmt.Errorf("open-meteo returned status %d: %s", resp.StatusCode, errResp.Reason)
+	}
+
+	if len(body) == 0 {
+		return fmt.Errorf("empty response body from Open-Meteo")
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode Open-Meteo response: %w", err)
+	}
+	return nil
+}
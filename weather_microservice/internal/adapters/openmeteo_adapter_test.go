@@ -0,0 +1,192 @@
+package adapters_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"weather_microservice/internal/adapters"
+	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+)
+
+// withOpenMeteoServer points both Open-Meteo base URLs at a stub server.
+func withOpenMeteoServer(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+	mockServer := httptest.NewServer(handler)
+	t.Cleanup(mockServer.Close)
+
+	originalAPIURL := adapters.OpenMeteoAPIBaseURL
+	originalGeocodingURL := adapters.OpenMeteoGeocodingBaseURL
+	adapters.OpenMeteoAPIBaseURL = func() string { return mockServer.URL }
+	adapters.OpenMeteoGeocodingBaseURL = func() string { return mockServer.URL }
+	t.Cleanup(func() {
+		adapters.OpenMeteoAPIBaseURL = originalAPIURL
+		adapters.OpenMeteoGeocodingBaseURL = originalGeocodingURL
+	})
+}
+
+func TestOpenMeteoAdapter_Success(t *testing.T) {
+	withOpenMeteoServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/search":
+			assert.Equal(t, "Kyiv", r.URL.Query().Get("name"))
+			assert.Equal(t, "UA", r.URL.Query().Get("countryCode"))
+			_, _ = w.Write([]byte(`{"results":[{"name":"Kyiv","latitude":50.45,"longitude":30.52}]}`))
+		case "/forecast":
+			assert.Equal(t, "50.450000", r.URL.Query().Get("latitude"))
+			assert.Equal(t, "30.520000", r.URL.Query().Get("longitude"))
+			assert.Equal(t, "ms", r.URL.Query().Get("wind_speed_unit"))
+			_, _ = w.Write([]byte(`{"current":{"time":1717405200,"temperature_2m":18.4,"relative_humidity_2m":63,` +
+				`"apparent_temperature":17.9,"precipitation":0.1,"weather_code":2,"cloud_cover":40,"pressure_msl":1015.2,` +
+				`"wind_speed_10m":3.5,"wind_direction_10m":180,"uv_index":4.2,"visibility":24140,"is_day":1},` +
+				`"daily":{"sunrise":[1717380000],"sunset":[1717438800]},"utc_offset_seconds":10800}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	adapter := adapters.NewOpenMeteoAdapter()
+	data, err := adapter.FetchWeather(context.Background(), contracts.Location{City: "Kyiv", CountryCode: "ua"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 18.4, data.Temperature)
+	assert.Equal(t, 63.0, data.Humidity)
+	assert.Equal(t, "Partly cloudy", data.Description)
+	assert.Equal(t, contracts.ConditionPartlyCloudy, data.Condition)
+	assert.Equal(t, "partly-cloudy-day", data.Icon)
+	assert.Equal(t, 17.9, data.FeelsLike)
+	assert.Equal(t, 3.5, data.WindSpeed)
+	assert.Equal(t, 180.0, data.WindDirection)
+	assert.Equal(t, 1015.2, data.Pressure)
+	assert.Equal(t, 0.1, data.Precipitation)
+	assert.Equal(t, 40.0, data.CloudCover)
+	if assert.NotNil(t, data.UVIndex) {
+		assert.Equal(t, 4.2, *data.UVIndex)
+	}
+	assert.InDelta(t, 24.14, data.Visibility, 1e-9)
+	assert.Equal(t, time.Unix(1717380000, 0).UTC(), data.Sunrise)
+	assert.Equal(t, time.Unix(1717438800, 0).UTC(), data.Sunset)
+	assert.Equal(t, time.Unix(1717405200, 0).UTC(), data.ObservedAt)
+	assert.Equal(t, 10800, data.UTCOffset)
+}
+
+func TestOpenMeteoAdapter_CoordinatesSkipGeocoding(t *testing.T) {
+	withOpenMeteoServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/search" {
+			t.Errorf("geocoding must not be called for coordinates")
+		}
+		_, _ = w.Write([]byte(`{"current":{"temperature_2m":-3,"relative_humidity_2m":90,"weather_code":73}}`))
+	})
+
+	adapter := adapters.NewOpenMeteoAdapter()
+	data, err := adapter.FetchWeather(context.Background(), contracts.CoordinatesLocation(49.84, 24.03))
+
+	require.NoError(t, err)
+	assert.Equal(t, "Moderate snow fall", data.Description)
+	assert.Equal(t, contracts.ConditionSnow, data.Condition)
+	assert.Equal(t, contracts.SeverityModerate, data.Severity)
+	assert.Equal(t, "snow", data.Icon)
+}
+
+func TestOpenMeteoAdapter_CityNotFound(t *testing.T) {
+	withOpenMeteoServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"generationtime_ms":0.5}`))
+	})
+
+	adapter := adapters.NewOpenMeteoAdapter()
+	_, err := adapter.FetchWeather(context.Background(), contracts.CityLocation("InvalidCity"))
+	require.ErrorIs(t, err, apierrors.ErrCityNotFound)
+}
+
+func TestOpenMeteoAdapter_APIError(t *testing.T) {
+	withOpenMeteoServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":true,"reason":"Latitude must be in range of -90 to 90°."}`))
+	})
+
+	adapter := adapters.NewOpenMeteoAdapter()
+	_, err := adapter.FetchWeather(context.Background(), contracts.CoordinatesLocation(120, 0))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Latitude must be in range")
+}
+
+func TestOpenMeteoAdapter_ForecastSuccess(t *testing.T) {
+	withOpenMeteoServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "2", r.URL.Query().Get("forecast_days"))
+		_, _ = w.Write([]byte(`{
+			"utc_offset_seconds": 10800,
+			"hourly": {
+				"time": [1750712400, 1750723200, 1750798800],
+				"temperature_2m": [15, 22, 19],
+				"relative_humidity_2m": [80, 60, 70],
+				"weather_code": [0, 0, 61]
+			}
+		}`))
+	})
+
+	adapter := adapters.NewOpenMeteoAdapter()
+	forecast, err := adapter.FetchForecast(context.Background(), contracts.CoordinatesLocation(50.45, 30.52), 2)
+
+	require.NoError(t, err)
+	require.Len(t, forecast.Hourly, 3)
+	require.Len(t, forecast.Daily, 2)
+	assert.Equal(t, 15.0, forecast.Daily[0].MinTemperature)
+	assert.Equal(t, 22.0, forecast.Daily[0].MaxTemperature)
+	assert.Equal(t, "Clear sky", forecast.Daily[0].Description)
+	assert.Equal(t, "Slight rain", forecast.Daily[1].Description)
+}
+
+func TestOpenMeteoAdapter_ForecastDaysOutOfRange(t *testing.T) {
+	adapter := adapters.NewOpenMeteoAdapter()
+	_, err := adapter.FetchForecast(context.Background(), contracts.CityLocation("Kyiv"), 17)
+	require.Error(t, err)
+}
+
+func TestOpenMeteoAdapter_SearchCities(t *testing.T) {
+	withOpenMeteoServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/search", r.URL.Path)
+		assert.Equal(t, "Lviv", r.URL.Query().Get("name"))
+		assert.Equal(t, "3", r.URL.Query().Get("count"))
+		_, _ = w.Write([]byte(`{"results":[{"name":"Lviv","latitude":49.84,"longitude":24.02,` +
+			`"admin1":"Lviv Oblast","country":"Ukraine","country_code":"UA"}]}`))
+	})
+
+	adapter := adapters.NewOpenMeteoAdapter()
+	cities, err := adapter.SearchCities(context.Background(), "Lviv", 3)
+
+	require.NoError(t, err)
+	assert.Equal(t, []contracts.City{{
+		ID:          "lviv,ua",
+		Name:        "Lviv",
+		Region:      "Lviv Oblast",
+		Country:     "Ukraine",
+		CountryCode: "UA",
+		Lat:         49.84,
+		Lon:         24.02,
+	}}, cities)
+}
+
+func TestOpenMeteoAdapter_ResolvesCityID(t *testing.T) {
+	withOpenMeteoServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/search" {
+			assert.Equal(t, "lviv", r.URL.Query().Get("name"))
+			assert.Equal(t, "UA", r.URL.Query().Get("countryCode"))
+			_, _ = w.Write([]byte(`{"results":[{"name":"Lviv","latitude":49.84,"longitude":24.02}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"current":{"temperature_2m":12,"relative_humidity_2m":70,"weather_code":0}}`))
+	})
+
+	adapter := adapters.NewOpenMeteoAdapter()
+	data, err := adapter.FetchWeather(context.Background(), contracts.CityLocation("lviv,ua"))
+
+	require.NoError(t, err)
+	assert.Equal(t, 12.0, data.Temperature)
+}
@@ -7,6 +7,7 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"weather_microservice/internal/apierrors"
@@ -15,43 +16,63 @@ import (
 
 const OPENWEATHER_SERVER_TIMEOUT = 10 * time.Second
 
+const (
+	// The free forecast endpoint returns 3-hour steps for five days.
+	openWeatherMaxForecastDays = 5
+	openWeatherPointsPerDay    = 8
+	openWeatherMaxPoints       = 40
+)
+
 type OpenWeatherAdapter struct {
 	configApiKey string
+	client       *http.Client
 }
 
 var OpenWeatherAPIBaseURL = func() string {
 	return "https://api.openweathermap.org/data/2.5"
 }
 
+var OpenWeatherGeocodingBaseURL = func() string {
+	return "https://api.openweathermap.org/geo/1.0"
+}
+
 func NewOpenWeatherAdapter(apikey string) (OpenWeatherAdapter, error) {
 	if apikey == "" {
 		return OpenWeatherAdapter{}, fmt.Errorf("OPENWEATHER_API_KEY is not configured")
 	}
 	return OpenWeatherAdapter{
 		configApiKey: apikey,
+		client:       &http.Client{Timeout: OPENWEATHER_SERVER_TIMEOUT},
 	}, nil
 }
 
-func (a *OpenWeatherAdapter) FetchWeather(ctx context.Context, city string) (contracts.WeatherData, error) {
-	if city == "" {
-		return contracts.WeatherData{}, fmt.Errorf("empty city provided")
+// SetTimeout overrides the HTTP timeout of requests to the provider.
+func (a *OpenWeatherAdapter) SetTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		client := *a.client
+		client.Timeout = timeout
+		a.client = &client
 	}
-	qCity := url.QueryEscape(city)
-	url := fmt.Sprintf("%s/weather?q=%s&appid=%s&units=metric",
-		OpenWeatherAPIBaseURL(), qCity, a.configApiKey)
+}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return contracts.WeatherData{}, fmt.Errorf("failed to create request: %w", err)
+// SetHTTPClient replaces the client of requests to the provider, e.g. to
+// record or replay its responses in tests. The client's own timeout applies.
+func (a *OpenWeatherAdapter) SetHTTPClient(client *http.Client) {
+	if client != nil {
+		a.client = client
 	}
+}
 
-	client := &http.Client{
-		Timeout: OPENWEATHER_SERVER_TIMEOUT,
+func (a *OpenWeatherAdapter) FetchWeather(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error) {
+	if loc.IsEmpty() {
+		return contracts.WeatherData{}, fmt.Errorf("empty location provided")
 	}
+	url := fmt.Sprintf("%s/weather?%s&appid=%s&units=metric",
+		OpenWeatherAPIBaseURL(), openWeatherLocationQuery(loc), a.configApiKey)
 
-	resp, err := client.Do(req)
+	resp, err := a.doRequest(ctx, url)
 	if err != nil {
-		return contracts.WeatherData{}, fmt.Errorf("failed to get weather: %w", err)
+		return contracts.WeatherData{}, err
 	}
 	defer func() {
 		if cerr := resp.Body.Close(); cerr != nil {
@@ -59,31 +80,43 @@ func (a *OpenWeatherAdapter) FetchWeather(ctx context.Context, city string) (con
 		}
 	}()
 
-	// Handle 404 (city not found)
-	if resp.StatusCode == http.StatusNotFound {
-		var errResp struct {
-			Cod     string `json:"cod"`
-			Message string `json:"message"`
-		}
-		_ = json.NewDecoder(resp.Body).Decode(&errResp)
-		if errResp.Message == "city not found" {
-			return contracts.WeatherData{}, apierrors.ErrCityNotFound
-		}
-		return contracts.WeatherData{}, fmt.Errorf("weather API 404: %s", errResp.Message)
-	}
-
-	if resp.StatusCode != 200 {
-		return contracts.WeatherData{}, fmt.Errorf("weather API returned status %d", resp.StatusCode)
+	if err := checkOpenWeatherStatus(resp); err != nil {
+		return contracts.WeatherData{}, err
 	}
 
 	var weatherResp struct {
 		Weather []struct {
+			ID          int    `json:"id"`
 			Description string `json:"description"`
+			Icon        string `json:"icon"` // "10d" by day, "10n" by night.
 		} `json:"weather"`
 		Main struct {
-			Temp     float64 `json:"temp"`
-			Humidity float64 `json:"humidity"`
+			Temp      float64 `json:"temp"`
+			FeelsLike float64 `json:"feels_like"`
+			Humidity  float64 `json:"humidity"`
+			Pressure  float64 `json:"pressure"`
 		} `json:"main"`
+		Wind struct {
+			Speed float64 `json:"speed"`
+			Deg   float64 `json:"deg"`
+		} `json:"wind"`
+		Clouds struct {
+			All float64 `json:"all"`
+		} `json:"clouds"`
+		// Rain and snow are only present while it is falling.
+		Rain struct {
+			OneHour float64 `json:"1h"`
+		} `json:"rain"`
+		Snow struct {
+			OneHour float64 `json:"1h"`
+		} `json:"snow"`
+		Visibility float64 `json:"visibility"` // Meters.
+		Dt         int64   `json:"dt"`
+		Timezone   int     `json:"timezone"` // Shift in seconds from UTC.
+		Sys        struct {
+			Sunrise int64 `json:"sunrise"`
+			Sunset  int64 `json:"sunset"`
+		} `json:"sys"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&weatherResp); err != nil {
@@ -94,10 +127,190 @@ func (a *OpenWeatherAdapter) FetchWeather(ctx context.Context, city string) (con
 		return contracts.WeatherData{}, fmt.Errorf("no weather data found")
 	}
 
-	// Convert to internal format
-	return contracts.WeatherData{
-		Temperature: weatherResp.Main.Temp,
-		Humidity:    weatherResp.Main.Humidity,
-		Description: weatherResp.Weather[0].Description,
+	// Convert to internal format. The 2.5 API does not report the UV index.
+	condition := weatherResp.Weather[0]
+	data := contracts.WeatherData{
+		Temperature:   weatherResp.Main.Temp,
+		Humidity:      weatherResp.Main.Humidity,
+		Description:   condition.Description,
+		FeelsLike:     weatherResp.Main.FeelsLike,
+		WindSpeed:     weatherResp.Wind.Speed,
+		WindDirection: weatherResp.Wind.Deg,
+		Pressure:      weatherResp.Main.Pressure,
+		Precipitation: weatherResp.Rain.OneHour + weatherResp.Snow.OneHour,
+		CloudCover:    weatherResp.Clouds.All,
+		Visibility:    weatherResp.Visibility / 1000,
+		Sunrise:       unixTime(weatherResp.Sys.Sunrise),
+		Sunset:        unixTime(weatherResp.Sys.Sunset),
+		ObservedAt:    unixTime(weatherResp.Dt),
+		UTCOffset:     weatherResp.Timezone,
+	}
+	isDay := !strings.HasSuffix(condition.Icon, "n")
+	return withCondition(data, openWeatherCondition(condition.ID), isDay), nil
+}
+
+// FetchForecast returns the 3-hourly forecast for up to five days.
+func (a *OpenWeatherAdapter) FetchForecast(ctx context.Context, loc contracts.Location, days int) (contracts.ForecastData, error) {
+	if loc.IsEmpty() {
+		return contracts.ForecastData{}, fmt.Errorf("empty location provided")
+	}
+	if days < 1 || days > openWeatherMaxForecastDays {
+		return contracts.ForecastData{}, fmt.Errorf("OpenWeather supports 1-%d forecast days, got %d", openWeatherMaxForecastDays, days)
+	}
+	// Points start now rather than at midnight, so one more day of them is
+	// requested to cover the last calendar day in full.
+	points := min((days+1)*openWeatherPointsPerDay, openWeatherMaxPoints)
+	url := fmt.Sprintf("%s/forecast?%s&appid=%s&units=metric&cnt=%d",
+		OpenWeatherAPIBaseURL(), openWeatherLocationQuery(loc), a.configApiKey, points)
+
+	resp, err := a.doRequest(ctx, url)
+	if err != nil {
+		return contracts.ForecastData{}, err
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			log.Printf("warning: failed to close response body: %v", cerr)
+		}
+	}()
+
+	if err := checkOpenWeatherStatus(resp); err != nil {
+		return contracts.ForecastData{}, err
+	}
+
+	var forecastResp struct {
+		List []struct {
+			Dt   int64 `json:"dt"`
+			Main struct {
+				Temp     float64 `json:"temp"`
+				Humidity float64 `json:"humidity"`
+			} `json:"main"`
+			Weather []struct {
+				Description string `json:"description"`
+			} `json:"weather"`
+		} `json:"list"`
+		City struct {
+			Name     string `json:"name"`
+			Timezone int    `json:"timezone"` // Shift in seconds from UTC.
+		} `json:"city"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&forecastResp); err != nil {
+		return contracts.ForecastData{}, fmt.Errorf("failed to decode forecast response: %w", err)
+	}
+
+	if len(forecastResp.List) == 0 {
+		return contracts.ForecastData{}, fmt.Errorf("no forecast data found")
+	}
+
+	hourly := make([]contracts.HourlyForecast, 0, len(forecastResp.List))
+	for _, item := range forecastResp.List {
+		var description string
+		if len(item.Weather) > 0 {
+			description = item.Weather[0].Description
+		}
+		hourly = append(hourly, contracts.HourlyForecast{
+			Time:        time.Unix(item.Dt, 0).UTC(),
+			Temperature: item.Main.Temp,
+			Humidity:    item.Main.Humidity,
+			Description: description,
+		})
+	}
+
+	cityZone := time.FixedZone(forecastResp.City.Name, forecastResp.City.Timezone)
+	daily := aggregateDaily(hourly, cityZone, days)
+	return contracts.ForecastData{
+		City:   forecastResp.City.Name,
+		Hourly: hourlyThrough(hourly, cityZone, daily[len(daily)-1].Date),
+		Daily:  daily,
 	}, nil
 }
+
+// SearchCities looks cities up through the OpenWeather direct geocoding API.
+func (a *OpenWeatherAdapter) SearchCities(ctx context.Context, query string, limit int) ([]contracts.City, error) {
+	url := fmt.Sprintf("%s/direct?q=%s&limit=%d&appid=%s",
+		OpenWeatherGeocodingBaseURL(), url.QueryEscape(strings.TrimSpace(query)), limit, a.configApiKey)
+
+	resp, err := a.doRequest(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			log.Printf("warning: failed to close response body: %v", cerr)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("geocoding API returned status %d", resp.StatusCode)
+	}
+
+	var places []struct {
+		Name    string  `json:"name"`
+		State   string  `json:"state"`
+		Country string  `json:"country"` // ISO 3166 code.
+		Lat     float64 `json:"lat"`
+		Lon     float64 `json:"lon"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&places); err != nil {
+		return nil, fmt.Errorf("failed to decode geocoding response: %w", err)
+	}
+
+	cities := make([]contracts.City, 0, len(places))
+	for _, p := range places {
+		cities = append(cities, contracts.City{
+			ID:          contracts.CityID(p.Name, p.Country),
+			Name:        p.Name,
+			Region:      p.State,
+			CountryCode: p.Country,
+			Lat:         p.Lat,
+			Lon:         p.Lon,
+		})
+	}
+	return cities, nil
+}
+
+// openWeatherLocationQuery builds the location part of an OpenWeather query string.
+func openWeatherLocationQuery(loc contracts.Location) string {
+	if loc.HasCoordinates() {
+		return fmt.Sprintf("lat=%f&lon=%f", loc.Coordinates.Lat, loc.Coordinates.Lon)
+	}
+	q := loc.City
+	if loc.CountryCode != "" {
+		q += "," + loc.CountryCode
+	}
+	return "q=" + url.QueryEscape(q)
+}
+
+func (a *OpenWeatherAdapter) doRequest(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weather: %w", err)
+	}
+	return resp, nil
+}
+
+// checkOpenWeatherStatus maps non-200 OpenWeather responses to errors.
+func checkOpenWeatherStatus(resp *http.Response) error {
+	// Handle 404 (city not found)
+	if resp.StatusCode == http.StatusNotFound {
+		var errResp struct {
+			Cod     string `json:"cod"`
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&errResp)
+		if errResp.Message == "city not found" {
+			return apierrors.ErrCityNotFound
+		}
+		return fmt.Errorf("weather API 404: %s", errResp.Message)
+	}
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("weather API returned status %d", resp.StatusCode)
+	}
+	return nil
+}
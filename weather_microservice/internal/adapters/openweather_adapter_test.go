@@ -5,12 +5,16 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"weather_microservice/internal/adapters"
 	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
 )
 
 func TestOpenWeatherAdapter_CityNotFound(t *testing.T) {
@@ -34,14 +38,21 @@ func TestOpenWeatherAdapter_CityNotFound(t *testing.T) {
 		adapters.OpenWeatherAPIBaseURL = originalBaseURL
 	}()
 
-	_, err = adapter.FetchWeather(context.Background(), "InvalidCity")
+	_, err = adapter.FetchWeather(context.Background(), contracts.CityLocation("InvalidCity"))
 	require.ErrorIs(t, err, apierrors.ErrCityNotFound)
 }
 
 func TestOpenWeatherAdapter_Success(t *testing.T) {
 	mockResponse := `{
-		"weather": [{"description": "clear sky"}],
-		"main": {"temp": 25.5, "humidity": 60}
+		"weather": [{"id": 800, "description": "clear sky", "icon": "01n"}],
+		"main": {"temp": 25.5, "feels_like": 26.1, "humidity": 60, "pressure": 1012},
+		"wind": {"speed": 4.1, "deg": 250},
+		"clouds": {"all": 20},
+		"rain": {"1h": 0.4},
+		"visibility": 10000,
+		"dt": 1717405200,
+		"timezone": 10800,
+		"sys": {"sunrise": 1717380000, "sunset": 1717438800}
 	}`
 
 	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -64,11 +75,78 @@ func TestOpenWeatherAdapter_Success(t *testing.T) {
 		adapters.OpenWeatherAPIBaseURL = originalBaseURL
 	}()
 
-	data, err := adapter.FetchWeather(context.Background(), "Kyiv")
+	data, err := adapter.FetchWeather(context.Background(), contracts.CityLocation("Kyiv"))
 	require.NoError(t, err)
 	require.Equal(t, 25.5, data.Temperature)
 	require.Equal(t, 60.0, data.Humidity)
 	require.Equal(t, "clear sky", data.Description)
+	require.Equal(t, contracts.ConditionClear, data.Condition)
+	require.Equal(t, contracts.SeverityNone, data.Severity)
+	require.Equal(t, "clear-night", data.Icon)
+	require.Equal(t, 26.1, data.FeelsLike)
+	require.Equal(t, 4.1, data.WindSpeed)
+	require.Equal(t, 250.0, data.WindDirection)
+	require.Equal(t, 1012.0, data.Pressure)
+	require.Equal(t, 0.4, data.Precipitation)
+	require.Equal(t, 20.0, data.CloudCover)
+	require.Nil(t, data.UVIndex)
+	require.Equal(t, 10.0, data.Visibility)
+	require.Equal(t, time.Unix(1717380000, 0).UTC(), data.Sunrise)
+	require.Equal(t, time.Unix(1717438800, 0).UTC(), data.Sunset)
+	require.Equal(t, time.Unix(1717405200, 0).UTC(), data.ObservedAt)
+	require.Equal(t, 10800, data.UTCOffset)
+}
+
+func TestOpenWeatherAdapter_Coordinates(t *testing.T) {
+	var gotQuery map[string][]string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query()
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintln(w, `{"weather": [{"description": "clear sky"}], "main": {"temp": 20, "humidity": 40}}`)
+	}))
+	defer mockServer.Close()
+
+	adapter, err := adapters.NewOpenWeatherAdapter("fake-key")
+	require.NoError(t, err)
+
+	originalBaseURL := adapters.OpenWeatherAPIBaseURL
+	adapters.OpenWeatherAPIBaseURL = func() string {
+		return mockServer.URL
+	}
+	defer func() {
+		adapters.OpenWeatherAPIBaseURL = originalBaseURL
+	}()
+
+	_, err = adapter.FetchWeather(context.Background(), contracts.CoordinatesLocation(48.8566, 2.3522))
+	require.NoError(t, err)
+	require.Equal(t, "48.856600", gotQuery["lat"][0])
+	require.Equal(t, "2.352200", gotQuery["lon"][0])
+	require.NotContains(t, gotQuery, "q")
+}
+
+func TestOpenWeatherAdapter_CityWithCountry(t *testing.T) {
+	var gotCity string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotCity = r.URL.Query().Get("q")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintln(w, `{"weather": [{"description": "clear sky"}], "main": {"temp": 20, "humidity": 40}}`)
+	}))
+	defer mockServer.Close()
+
+	adapter, err := adapters.NewOpenWeatherAdapter("fake-key")
+	require.NoError(t, err)
+
+	originalBaseURL := adapters.OpenWeatherAPIBaseURL
+	adapters.OpenWeatherAPIBaseURL = func() string {
+		return mockServer.URL
+	}
+	defer func() {
+		adapters.OpenWeatherAPIBaseURL = originalBaseURL
+	}()
+
+	_, err = adapter.FetchWeather(context.Background(), contracts.Location{City: "Paris", CountryCode: "US"})
+	require.NoError(t, err)
+	require.Equal(t, "Paris,US", gotCity)
 }
 
 func TestOpenWeatherAdapter_InvalidJSON(t *testing.T) {
@@ -92,7 +170,127 @@ func TestOpenWeatherAdapter_InvalidJSON(t *testing.T) {
 		adapters.OpenWeatherAPIBaseURL = originalBaseURL
 	}()
 
-	_, err = adapter.FetchWeather(context.Background(), "Kyiv")
+	_, err = adapter.FetchWeather(context.Background(), contracts.CityLocation("Kyiv"))
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "failed to decode")
 }
+
+func TestOpenWeatherAdapter_ForecastSuccess(t *testing.T) {
+	mockResponse := `{
+		"list": [
+			{"dt": 1718442000, "main": {"temp": 18.0, "humidity": 70}, "weather": [{"description": "light rain"}]},
+			{"dt": 1718452800, "main": {"temp": 24.0, "humidity": 50}, "weather": [{"description": "clear sky"}]},
+			{"dt": 1718463600, "main": {"temp": 22.0, "humidity": 60}, "weather": [{"description": "clear sky"}]}
+		],
+		"city": {"name": "Kyiv", "timezone": 10800}
+	}`
+
+	var gotQuery string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintln(w, mockResponse)
+	}))
+	defer mockServer.Close()
+
+	adapter, err := adapters.NewOpenWeatherAdapter("fake-key")
+	require.NoError(t, err)
+
+	originalBaseURL := adapters.OpenWeatherAPIBaseURL
+	adapters.OpenWeatherAPIBaseURL = func() string {
+		return mockServer.URL
+	}
+	defer func() {
+		adapters.OpenWeatherAPIBaseURL = originalBaseURL
+	}()
+
+	data, err := adapter.FetchForecast(context.Background(), contracts.CityLocation("Kyiv"), 1)
+	require.NoError(t, err)
+	require.Contains(t, gotQuery, "cnt=16")
+	require.Equal(t, "Kyiv", data.City)
+	require.Len(t, data.Hourly, 3)
+	require.Len(t, data.Daily, 1)
+	require.Equal(t, "2024-06-15", data.Daily[0].Date)
+	require.Equal(t, 18.0, data.Daily[0].MinTemperature)
+	require.Equal(t, 24.0, data.Daily[0].MaxTemperature)
+	require.Equal(t, 60.0, data.Daily[0].Humidity)
+	require.Equal(t, "clear sky", data.Daily[0].Description)
+}
+
+func TestOpenWeatherAdapter_ForecastCoversFullDays(t *testing.T) {
+	// The forecast starts at 15:00 UTC, the temperature is the point index.
+	start := time.Date(2024, 6, 15, 15, 0, 0, 0, time.UTC)
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cnt, err := strconv.Atoi(r.URL.Query().Get("cnt"))
+		require.NoError(t, err)
+		list := make([]string, 0, cnt)
+		for i := range cnt {
+			list = append(list, fmt.Sprintf(`{"dt": %d, "main": {"temp": %d, "humidity": 50}, "weather": [{"description": "clear sky"}]}`,
+				start.Add(time.Duration(i)*3*time.Hour).Unix(), i))
+		}
+		_, _ = fmt.Fprintf(w, `{"list": [%s], "city": {"name": "London", "timezone": 0}}`, strings.Join(list, ","))
+	}))
+	defer mockServer.Close()
+
+	adapter, err := adapters.NewOpenWeatherAdapter("fake-key")
+	require.NoError(t, err)
+
+	originalBaseURL := adapters.OpenWeatherAPIBaseURL
+	adapters.OpenWeatherAPIBaseURL = func() string {
+		return mockServer.URL
+	}
+	defer func() {
+		adapters.OpenWeatherAPIBaseURL = originalBaseURL
+	}()
+
+	data, err := adapter.FetchForecast(context.Background(), contracts.CityLocation("London"), 3)
+	require.NoError(t, err)
+	require.Len(t, data.Daily, 3)
+	// The last day gets all eight points, 00:00 to 21:00.
+	require.Equal(t, "2024-06-17", data.Daily[2].Date)
+	require.Equal(t, 11.0, data.Daily[2].MinTemperature)
+	require.Equal(t, 18.0, data.Daily[2].MaxTemperature)
+	// Points of the extra day fetched for it are dropped.
+	require.Len(t, data.Hourly, 19)
+	require.Equal(t, time.Date(2024, 6, 17, 21, 0, 0, 0, time.UTC), data.Hourly[len(data.Hourly)-1].Time)
+}
+
+func TestOpenWeatherAdapter_ForecastDaysOutOfRange(t *testing.T) {
+	adapter, err := adapters.NewOpenWeatherAdapter("fake-key")
+	require.NoError(t, err)
+
+	_, err = adapter.FetchForecast(context.Background(), contracts.CityLocation("Kyiv"), 6)
+	require.Error(t, err)
+}
+
+func TestOpenWeatherAdapter_SearchCities(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/direct", r.URL.Path)
+		require.Equal(t, "Kyiv", r.URL.Query().Get("q"))
+		require.Equal(t, "5", r.URL.Query().Get("limit"))
+		_, _ = fmt.Fprintln(w, `[{"name":"Kyiv","lat":50.45,"lon":30.52,"country":"UA","state":"Kyiv"}]`)
+	}))
+	defer mockServer.Close()
+
+	originalBaseURL := adapters.OpenWeatherGeocodingBaseURL
+	adapters.OpenWeatherGeocodingBaseURL = func() string {
+		return mockServer.URL
+	}
+	defer func() {
+		adapters.OpenWeatherGeocodingBaseURL = originalBaseURL
+	}()
+
+	adapter, err := adapters.NewOpenWeatherAdapter("fake-key")
+	require.NoError(t, err)
+
+	cities, err := adapter.SearchCities(context.Background(), " Kyiv ", 5)
+	require.NoError(t, err)
+	require.Equal(t, []contracts.City{{
+		ID:          "kyiv,ua",
+		Name:        "Kyiv",
+		Region:      "Kyiv",
+		CountryCode: "UA",
+		Lat:         50.45,
+		Lon:         30.52,
+	}}, cities)
+}
@@ -0,0 +1,53 @@
+package adapters
+
+import "weather_microservice/internal/contracts"
+
+// openWeatherCondition maps an OpenWeather condition id
+// (https://openweathermap.org/weather-conditions) to the canonical taxonomy.
+// Ids are grouped by hundreds, so ranges cover the whole group.
+func openWeatherCondition(id int) nativeCondition {
+	c := func(condition contracts.Condition, severity contracts.Severity) nativeCondition {
+		return nativeCondition{condition: condition, severity: severity}
+	}
+	switch {
+	case id == 210 || id == 230 || id == 231:
+		return c(contracts.ConditionThunderstorm, contracts.SeverityModerate)
+	case id >= 200 && id < 300:
+		return c(contracts.ConditionThunderstorm, contracts.SeveritySevere)
+	case id == 302 || id == 312 || id == 314:
+		return c(contracts.ConditionDrizzle, contracts.SeverityModerate)
+	case id >= 300 && id < 400:
+		return c(contracts.ConditionDrizzle, contracts.SeverityMinor)
+	case id == 511:
+		return c(contracts.ConditionFreezingRain, contracts.SeveritySevere)
+	case id == 500 || id == 520:
+		return c(contracts.ConditionRain, contracts.SeverityMinor)
+	case id == 501 || id == 521 || id == 531:
+		return c(contracts.ConditionRain, contracts.SeverityModerate)
+	case id >= 500 && id < 600:
+		return c(contracts.ConditionRain, contracts.SeveritySevere)
+	case id >= 611 && id <= 616:
+		return c(contracts.ConditionSleet, contracts.SeverityModerate)
+	case id == 600 || id == 620:
+		return c(contracts.ConditionSnow, contracts.SeverityMinor)
+	case id == 602 || id == 622:
+		return c(contracts.ConditionSnow, contracts.SeveritySevere)
+	case id >= 600 && id < 700:
+		return c(contracts.ConditionSnow, contracts.SeverityModerate)
+	case id == 701 || id == 741:
+		return c(contracts.ConditionFog, contracts.SeverityMinor)
+	case id == 771 || id == 781:
+		return c(contracts.ConditionSquall, contracts.SeveritySevere)
+	case id == 762:
+		return c(contracts.ConditionHaze, contracts.SeveritySevere)
+	case id >= 700 && id < 800:
+		return c(contracts.ConditionHaze, contracts.SeverityMinor)
+	case id == 800:
+		return c(contracts.ConditionClear, contracts.SeverityNone)
+	case id == 801 || id == 802:
+		return c(contracts.ConditionPartlyCloudy, contracts.SeverityNone)
+	case id == 803 || id == 804:
+		return c(contracts.ConditionCloudy, contracts.SeverityNone)
+	}
+	return unknownCondition
+}
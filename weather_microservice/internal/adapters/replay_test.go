@@ -0,0 +1,59 @@
+package adapters_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"weather_microservice/internal/adapters"
+	"weather_microservice/internal/adapters/adaptertest"
+	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+)
+
+// The tests below replay hand-edited provider responses: they follow the
+// real response shapes, but values are trimmed for readability, e.g. the
+// WeatherAPI fixture has an empty "hour" list. Rerunning them with
+// ADAPTERS_RECORD=1 and the API keys set replaces testdata with real
+// responses, so check the expected values afterwards.
+
+func TestOpenWeatherAdapter_Replay(t *testing.T) {
+	adapter, err := adapters.NewOpenWeatherAdapter(adaptertest.APIKey(t, "OPENWEATHER_API_KEY"))
+	require.NoError(t, err)
+	adapter.SetHTTPClient(adaptertest.Client(t, "testdata/openweather_weather.json"))
+
+	data, err := adapter.FetchWeather(context.Background(), contracts.CityLocation("Kyiv"))
+	require.NoError(t, err)
+	if !adaptertest.Recording() {
+		assert.Equal(t, 14.21, data.Temperature)
+		assert.Equal(t, 81.0, data.Humidity)
+		assert.Equal(t, "light rain", data.Description)
+		assert.Equal(t, contracts.ConditionRain, data.Condition)
+		assert.Equal(t, time.Unix(1760702400, 0).UTC(), data.ObservedAt)
+	}
+
+	_, err = adapter.FetchWeather(context.Background(), contracts.CityLocation("Atlantis"))
+	require.ErrorIs(t, err, apierrors.ErrCityNotFound)
+}
+
+func TestWeatherAPIAdapter_Replay(t *testing.T) {
+	adapter, err := adapters.NewWeatherAPIAdapter(adaptertest.APIKey(t, "WEATHER_API_KEY"))
+	require.NoError(t, err)
+	adapter.SetHTTPClient(adaptertest.Client(t, "testdata/weatherapi_weather.json"))
+
+	data, err := adapter.FetchWeather(context.Background(), contracts.CityLocation("Kyiv"))
+	require.NoError(t, err)
+	if !adaptertest.Recording() {
+		assert.Equal(t, 14.3, data.Temperature)
+		assert.Equal(t, 82.0, data.Humidity)
+		assert.Equal(t, "Light rain", data.Description)
+		assert.Equal(t, contracts.ConditionRain, data.Condition)
+		assert.Equal(t, time.Date(2025, 10, 17, 4, 11, 0, 0, time.UTC), data.Sunrise)
+	}
+
+	_, err = adapter.FetchWeather(context.Background(), contracts.CityLocation("Atlantis"))
+	require.ErrorIs(t, err, apierrors.ErrCityNotFound)
+}
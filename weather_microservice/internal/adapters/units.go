@@ -0,0 +1,16 @@
+package adapters
+
+import "time"
+
+// kphToMps converts km/h, which some providers report wind in, to m/s.
+func kphToMps(kph float64) float64 {
+	return kph / 3.6
+}
+
+// unixTime converts epoch seconds to UTC time, keeping zero for a missing value.
+func unixTime(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0).UTC()
+}
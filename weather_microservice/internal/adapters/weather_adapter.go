@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"net/url"
 	"time"
@@ -15,8 +14,12 @@ import (
 
 const WEATHER_SERVER_TIMEOUT = 10 * time.Second
 
+// weatherAPIMaxForecastDays is the limit of the forecast.json endpoint.
+const weatherAPIMaxForecastDays = 14
+
 type WeatherAPIAdapter struct {
 	configApiKey string
+	client       *http.Client
 }
 
 var WeatherAPIBaseURL = func() string {
@@ -29,53 +32,88 @@ func NewWeatherAPIAdapter(apikey string) (WeatherAPIAdapter, error) {
 	}
 	return WeatherAPIAdapter{
 		configApiKey: apikey,
+		client:       &http.Client{Timeout: WEATHER_SERVER_TIMEOUT},
 	}, nil
 }
 
-func (a *WeatherAPIAdapter) FetchWeather(ctx context.Context, city string) (contracts.WeatherData, error) {
-	if city == "" {
-		return contracts.WeatherData{}, fmt.Errorf("empty city provided")
+// SetTimeout overrides the HTTP timeout of requests to the provider.
+func (a *WeatherAPIAdapter) SetTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		client := *a.client
+		client.Timeout = timeout
+		a.client = &client
+	}
+}
+
+// SetHTTPClient replaces the client of requests to the provider, e.g. to
+// record or replay its responses in tests. The client's own timeout applies.
+func (a *WeatherAPIAdapter) SetHTTPClient(client *http.Client) {
+	if client != nil {
+		a.client = client
 	}
-	qCity := url.QueryEscape(city)
-	url := fmt.Sprintf("%s/current.json?key=%s&q=%s", WeatherAPIBaseURL(), a.configApiKey, qCity)
+}
+
+func (a *WeatherAPIAdapter) FetchWeather(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error) {
+	if loc.IsEmpty() {
+		return contracts.WeatherData{}, fmt.Errorf("empty location provided")
+	}
+	// forecast.json returns the current conditions together with today's
+	// astronomy, which current.json lacks.
+	url := fmt.Sprintf("%s/forecast.json?key=%s&q=%s&days=1", WeatherAPIBaseURL(), a.configApiKey, weatherAPILocationQuery(loc))
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return contracts.WeatherData{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	client := &http.Client{
-		Timeout: WEATHER_SERVER_TIMEOUT,
-	}
-
-	resp, err := client.Do(req)
+	resp, err := a.client.Do(req)
 
 	if err != nil {
 		return contracts.WeatherData{}, fmt.Errorf("failed to get weather from WeatherAPI: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	log.Printf("WeatherAPI status: %s (%d)", resp.Status, resp.StatusCode)
-
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return contracts.WeatherData{}, fmt.Errorf("failed to read WeatherAPI response body: %w", err)
 	}
 
-	log.Printf("WeatherAPI raw response: %s", string(body))
-
 	if len(body) == 0 {
 		return contracts.WeatherData{}, fmt.Errorf("empty response body from WeatherAPI")
 	}
 
 	var weatherResp struct {
+		Location struct {
+			LocaltimeEpoch int64  `json:"localtime_epoch"`
+			Localtime      string `json:"localtime"`
+		} `json:"location"`
 		Current struct {
-			TempC     float64 `json:"temp_c"`
-			Humidity  float64 `json:"humidity"`
-			Condition struct {
+			LastUpdatedEpoch int64    `json:"last_updated_epoch"`
+			TempC            float64  `json:"temp_c"`
+			FeelsLikeC       float64  `json:"feelslike_c"`
+			Humidity         float64  `json:"humidity"`
+			WindKph          float64  `json:"wind_kph"`
+			WindDegree       float64  `json:"wind_degree"`
+			PressureMb       float64  `json:"pressure_mb"`
+			PrecipMm         float64  `json:"precip_mm"`
+			Cloud            float64  `json:"cloud"`
+			VisKm            float64  `json:"vis_km"`
+			UV               *float64 `json:"uv"`
+			IsDay            int      `json:"is_day"`
+			Condition        struct {
 				Text string `json:"text"`
+				Code int    `json:"code"`
 			} `json:"condition"`
 		} `json:"current"`
+		Forecast struct {
+			ForecastDay []struct {
+				Date  string `json:"date"`
+				Astro struct {
+					Sunrise string `json:"sunrise"`
+					Sunset  string `json:"sunset"`
+				} `json:"astro"`
+			} `json:"forecastday"`
+		} `json:"forecast"`
 		Error struct {
 			Code    int    `json:"code"`
 			Message string `json:"message"`
@@ -94,10 +132,160 @@ func (a *WeatherAPIAdapter) FetchWeather(ctx context.Context, city string) (cont
 		return contracts.WeatherData{}, fmt.Errorf("WeatherAPI error: %s", weatherResp.Error.Message)
 	}
 
-	// Convert to internal format
-	return contracts.WeatherData{
-		Temperature: weatherResp.Current.TempC,
-		Humidity:    weatherResp.Current.Humidity,
-		Description: weatherResp.Current.Condition.Text,
-	}, nil
+	current := weatherResp.Current
+	offset := weatherAPIOffset(weatherResp.Location.Localtime, weatherResp.Location.LocaltimeEpoch)
+	data := contracts.WeatherData{
+		Temperature:   current.TempC,
+		Humidity:      current.Humidity,
+		Description:   current.Condition.Text,
+		FeelsLike:     current.FeelsLikeC,
+		WindSpeed:     kphToMps(current.WindKph),
+		WindDirection: current.WindDegree,
+		Pressure:      current.PressureMb,
+		Precipitation: current.PrecipMm,
+		CloudCover:    current.Cloud,
+		UVIndex:       current.UV,
+		Visibility:    current.VisKm,
+		ObservedAt:    unixTime(current.LastUpdatedEpoch),
+		UTCOffset:     offset,
+	}
+	if days := weatherResp.Forecast.ForecastDay; len(days) > 0 {
+		zone := time.FixedZone("", offset)
+		data.Sunrise = weatherAPIAstroTime(days[0].Date, days[0].Astro.Sunrise, zone)
+		data.Sunset = weatherAPIAstroTime(days[0].Date, days[0].Astro.Sunset, zone)
+	}
+	return withCondition(data, weatherAPICondition(current.Condition.Code), current.IsDay == 1), nil
+}
+
+// weatherAPIOffset derives the city's UTC offset in seconds from its local
+// wall clock and epoch time, so the astronomy times can be converted without
+// tzdata. Unknown offsets are zero.
+func weatherAPIOffset(localtime string, epoch int64) int {
+	wall, err := time.Parse("2006-01-02 15:04", localtime)
+	if err != nil || epoch == 0 {
+		return 0
+	}
+	return int(wall.Sub(time.Unix(epoch, 0)).Round(15 * time.Minute).Seconds())
+}
+
+// weatherAPIAstroTime parses astronomy times like "06:12 AM", which are given
+// in the city's local time. Missing values such as "No sunset" yield zero time.
+func weatherAPIAstroTime(date, clock string, zone *time.Location) time.Time {
+	t, err := time.ParseInLocation("2006-01-02 03:04 PM", date+" "+clock, zone)
+	if err != nil {
+		return time.Time{}
+	}
+	return t.UTC()
+}
+
+// weatherAPILocationQuery builds the escaped "q" parameter, which WeatherAPI
+// accepts both as a place name and as "lat,lon".
+func weatherAPILocationQuery(loc contracts.Location) string {
+	if loc.HasCoordinates() {
+		return url.QueryEscape(fmt.Sprintf("%f,%f", loc.Coordinates.Lat, loc.Coordinates.Lon))
+	}
+	q := loc.City
+	if loc.CountryCode != "" {
+		q += "," + loc.CountryCode
+	}
+	return url.QueryEscape(q)
+}
+
+// FetchForecast returns hourly and daily forecast from WeatherAPI.
+func (a *WeatherAPIAdapter) FetchForecast(ctx context.Context, loc contracts.Location, days int) (contracts.ForecastData, error) {
+	if loc.IsEmpty() {
+		return contracts.ForecastData{}, fmt.Errorf("empty location provided")
+	}
+	if days < 1 || days > weatherAPIMaxForecastDays {
+		return contracts.ForecastData{}, fmt.Errorf("WeatherAPI supports 1-%d forecast days, got %d", weatherAPIMaxForecastDays, days)
+	}
+	url := fmt.Sprintf("%s/forecast.json?key=%s&q=%s&days=%d", WeatherAPIBaseURL(), a.configApiKey, weatherAPILocationQuery(loc), days)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return contracts.ForecastData{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return contracts.ForecastData{}, fmt.Errorf("failed to get forecast from WeatherAPI: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return contracts.ForecastData{}, fmt.Errorf("failed to read WeatherAPI response body: %w", err)
+	}
+
+	if len(body) == 0 {
+		return contracts.ForecastData{}, fmt.Errorf("empty response body from WeatherAPI")
+	}
+
+	type condition struct {
+		Text string `json:"text"`
+	}
+	var forecastResp struct {
+		Location struct {
+			Name string `json:"name"`
+		} `json:"location"`
+		Forecast struct {
+			ForecastDay []struct {
+				Date string `json:"date"`
+				Day  struct {
+					MaxTempC    float64   `json:"maxtemp_c"`
+					MinTempC    float64   `json:"mintemp_c"`
+					AvgHumidity float64   `json:"avghumidity"`
+					Condition   condition `json:"condition"`
+				} `json:"day"`
+				Hour []struct {
+					TimeEpoch int64     `json:"time_epoch"`
+					TempC     float64   `json:"temp_c"`
+					Humidity  float64   `json:"humidity"`
+					Condition condition `json:"condition"`
+				} `json:"hour"`
+			} `json:"forecastday"`
+		} `json:"forecast"`
+		Error struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	if err := json.Unmarshal(body, &forecastResp); err != nil {
+		return contracts.ForecastData{}, fmt.Errorf("failed to decode WeatherAPI response: %w", err)
+	}
+
+	if forecastResp.Error.Code != 0 {
+		if forecastResp.Error.Code == 1006 { // No matching location found
+			return contracts.ForecastData{}, apierrors.ErrCityNotFound
+		}
+		return contracts.ForecastData{}, fmt.Errorf("WeatherAPI error: %s", forecastResp.Error.Message)
+	}
+
+	if len(forecastResp.Forecast.ForecastDay) == 0 {
+		return contracts.ForecastData{}, fmt.Errorf("no forecast data found")
+	}
+
+	forecast := contracts.ForecastData{
+		City: forecastResp.Location.Name,
+	}
+	for _, day := range forecastResp.Forecast.ForecastDay {
+		forecast.Daily = append(forecast.Daily, contracts.DailyForecast{
+			Date:           day.Date,
+			MinTemperature: day.Day.MinTempC,
+			MaxTemperature: day.Day.MaxTempC,
+			Humidity:       day.Day.AvgHumidity,
+			Description:    day.Day.Condition.Text,
+		})
+		for _, hour := range day.Hour {
+			forecast.Hourly = append(forecast.Hourly, contracts.HourlyForecast{
+				Time:        time.Unix(hour.TimeEpoch, 0).UTC(),
+				Temperature: hour.TempC,
+				Humidity:    hour.Humidity,
+				Description: hour.Condition.Text,
+			})
+		}
+	}
+
+	return forecast, nil
 }
@@ -6,11 +6,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"weather_microservice/internal/adapters"
 	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
 )
 
 func TestWeatherAPIAdapter_CityNotFound(t *testing.T) {
@@ -34,17 +36,32 @@ func TestWeatherAPIAdapter_CityNotFound(t *testing.T) {
 		adapters.WeatherAPIBaseURL = originalBaseURL
 	}()
 
-	_, err = adapter.FetchWeather(context.Background(), "InvalidCity")
+	_, err = adapter.FetchWeather(context.Background(), contracts.CityLocation("InvalidCity"))
 	require.ErrorIs(t, err, apierrors.ErrCityNotFound)
 }
 
 func TestWeatherAPIAdapter_Success(t *testing.T) {
 	mockResponse := `{
+		"location": {"localtime_epoch": 1717405200, "localtime": "2024-06-03 12:00"},
 		"current": {
+			"last_updated_epoch": 1717404300,
 			"temp_c": 21.1,
+			"feelslike_c": 20.4,
 			"humidity": 72,
-			"condition": {"text": "Partly cloudy"}
-		}
+			"wind_kph": 18,
+			"wind_degree": 90,
+			"pressure_mb": 1009,
+			"precip_mm": 0.2,
+			"cloud": 50,
+			"vis_km": 10,
+			"uv": 5,
+			"is_day": 1,
+			"condition": {"text": "Patchy light rain with thunder", "code": 1273}
+		},
+		"forecast": {"forecastday": [{
+			"date": "2024-06-03",
+			"astro": {"sunrise": "04:47 AM", "sunset": "09:05 PM"}
+		}]}
 	}`
 
 	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -67,11 +84,53 @@ func TestWeatherAPIAdapter_Success(t *testing.T) {
 		adapters.WeatherAPIBaseURL = originalBaseURL
 	}()
 
-	data, err := adapter.FetchWeather(context.Background(), "Kyiv")
+	data, err := adapter.FetchWeather(context.Background(), contracts.CityLocation("Kyiv"))
 	require.NoError(t, err)
 	require.Equal(t, 21.1, data.Temperature)
 	require.Equal(t, 72.0, data.Humidity)
-	require.Equal(t, "Partly cloudy", data.Description)
+	require.Equal(t, "Patchy light rain with thunder", data.Description)
+	require.Equal(t, contracts.ConditionThunderstorm, data.Condition)
+	require.Equal(t, contracts.SeverityModerate, data.Severity)
+	require.Equal(t, "thunderstorm", data.Icon)
+	require.Equal(t, 20.4, data.FeelsLike)
+	require.Equal(t, 5.0, data.WindSpeed)
+	require.Equal(t, 90.0, data.WindDirection)
+	require.Equal(t, 1009.0, data.Pressure)
+	require.Equal(t, 0.2, data.Precipitation)
+	require.Equal(t, 50.0, data.CloudCover)
+	require.NotNil(t, data.UVIndex)
+	require.Equal(t, 5.0, *data.UVIndex)
+	require.Equal(t, 10.0, data.Visibility)
+	require.Equal(t, time.Unix(1717404300, 0).UTC(), data.ObservedAt)
+	require.Equal(t, 3*60*60, data.UTCOffset)
+	// Local 12:00 at 09:00 UTC means the city is at UTC+3.
+	require.Equal(t, time.Date(2024, 6, 3, 1, 47, 0, 0, time.UTC), data.Sunrise)
+	require.Equal(t, time.Date(2024, 6, 3, 18, 5, 0, 0, time.UTC), data.Sunset)
+}
+
+func TestWeatherAPIAdapter_Coordinates(t *testing.T) {
+	var gotQuery string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query().Get("q")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"current": {"temp_c": 20, "humidity": 40, "condition": {"text": "Sunny"}}}`))
+	}))
+	defer mockServer.Close()
+
+	adapter, err := adapters.NewWeatherAPIAdapter("fake-key")
+	require.NoError(t, err)
+
+	originalBaseURL := adapters.WeatherAPIBaseURL
+	adapters.WeatherAPIBaseURL = func() string {
+		return mockServer.URL
+	}
+	defer func() {
+		adapters.WeatherAPIBaseURL = originalBaseURL
+	}()
+
+	_, err = adapter.FetchWeather(context.Background(), contracts.CoordinatesLocation(50.45, 30.5234))
+	require.NoError(t, err)
+	require.Equal(t, "50.450000,30.523400", gotQuery)
 }
 
 func TestWeatherAPIAdapter_InvalidJSON(t *testing.T) {
@@ -95,7 +154,7 @@ func TestWeatherAPIAdapter_InvalidJSON(t *testing.T) {
 		adapters.WeatherAPIBaseURL = originalBaseURL
 	}()
 
-	_, err = adapter.FetchWeather(context.Background(), "Kyiv")
+	_, err = adapter.FetchWeather(context.Background(), contracts.CityLocation("Kyiv"))
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "decode")
 }
@@ -120,7 +179,73 @@ func TestWeatherAPIAdapter_EmptyResponse(t *testing.T) {
 		adapters.WeatherAPIBaseURL = originalBaseURL
 	}()
 
-	_, err = adapter.FetchWeather(context.Background(), "Kyiv")
+	_, err = adapter.FetchWeather(context.Background(), contracts.CityLocation("Kyiv"))
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "empty response body")
 }
+
+func TestWeatherAPIAdapter_ForecastSuccess(t *testing.T) {
+	mockResponse := `{
+		"location": {"name": "Kyiv"},
+		"forecast": {
+			"forecastday": [{
+				"date": "2024-06-15",
+				"day": {"maxtemp_c": 25.0, "mintemp_c": 14.0, "avghumidity": 55, "condition": {"text": "Sunny"}},
+				"hour": [
+					{"time_epoch": 1718398800, "temp_c": 14.0, "humidity": 80, "condition": {"text": "Clear"}},
+					{"time_epoch": 1718442000, "temp_c": 25.0, "humidity": 40, "condition": {"text": "Sunny"}}
+				]
+			}]
+		}
+	}`
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/forecast.json", r.URL.Path)
+		require.Equal(t, "2", r.URL.Query().Get("days"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(mockResponse))
+	}))
+	defer mockServer.Close()
+
+	adapter, err := adapters.NewWeatherAPIAdapter("fake-key")
+	require.NoError(t, err)
+
+	originalBaseURL := adapters.WeatherAPIBaseURL
+	adapters.WeatherAPIBaseURL = func() string {
+		return mockServer.URL
+	}
+	defer func() {
+		adapters.WeatherAPIBaseURL = originalBaseURL
+	}()
+
+	data, err := adapter.FetchForecast(context.Background(), contracts.CityLocation("Kyiv"), 2)
+	require.NoError(t, err)
+	require.Equal(t, "Kyiv", data.City)
+	require.Len(t, data.Hourly, 2)
+	require.Len(t, data.Daily, 1)
+	require.Equal(t, 14.0, data.Daily[0].MinTemperature)
+	require.Equal(t, 25.0, data.Daily[0].MaxTemperature)
+	require.Equal(t, "Sunny", data.Daily[0].Description)
+}
+
+func TestWeatherAPIAdapter_ForecastCityNotFound(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"code":1006,"message":"No matching location found."}}`))
+	}))
+	defer mockServer.Close()
+
+	adapter, err := adapters.NewWeatherAPIAdapter("fake-key")
+	require.NoError(t, err)
+
+	originalBaseURL := adapters.WeatherAPIBaseURL
+	adapters.WeatherAPIBaseURL = func() string {
+		return mockServer.URL
+	}
+	defer func() {
+		adapters.WeatherAPIBaseURL = originalBaseURL
+	}()
+
+	_, err = adapter.FetchForecast(context.Background(), contracts.CityLocation("InvalidCity"), 1)
+	require.ErrorIs(t, err, apierrors.ErrCityNotFound)
+}
@@ -0,0 +1,65 @@
+package adapters
+
+import "weather_microservice/internal/contracts"
+
+// weatherAPICodes maps WeatherAPI condition codes
+// (https://www.weatherapi.com/docs/weather_conditions.json) to the canonical
+// taxonomy. WeatherAPI texts are used as descriptions, so they are omitted here.
+var weatherAPICodes = map[int]nativeCondition{
+	1000: {"", contracts.ConditionClear, contracts.SeverityNone},            // Sunny / Clear
+	1003: {"", contracts.ConditionPartlyCloudy, contracts.SeverityNone},     // Partly cloudy
+	1006: {"", contracts.ConditionCloudy, contracts.SeverityNone},           // Cloudy
+	1009: {"", contracts.ConditionCloudy, contracts.SeverityNone},           // Overcast
+	1030: {"", contracts.ConditionFog, contracts.SeverityMinor},             // Mist
+	1063: {"", contracts.ConditionRain, contracts.SeverityMinor},            // Patchy rain possible
+	1066: {"", contracts.ConditionSnow, contracts.SeverityMinor},            // Patchy snow possible
+	1069: {"", contracts.ConditionSleet, contracts.SeverityMinor},           // Patchy sleet possible
+	1072: {"", contracts.ConditionFreezingRain, contracts.SeverityModerate}, // Patchy freezing drizzle possible
+	1087: {"", contracts.ConditionThunderstorm, contracts.SeverityModerate}, // Thundery outbreaks possible
+	1114: {"", contracts.ConditionSnow, contracts.SeverityModerate},         // Blowing snow
+	1117: {"", contracts.ConditionSnow, contracts.SeveritySevere},           // Blizzard
+	1135: {"", contracts.ConditionFog, contracts.SeverityMinor},             // Fog
+	1147: {"", contracts.ConditionFog, contracts.SeverityModerate},          // Freezing fog
+	1150: {"", contracts.ConditionDrizzle, contracts.SeverityMinor},         // Patchy light drizzle
+	1153: {"", contracts.ConditionDrizzle, contracts.SeverityMinor},         // Light drizzle
+	1168: {"", contracts.ConditionFreezingRain, contracts.SeverityModerate}, // Freezing drizzle
+	1171: {"", contracts.ConditionFreezingRain, contracts.SeveritySevere},   // Heavy freezing drizzle
+	1180: {"", contracts.ConditionRain, contracts.SeverityMinor},            // Patchy light rain
+	1183: {"", contracts.ConditionRain, contracts.SeverityMinor},            // Light rain
+	1186: {"", contracts.ConditionRain, contracts.SeverityModerate},         // Moderate rain at times
+	1189: {"", contracts.ConditionRain, contracts.SeverityModerate},         // Moderate rain
+	1192: {"", contracts.ConditionRain, contracts.SeveritySevere},           // Heavy rain at times
+	1195: {"", contracts.ConditionRain, contracts.SeveritySevere},           // Heavy rain
+	1198: {"", contracts.ConditionFreezingRain, contracts.SeverityModerate}, // Light freezing rain
+	1201: {"", contracts.ConditionFreezingRain, contracts.SeveritySevere},   // Moderate or heavy freezing rain
+	1204: {"", contracts.ConditionSleet, contracts.SeverityMinor},           // Light sleet
+	1207: {"", contracts.ConditionSleet, contracts.SeverityModerate},        // Moderate or heavy sleet
+	1210: {"", contracts.ConditionSnow, contracts.SeverityMinor},            // Patchy light snow
+	1213: {"", contracts.ConditionSnow, contracts.SeverityMinor},            // Light snow
+	1216: {"", contracts.ConditionSnow, contracts.SeverityModerate},         // Patchy moderate snow
+	1219: {"", contracts.ConditionSnow, contracts.SeverityModerate},         // Moderate snow
+	1222: {"", contracts.ConditionSnow, contracts.SeveritySevere},           // Patchy heavy snow
+	1225: {"", contracts.ConditionSnow, contracts.SeveritySevere},           // Heavy snow
+	1237: {"", contracts.ConditionSleet, contracts.SeverityModerate},        // Ice pellets
+	1240: {"", contracts.ConditionRain, contracts.SeverityMinor},            // Light rain shower
+	1243: {"", contracts.ConditionRain, contracts.SeverityModerate},         // Moderate or heavy rain shower
+	1246: {"", contracts.ConditionRain, contracts.SeveritySevere},           // Torrential rain shower
+	1249: {"", contracts.ConditionSleet, contracts.SeverityMinor},           // Light sleet showers
+	1252: {"", contracts.ConditionSleet, contracts.SeverityModerate},        // Moderate or heavy sleet showers
+	1255: {"", contracts.ConditionSnow, contracts.SeverityMinor},            // Light snow showers
+	1258: {"", contracts.ConditionSnow, contracts.SeverityModerate},         // Moderate or heavy snow showers
+	1261: {"", contracts.ConditionSleet, contracts.SeverityMinor},           // Light showers of ice pellets
+	1264: {"", contracts.ConditionSleet, contracts.SeverityModerate},        // Moderate or heavy showers of ice pellets
+	1273: {"", contracts.ConditionThunderstorm, contracts.SeverityModerate}, // Patchy light rain with thunder
+	1276: {"", contracts.ConditionThunderstorm, contracts.SeveritySevere},   // Moderate or heavy rain with thunder
+	1279: {"", contracts.ConditionThunderstorm, contracts.SeverityModerate}, // Patchy light snow with thunder
+	1282: {"", contracts.ConditionThunderstorm, contracts.SeveritySevere},   // Moderate or heavy snow with thunder
+}
+
+// weatherAPICondition returns the canonical condition of a WeatherAPI code.
+func weatherAPICondition(code int) nativeCondition {
+	if c, ok := weatherAPICodes[code]; ok {
+		return c
+	}
+	return unknownCondition
+}
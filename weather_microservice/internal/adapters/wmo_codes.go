@@ -0,0 +1,57 @@
+package adapters
+
+import (
+	"fmt"
+
+	"weather_microservice/internal/contracts"
+)
+
+// wmoCodes maps WMO weather interpretation codes (WW), as returned by
+// Open-Meteo in the weather_code field, to human-readable descriptions and
+// canonical conditions.
+var wmoCodes = map[int]nativeCondition{
+	0:  {"Clear sky", contracts.ConditionClear, contracts.SeverityNone},
+	1:  {"Mainly clear", contracts.ConditionClear, contracts.SeverityNone},
+	2:  {"Partly cloudy", contracts.ConditionPartlyCloudy, contracts.SeverityNone},
+	3:  {"Overcast", contracts.ConditionCloudy, contracts.SeverityNone},
+	45: {"Fog", contracts.ConditionFog, contracts.SeverityMinor},
+	48: {"Depositing rime fog", contracts.ConditionFog, contracts.SeverityModerate},
+	51: {"Light drizzle", contracts.ConditionDrizzle, contracts.SeverityMinor},
+	53: {"Moderate drizzle", contracts.ConditionDrizzle, contracts.SeverityMinor},
+	55: {"Dense drizzle", contracts.ConditionDrizzle, contracts.SeverityModerate},
+	56: {"Light freezing drizzle", contracts.ConditionFreezingRain, contracts.SeverityModerate},
+	57: {"Dense freezing drizzle", contracts.ConditionFreezingRain, contracts.SeveritySevere},
+	61: {"Slight rain", contracts.ConditionRain, contracts.SeverityMinor},
+	63: {"Moderate rain", contracts.ConditionRain, contracts.SeverityModerate},
+	65: {"Heavy rain", contracts.ConditionRain, contracts.SeveritySevere},
+	66: {"Light freezing rain", contracts.ConditionFreezingRain, contracts.SeverityModerate},
+	67: {"Heavy freezing rain", contracts.ConditionFreezingRain, contracts.SeveritySevere},
+	71: {"Slight snow fall", contracts.ConditionSnow, contracts.SeverityMinor},
+	73: {"Moderate snow fall", contracts.ConditionSnow, contracts.SeverityModerate},
+	75: {"Heavy snow fall", contracts.ConditionSnow, contracts.SeveritySevere},
+	77: {"Snow grains", contracts.ConditionSnow, contracts.SeverityMinor},
+	80: {"Slight rain showers", contracts.ConditionRain, contracts.SeverityMinor},
+	81: {"Moderate rain showers", contracts.ConditionRain, contracts.SeverityModerate},
+	82: {"Violent rain showers", contracts.ConditionRain, contracts.SeveritySevere},
+	85: {"Slight snow showers", contracts.ConditionSnow, contracts.SeverityMinor},
+	86: {"Heavy snow showers", contracts.ConditionSnow, contracts.SeveritySevere},
+	95: {"Thunderstorm", contracts.ConditionThunderstorm, contracts.SeverityModerate},
+	96: {"Thunderstorm with slight hail", contracts.ConditionThunderstorm, contracts.SeveritySevere},
+	99: {"Thunderstorm with heavy hail", contracts.ConditionThunderstorm, contracts.SeveritySevere},
+}
+
+// wmoDescription returns the description of a WMO weather code.
+func wmoDescription(code int) string {
+	if c, ok := wmoCodes[code]; ok {
+		return c.description
+	}
+	return fmt.Sprintf("Unknown weather code %d", code)
+}
+
+// wmoCondition returns the canonical condition of a WMO weather code.
+func wmoCondition(code int) nativeCondition {
+	if c, ok := wmoCodes[code]; ok {
+		return c
+	}
+	return unknownCondition
+}
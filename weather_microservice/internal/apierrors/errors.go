@@ -10,7 +10,13 @@ var (
 	ErrFailedSendConfirmEmail = errors.New("failed to send confirmation email")
 	ErrInvalidEmail           = errors.New("invalid email")
 	ErrInvalidCity            = errors.New("invalid city")
+	ErrInvalidCoordinates     = errors.New("invalid coordinates")
 	ErrInvalidFrequency       = errors.New("invalid frequency")
+	ErrInvalidForecastDays    = errors.New("invalid forecast days")
+	ErrInvalidTimeRange       = errors.New("invalid time range")
+	ErrHistoryDisabled        = errors.New("weather history is disabled")
+	ErrBatchTooLarge          = errors.New("too many locations in batch")
+	ErrInvalidCityQuery       = errors.New("invalid city search query")
 	// Cache-related errors.
 
 	ErrCacheMiss        = errors.New("cache miss")
@@ -18,4 +24,27 @@ var (
 	ErrCacheTimeout     = errors.New("cache operation timeout")
 	ErrCacheUnavailable = errors.New("cache unavailable")
 	ErrCacheCorrupted   = errors.New("cached data corrupted")
+	ErrInvalidPattern   = errors.New("invalid cache key pattern")
+
+	// API key errors.
+
+	ErrMissingAPIKey     = errors.New("api key is required")
+	ErrInvalidAPIKey     = errors.New("invalid api key")
+	ErrInsufficientScope = errors.New("api key lacks the required scope")
+	ErrAPIQuotaExceeded  = errors.New("api key quota exceeded")
+	ErrAuthUnavailable   = errors.New("authentication is unavailable")
+
+	// Provider-related errors.
+
+	ErrCircuitOpen = errors.New("provider circuit breaker is open")
+	// ErrProviderBudgetExhausted means the provider was skipped because its
+	// daily or monthly call budget is used up.
+	ErrProviderBudgetExhausted = errors.New("provider call budget exhausted")
+	// ErrProviderQuotaDisabled means provider call budgets are not tracked.
+	ErrProviderQuotaDisabled = errors.New("provider quota tracking is disabled")
+	// ErrAllProvidersFailed is returned together with the last provider error
+	// once the chain has nobody left to ask.
+	ErrAllProvidersFailed = errors.New("all weather providers failed")
+	// ErrGeocodingUnavailable means no configured provider can search cities.
+	ErrGeocodingUnavailable = errors.New("no provider supports city search")
 )
@@ -0,0 +1,88 @@
+// Package apikeys authenticates clients of the public API by API keys and
+// counts their usage against per-key quotas.
+package apikeys
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+)
+
+// Hash returns the hex SHA-256 hash under which a key secret is stored.
+func Hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Authenticator checks API keys against a store and counts their requests.
+type Authenticator struct {
+	store contracts.APIKeyStore
+	now   func() time.Time
+}
+
+// NewAuthenticator creates an authenticator on top of a key store.
+func NewAuthenticator(store contracts.APIKeyStore) *Authenticator {
+	return &Authenticator{store: store, now: time.Now}
+}
+
+// Authenticate resolves the key of secret and checks that it has scope.
+// Requests are counted separately by CountUsage, once they pass rate limits.
+func (a *Authenticator) Authenticate(ctx context.Context, secret, scope string) (contracts.APIKey, error) {
+	if secret == "" {
+		return contracts.APIKey{}, apierrors.ErrMissingAPIKey
+	}
+	key, err := a.store.Lookup(ctx, Hash(secret))
+	if err != nil {
+		return contracts.APIKey{}, err
+	}
+	if !key.HasScope(scope) {
+		return contracts.APIKey{}, fmt.Errorf("%w: %s", apierrors.ErrInsufficientScope, scope)
+	}
+	return key, nil
+}
+
+// CountUsage counts a request of key against its daily quota and returns
+// ErrAPIQuotaExceeded once the quota is used up.
+func (a *Authenticator) CountUsage(ctx context.Context, key contracts.APIKey) error {
+	used, err := a.store.IncrementUsage(ctx, key.ID, a.now())
+	if err != nil {
+		return fmt.Errorf("failed to count api key usage: %w", err)
+	}
+	if key.DailyQuota > 0 && used > key.DailyQuota {
+		return apierrors.ErrAPIQuotaExceeded
+	}
+	return nil
+}
+
+// Usage returns the requests of a key on the day of t.
+func (a *Authenticator) Usage(ctx context.Context, keyID string, t time.Time) (int64, error) {
+	return a.store.Usage(ctx, keyID, t)
+}
+
+// Close closes the key store.
+func (a *Authenticator) Close() error {
+	return a.store.Close()
+}
+
+type contextKey struct{}
+
+// NewContext returns a context carrying the authenticated key.
+func NewContext(ctx context.Context, key contracts.APIKey) context.Context {
+	return context.WithValue(ctx, contextKey{}, key)
+}
+
+// FromContext returns the authenticated key of a request, if any.
+func FromContext(ctx context.Context) (contracts.APIKey, bool) {
+	key, ok := ctx.Value(contextKey{}).(contracts.APIKey)
+	return key, ok
+}
+
+// day truncates t to its UTC day, the period of key quotas.
+func day(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,79 @@
+package apikeys
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+)
+
+func TestAuthenticator_Authenticate(t *testing.T) {
+	store := NewFileStore(map[string]contracts.APIKey{
+		Hash("partner-secret"): {ID: "partner", Scopes: []string{contracts.ScopeWeatherRead}, DailyQuota: 2},
+	})
+	auth := NewAuthenticator(store)
+	now := time.Date(2025, 6, 1, 23, 0, 0, 0, time.UTC)
+	auth.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, err := auth.Authenticate(ctx, "", contracts.ScopeWeatherRead)
+	require.ErrorIs(t, err, apierrors.ErrMissingAPIKey)
+
+	_, err = auth.Authenticate(ctx, "wrong-secret", contracts.ScopeWeatherRead)
+	require.ErrorIs(t, err, apierrors.ErrInvalidAPIKey)
+
+	_, err = auth.Authenticate(ctx, "partner-secret", contracts.ScopeSubscribe)
+	require.ErrorIs(t, err, apierrors.ErrInsufficientScope)
+
+	key, err := auth.Authenticate(ctx, "partner-secret", contracts.ScopeWeatherRead)
+	require.NoError(t, err)
+	assert.Equal(t, "partner", key.ID)
+
+	// Authentication alone does not count requests.
+	used, err := auth.Usage(ctx, "partner", now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), used)
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, auth.CountUsage(ctx, key))
+	}
+	require.ErrorIs(t, auth.CountUsage(ctx, key), apierrors.ErrAPIQuotaExceeded)
+
+	used, err = auth.Usage(ctx, "partner", now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), used)
+
+	// The quota resets on the next UTC day.
+	now = now.Add(2 * time.Hour)
+	require.NoError(t, auth.CountUsage(ctx, key))
+}
+
+func TestLoadFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api_keys.json")
+	content := `{"keys": [{"id": "partner", "name": "Partner", "key_sha256": "` + Hash("secret") +
+		`", "scopes": ["weather:read", "subscribe"], "daily_quota": 100}]}`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	store, err := LoadFileStore(path)
+	require.NoError(t, err)
+
+	key, err := store.Lookup(context.Background(), Hash("secret"))
+	require.NoError(t, err)
+	assert.Equal(t, contracts.APIKey{
+		ID:         "partner",
+		Name:       "Partner",
+		Scopes:     []string{"weather:read", "subscribe"},
+		DailyQuota: 100,
+	}, key)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"keys": [{"id": "partner"}]}`), 0o600))
+	_, err = LoadFileStore(path)
+	require.Error(t, err)
+}
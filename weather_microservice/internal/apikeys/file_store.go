@@ -0,0 +1,97 @@
+package apikeys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+)
+
+// FileStore keeps keys loaded from a JSON file and counts usage in memory,
+// so usage is per replica and resets on restart.
+type FileStore struct {
+	keys map[string]contracts.APIKey
+
+	mu    sync.Mutex
+	usage map[usageKey]int64
+}
+
+type usageKey struct {
+	keyID string
+	day   time.Time
+}
+
+// compile-time гарантія, що реалізує інтерфейс.
+var _ contracts.APIKeyStore = (*FileStore)(nil)
+
+// fileKey is one entry of the keys file.
+type fileKey struct {
+	contracts.APIKey
+	// KeySHA256 is the hex SHA-256 hash of the key secret.
+	KeySHA256 string `json:"key_sha256"`
+}
+
+// LoadFileStore reads keys from a JSON file of the form
+// {"keys": [{"id": "...", "name": "...", "key_sha256": "...", "scopes": [...], "daily_quota": 0}]}.
+func LoadFileStore(path string) (*FileStore, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read api keys file: %w", err)
+	}
+	var file struct {
+		Keys []fileKey `json:"keys"`
+	}
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse api keys file: %w", err)
+	}
+
+	keys := make(map[string]contracts.APIKey, len(file.Keys))
+	for _, k := range file.Keys {
+		if k.ID == "" || k.KeySHA256 == "" {
+			return nil, fmt.Errorf("api key entries require id and key_sha256")
+		}
+		keys[k.KeySHA256] = k.APIKey
+	}
+	return NewFileStore(keys), nil
+}
+
+// NewFileStore creates a store of keys by the hash of their secret.
+func NewFileStore(keys map[string]contracts.APIKey) *FileStore {
+	return &FileStore{keys: keys, usage: make(map[usageKey]int64)}
+}
+
+func (s *FileStore) Lookup(ctx context.Context, hash string) (contracts.APIKey, error) {
+	key, ok := s.keys[hash]
+	if !ok {
+		return contracts.APIKey{}, apierrors.ErrInvalidAPIKey
+	}
+	return key, nil
+}
+
+func (s *FileStore) IncrementUsage(ctx context.Context, keyID string, t time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	today := day(t)
+	for k := range s.usage {
+		if k.day.Before(today) {
+			delete(s.usage, k)
+		}
+	}
+	k := usageKey{keyID: keyID, day: today}
+	s.usage[k]++
+	return s.usage[k], nil
+}
+
+func (s *FileStore) Usage(ctx context.Context, keyID string, t time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.usage[usageKey{keyID: keyID, day: day(t)}], nil
+}
+
+func (s *FileStore) Close() error { return nil }
@@ -0,0 +1,126 @@
+package apikeys
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+
+	_ "github.com/lib/pq" // Postgres driver for database/sql.
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS api_keys (
+	id          TEXT PRIMARY KEY,
+	name        TEXT NOT NULL DEFAULT '',
+	key_sha256  TEXT NOT NULL UNIQUE,
+	scopes      TEXT NOT NULL DEFAULT '',
+	daily_quota BIGINT NOT NULL DEFAULT 0,
+	revoked_at  TIMESTAMPTZ
+);
+CREATE TABLE IF NOT EXISTS api_key_usage (
+	key_id   TEXT NOT NULL REFERENCES api_keys (id) ON DELETE CASCADE,
+	day      DATE NOT NULL,
+	requests BIGINT NOT NULL,
+	PRIMARY KEY (key_id, day)
+);`
+
+// PostgresStore keeps keys and their daily usage in Postgres, so usage is
+// shared by all replicas. Scopes are stored comma-separated.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// compile-time гарантія, що реалізує інтерфейс.
+var _ contracts.APIKeyStore = (*PostgresStore)(nil)
+
+// NewPostgresStore creates a store on top of an open database.
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// OpenPostgresStore connects to Postgres and creates the key tables if needed.
+func OpenPostgresStore(ctx context.Context, databaseURL string) (*PostgresStore, error) {
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open api keys database: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to connect to api keys database: %w", err)
+	}
+	store := NewPostgresStore(db)
+	if err := store.Migrate(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// Migrate creates the key and usage tables.
+func (s *PostgresStore) Migrate(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, schema); err != nil {
+		return fmt.Errorf("failed to migrate api keys database: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) Lookup(ctx context.Context, hash string) (contracts.APIKey, error) {
+	var key contracts.APIKey
+	var scopes string
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, name, scopes, daily_quota FROM api_keys
+		WHERE key_sha256 = $1 AND revoked_at IS NULL`,
+		hash,
+	).Scan(&key.ID, &key.Name, &scopes, &key.DailyQuota)
+	if errors.Is(err, sql.ErrNoRows) {
+		return contracts.APIKey{}, apierrors.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return contracts.APIKey{}, fmt.Errorf("failed to look up api key: %w", err)
+	}
+	for _, scope := range strings.Split(scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			key.Scopes = append(key.Scopes, scope)
+		}
+	}
+	return key, nil
+}
+
+func (s *PostgresStore) IncrementUsage(ctx context.Context, keyID string, t time.Time) (int64, error) {
+	var requests int64
+	err := s.db.QueryRowContext(ctx,
+		`INSERT INTO api_key_usage (key_id, day, requests) VALUES ($1, $2, 1)
+		ON CONFLICT (key_id, day) DO UPDATE SET requests = api_key_usage.requests + 1
+		RETURNING requests`,
+		keyID, day(t),
+	).Scan(&requests)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count api key usage: %w", err)
+	}
+	return requests, nil
+}
+
+func (s *PostgresStore) Usage(ctx context.Context, keyID string, t time.Time) (int64, error) {
+	var requests int64
+	err := s.db.QueryRowContext(ctx,
+		`SELECT requests FROM api_key_usage WHERE key_id = $1 AND day = $2`,
+		keyID, day(t),
+	).Scan(&requests)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read api key usage: %w", err)
+	}
+	return requests, nil
+}
+
+func (s *PostgresStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,60 @@
+package apikeys
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+)
+
+func newMockStore(t *testing.T) (*PostgresStore, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return NewPostgresStore(db), mock
+}
+
+func TestPostgresStore_Lookup(t *testing.T) {
+	store, mock := newMockStore(t)
+
+	mock.ExpectQuery("SELECT id, name, scopes, daily_quota FROM api_keys").
+		WithArgs("known").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "scopes", "daily_quota"}).
+			AddRow("partner", "Partner", "weather:read, subscribe", 1000))
+	mock.ExpectQuery("SELECT id, name, scopes, daily_quota FROM api_keys").
+		WithArgs("unknown").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "scopes", "daily_quota"}))
+
+	key, err := store.Lookup(context.Background(), "known")
+	require.NoError(t, err)
+	assert.Equal(t, contracts.APIKey{
+		ID:         "partner",
+		Name:       "Partner",
+		Scopes:     []string{"weather:read", "subscribe"},
+		DailyQuota: 1000,
+	}, key)
+
+	_, err = store.Lookup(context.Background(), "unknown")
+	require.ErrorIs(t, err, apierrors.ErrInvalidAPIKey)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_IncrementUsage(t *testing.T) {
+	store, mock := newMockStore(t)
+	now := time.Date(2025, 6, 1, 15, 30, 0, 0, time.UTC)
+
+	mock.ExpectQuery("INSERT INTO api_key_usage").
+		WithArgs("partner", time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)).
+		WillReturnRows(sqlmock.NewRows([]string{"requests"}).AddRow(42))
+
+	used, err := store.IncrementUsage(context.Background(), "partner", now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), used)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
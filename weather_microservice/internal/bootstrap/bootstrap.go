@@ -1,14 +1,27 @@
 package bootstrap
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
 	"weather_microservice/internal/adapters"
+	"weather_microservice/internal/apikeys"
 	"weather_microservice/internal/cache"
 	"weather_microservice/internal/chain"
+	"weather_microservice/internal/client"
 	"weather_microservice/internal/config"
 	"weather_microservice/internal/contracts"
+	"weather_microservice/internal/history"
+	"weather_microservice/internal/quota"
+	"weather_microservice/internal/ratelimit"
 	"weather_microservice/internal/weather_service"
 	"weather_microservice/internal/logging"
+	"weather_microservice/internal/warmup"
 )
 
 func InitWeatherService(cfg *config.Config) (weather_service.WeatherService, error) {
@@ -21,9 +34,11 @@ func InitWeatherService(cfg *config.Config) (weather_service.WeatherService, err
 	metrics.Register()
 
 	// Setup cache
-	var redisCache contracts.WeatherCache
+	var remoteCache cache.Backend
+	var negativeCache contracts.NegativeCache = cache.NoopWeatherCache{}
+	var cityCache contracts.CityCache = cache.NoopWeatherCache{}
 	if cfg.Cache.Enabled {
-		redisCache =  cache.NewRedisCache(
+		rc := cache.NewRedisCache(
 			cache.RedisConfig{
 				Addr:     cfg.Cache.Redis.Addr,
 				Password: cfg.Cache.Redis.Password,
@@ -37,20 +52,28 @@ func InitWeatherService(cfg *config.Config) (weather_service.WeatherService, err
 			},
 			metrics,
 		)
-	
-	} else {
-		redisCache = cache.NoopWeatherCache{}
+		if rc != nil {
+			remoteCache = rc
+			negativeCache = rc
+			cityCache = rc
+		} else {
+			log.Printf("Redis is unreachable, continuing without Redis cache")
+		}
 	}
 
-	// Setup adapters
-	openWeather, err := adapters.NewOpenWeatherAdapter(cfg.OpenWeatherKey)
-	if err != nil {
-		return weather_service.WeatherService{}, fmt.Errorf("failed to create OpenWeather adapter: %w", err)
-	}
-
-	weatherAPI, err := adapters.NewWeatherAPIAdapter(cfg.WeatherKey)
-	if err != nil {
-		return weather_service.WeatherService{}, fmt.Errorf("failed to create WeatherAPI adapter: %w", err)
+	var weatherCache cache.Backend
+	switch {
+	case cfg.Cache.Enabled && cfg.Cache.Memory.Enabled:
+		memoryCache := cache.NewMemoryCache(cfg.Cache.Memory.MaxEntries, cfg.Cache.Memory.TTL, metrics)
+		if remoteCache != nil {
+			weatherCache = cache.NewTieredCache(memoryCache, remoteCache)
+		} else {
+			weatherCache = memoryCache
+		}
+	case remoteCache != nil:
+		weatherCache = remoteCache
+	default:
+		weatherCache = cache.NoopWeatherCache{}
 	}
 
 	// Setup logger
@@ -59,10 +82,259 @@ func InitWeatherService(cfg *config.Config) (weather_service.WeatherService, err
 	// Setup chain
 	weatherChain := chain.NewWeatherChain(logger)
 
-	owHandler := chain.NewBaseWeatherHandler(&openWeather, "openweather")
-	waHandler := chain.NewBaseWeatherHandler(&weatherAPI, "weatherapi")
-	owHandler.SetNext(waHandler)
-	weatherChain.SetFirstHandler(owHandler)
+	chainMetrics := chain.NewPrometheusMetrics()
+	chainMetrics.Register()
+	weatherChain.SetMetrics(chainMetrics)
+
+	switch strategy := chain.Strategy(cfg.Chain.Strategy); strategy {
+	case chain.StrategySequential, chain.StrategyRace:
+		weatherChain.SetStrategy(strategy, cfg.Chain.HedgeDelay)
+	case chain.StrategyConsensus:
+		weatherChain.SetStrategy(strategy, 0)
+		weatherChain.SetConsensusOptions(chain.ConsensusOptions{
+			TemperatureThreshold: cfg.Chain.OutlierTemperature,
+			HumidityThreshold:    cfg.Chain.OutlierHumidity,
+		})
+	default:
+		return weather_service.WeatherService{}, fmt.Errorf("unknown WEATHER_CHAIN_STRATEGY %q", cfg.Chain.Strategy)
+	}
+
+	tracker := initProviderQuota(cfg)
+	if tracker != nil {
+		weatherChain.SetQuota(tracker)
+	}
+
+	// Setup providers in the configured order.
+	registry := newProviderRegistry()
+	var enabled []string
+	for _, p := range cfg.Providers {
+		// A misspelled name would otherwise drop the provider silently.
+		if !registry.Has(p.Name) {
+			return weather_service.WeatherService{}, fmt.Errorf("unknown weather provider %q in WEATHER_PROVIDERS, known providers: %s",
+				p.Name, strings.Join(registry.Names(), ", "))
+		}
+		if !p.Enabled {
+			log.Printf("Weather provider %s is disabled", p.Name)
+			continue
+		}
+		provider, err := registry.Create(p.Name, chain.ProviderSettings{
+			APIKey:  p.APIKey,
+			Timeout: p.Timeout,
+		})
+		if err != nil {
+			log.Printf("Skipping weather provider %s: %v", p.Name, err)
+			continue
+		}
+		handler := chain.NewBaseWeatherHandler(provider, p.Name)
+		handler.SetMetrics(chainMetrics)
+		if tracker != nil {
+			tracker.SetBudget(p.Name, quota.Budget{Daily: int64(p.DailyBudget), Monthly: int64(p.MonthlyBudget)})
+			handler.SetQuota(tracker)
+		}
+		weatherChain.AddHandler(withCircuitBreaker(cfg, handler, chainMetrics))
+		if geocoder, ok := provider.(chain.GeocodingProvider); ok {
+			weatherChain.AddGeocoder(p.Name, geocoder)
+		}
+		enabled = append(enabled, p.Name)
+	}
+	if len(enabled) == 0 {
+		return weather_service.WeatherService{}, fmt.Errorf("no weather providers available, check WEATHER_PROVIDERS and API keys")
+	}
+	log.Printf("Weather provider chain: %s", strings.Join(enabled, " -> "))
+	if chain.Strategy(cfg.Chain.Strategy) == chain.StrategyConsensus && len(enabled) < chain.MinOutlierProviders {
+		log.Printf("Warning: consensus needs at least %d weather providers to drop outliers, "+
+			"with %d it averages the answers and only reports disagreements", chain.MinOutlierProviders, len(enabled))
+	}
+
+	serviceMetrics := weather_service.NewPrometheusMetrics()
+	serviceMetrics.Register()
+
+	svc := weather_service.NewWeatherService(
+		weatherChain,
+		weatherCache,
+		weatherCache,
+		negativeCache,
+		cfg.Cache.Expiration,
+		cfg.Cache.ForecastExpiration,
+		cfg.Cache.NotFoundExpiration,
+		weather_service.StalePolicy{
+			WhileRevalidate: cfg.Cache.StaleWhileRevalidate,
+			IfError:         cfg.Cache.StaleIfError,
+		},
+		serviceMetrics,
+	)
+	svc.SetCityCache(cityCache, cfg.Cache.CitiesExpiration)
+	if tracker != nil {
+		svc.SetProviderQuotas(tracker)
+	}
+
+	if store := initHistory(cfg); store != nil {
+		weatherChain.SetHistory(store)
+		svc.SetHistory(store)
+	}
+
+	return svc, nil
+}
+
+// initHistory opens the weather history store, or returns nil when history
+// is disabled or the database is unreachable.
+func initHistory(cfg *config.Config) contracts.HistoryStore {
+	if !cfg.History.Enabled {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	store, err := history.OpenPostgresStore(ctx, cfg.History.DatabaseURL)
+	if err != nil {
+		log.Printf("Weather history database is unreachable, continuing without history: %v", err)
+		return nil
+	}
+	return store
+}
+
+// initProviderQuota creates the tracker of provider call budgets, or nil when
+// tracking is disabled. An unreachable Redis falls back to memory.
+func initProviderQuota(cfg *config.Config) *quota.Tracker {
+	if !cfg.ProviderQuota.Enabled {
+		return nil
+	}
+	var store quota.Store = quota.NewMemoryStore()
+	if cfg.ProviderQuota.Store == "redis" {
+		// Redis is only deployed alongside the cache.
+		if !cfg.Cache.Enabled {
+			log.Printf("Redis is disabled, provider call budgets are kept per replica")
+		} else if client, err := connectRedis(cfg); err != nil {
+			log.Printf("Redis is unreachable, provider call budgets are kept per replica: %v", err)
+		} else {
+			store = quota.NewRedisStore(client, "quota:")
+		}
+	}
+
+	metrics := quota.NewPrometheusMetrics()
+	metrics.Register()
+	return quota.NewTracker(store, metrics)
+}
+
+// InitCacheWarmer creates the warmer of subscribed cities, or nil when
+// warm-up or caching is disabled.
+func InitCacheWarmer(cfg *config.Config, weatherService weather_service.WeatherService) *warmup.Warmer {
+	if !cfg.Cache.Enabled || !cfg.Warmup.Enabled {
+		return nil
+	}
+	return warmup.NewWarmer(
+		client.NewSubscriptionClient(cfg.SubscriptionServiceURL),
+		weatherService,
+		warmup.Config{
+			Lead:        cfg.Warmup.Lead,
+			DailyHour:   cfg.Warmup.DailyHour,
+			Concurrency: cfg.Warmup.Concurrency,
+			CacheTTL:    cfg.Cache.Expiration,
+		},
+	)
+}
+
+// InitRateLimitStore creates the bucket store of the HTTP rate limiter, or nil
+// when rate limiting is disabled. An unreachable Redis falls back to memory.
+func InitRateLimitStore(cfg *config.Config) ratelimit.Store {
+	if !cfg.RateLimit.Enabled {
+		return nil
+	}
+	if cfg.RateLimit.Store != "redis" {
+		return ratelimit.NewMemoryStore()
+	}
+
+	client, err := connectRedis(cfg)
+	if err != nil {
+		log.Printf("Redis is unreachable, rate limits are kept per replica: %v", err)
+		return ratelimit.NewMemoryStore()
+	}
+	return ratelimit.NewRedisStore(client, "ratelimit:")
+}
 
-	return weather_service.NewWeatherService(weatherChain, redisCache, cfg.Cache.Expiration), nil
+// connectRedis opens a client of the configured Redis and checks that it is
+// reachable.
+func connectRedis(cfg *config.Config) (*redis.Client, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:         cfg.Cache.Redis.Addr,
+		Password:     cfg.Cache.Redis.Password,
+		DB:           cfg.Cache.Redis.DB,
+		PoolSize:     cfg.Cache.Redis.PoolSize,
+		DialTimeout:  cfg.Cache.Redis.Timeout,
+		ReadTimeout:  cfg.Cache.Redis.Timeout,
+		WriteTimeout: cfg.Cache.Redis.Timeout,
+		PoolTimeout:  cfg.Cache.Redis.Timeout,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Cache.Redis.Timeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// InitAuthenticator creates the API key authenticator of public endpoints, or
+// nil when authentication is disabled. Unlike other optional parts it fails
+// instead of degrading, so the API is never exposed without keys by mistake.
+func InitAuthenticator(cfg *config.Config) (*apikeys.Authenticator, error) {
+	if !cfg.Auth.Enabled {
+		return nil, nil
+	}
+	switch cfg.Auth.KeyStore {
+	case "file":
+		store, err := apikeys.LoadFileStore(cfg.Auth.KeysFile)
+		if err != nil {
+			return nil, err
+		}
+		return apikeys.NewAuthenticator(store), nil
+	case "postgres":
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		store, err := apikeys.OpenPostgresStore(ctx, cfg.Auth.DatabaseURL)
+		if err != nil {
+			return nil, err
+		}
+		return apikeys.NewAuthenticator(store), nil
+	default:
+		return nil, fmt.Errorf("unknown API_KEY_STORE %q", cfg.Auth.KeyStore)
+	}
+}
+
+// newProviderRegistry registers every known weather provider.
+func newProviderRegistry() *chain.ProviderRegistry {
+	registry := chain.NewProviderRegistry()
+	registry.Register("openweather", func(settings chain.ProviderSettings) (chain.WeatherAPIProvider, error) {
+		adapter, err := adapters.NewOpenWeatherAdapter(settings.APIKey)
+		if err != nil {
+			return nil, err
+		}
+		adapter.SetTimeout(settings.Timeout)
+		return &adapter, nil
+	})
+	registry.Register("weatherapi", func(settings chain.ProviderSettings) (chain.WeatherAPIProvider, error) {
+		adapter, err := adapters.NewWeatherAPIAdapter(settings.APIKey)
+		if err != nil {
+			return nil, err
+		}
+		adapter.SetTimeout(settings.Timeout)
+		return &adapter, nil
+	})
+	registry.Register("openmeteo", func(settings chain.ProviderSettings) (chain.WeatherAPIProvider, error) {
+		adapter := adapters.NewOpenMeteoAdapter()
+		adapter.SetTimeout(settings.Timeout)
+		return &adapter, nil
+	})
+	return registry
+}
+
+// withCircuitBreaker wraps a provider handler with a circuit breaker if enabled.
+func withCircuitBreaker(cfg *config.Config, handler chain.WeatherHandler, metrics chain.Metrics) chain.WeatherHandler {
+	if !cfg.CircuitBreaker.Enabled {
+		return handler
+	}
+	return chain.NewCircuitBreakerHandler(handler, chain.CircuitBreakerConfig{
+		FailureThreshold: cfg.CircuitBreaker.FailureThreshold,
+		SuccessThreshold: cfg.CircuitBreaker.SuccessThreshold,
+		CoolDown:         cfg.CircuitBreaker.CoolDown,
+	}, metrics)
 }
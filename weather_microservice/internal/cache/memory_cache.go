@@ -0,0 +1,280 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+)
+
+const (
+	// Default in-memory cache limits.
+	defaultMemoryMaxEntries = 1000
+	defaultMemoryTTL        = time.Minute
+)
+
+// MemoryCache is an in-process LRU cache with per-entry TTL.
+// It implements WeatherCache and ForecastCache and is safe for concurrent use.
+type MemoryCache struct {
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	lru        *list.List // front is the most recently used entry
+	maxEntries int
+	maxTTL     time.Duration
+	metrics    MemoryMetrics
+	now        func() time.Time
+}
+
+type memoryEntry struct {
+	key       string
+	value     any
+	expiresAt time.Time
+}
+
+// compile-time гарантія, що реалізує інтерфейс.
+var _ contracts.WeatherCache = (*MemoryCache)(nil)
+var _ contracts.ForecastCache = (*MemoryCache)(nil)
+var _ contracts.CacheAdmin = (*MemoryCache)(nil)
+var _ contracts.BatchWeatherCache = (*MemoryCache)(nil)
+
+// NewMemoryCache creates an in-memory cache holding at most maxEntries entries,
+// each for no longer than maxTTL.
+func NewMemoryCache(maxEntries int, maxTTL time.Duration, metrics MemoryMetrics) *MemoryCache {
+	if maxEntries <= 0 {
+		maxEntries = defaultMemoryMaxEntries
+	}
+	if maxTTL <= 0 {
+		maxTTL = defaultMemoryTTL
+	}
+	if metrics == nil {
+		metrics = NoopMetrics{}
+	}
+	return &MemoryCache{
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		maxEntries: maxEntries,
+		maxTTL:     maxTTL,
+		metrics:    metrics,
+		now:        time.Now,
+	}
+}
+
+// Get retrieves weather data from memory.
+func (m *MemoryCache) Get(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error) {
+	value, ok := m.get(weatherCachePrefix + loc.Key())
+	if !ok {
+		return contracts.WeatherData{}, fmt.Errorf("memory cache miss for location: %s", loc)
+	}
+	return value.(contracts.WeatherData), nil
+}
+
+// GetMany retrieves weather data of several locations from memory.
+func (m *MemoryCache) GetMany(ctx context.Context, locs []contracts.Location) (map[string]contracts.WeatherData, error) {
+	found := make(map[string]contracts.WeatherData, len(locs))
+	for _, loc := range locs {
+		if data, err := m.Get(ctx, loc); err == nil {
+			found[loc.Key()] = data
+		}
+	}
+	return found, nil
+}
+
+// Set stores weather data in memory. Expiration is capped by the cache TTL.
+func (m *MemoryCache) Set(ctx context.Context, loc contracts.Location, data contracts.WeatherData, expiration time.Duration) error {
+	m.set(weatherCachePrefix+loc.Key(), data, expiration)
+	return nil
+}
+
+// GetForecast retrieves forecast data from memory.
+func (m *MemoryCache) GetForecast(ctx context.Context, loc contracts.Location, days int) (contracts.ForecastData, error) {
+	value, ok := m.get(forecastKey(loc, days))
+	if !ok {
+		return contracts.ForecastData{}, fmt.Errorf("memory forecast cache miss for location: %s", loc)
+	}
+	return value.(contracts.ForecastData), nil
+}
+
+// SetForecast stores forecast data in memory. Expiration is capped by the cache TTL.
+func (m *MemoryCache) SetForecast(ctx context.Context, loc contracts.Location, days int, data contracts.ForecastData, expiration time.Duration) error {
+	m.set(forecastKey(loc, days), data, expiration)
+	return nil
+}
+
+// Delete removes weather data from memory.
+func (m *MemoryCache) Delete(ctx context.Context, loc contracts.Location) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if el, ok := m.entries[weatherCachePrefix+loc.Key()]; ok {
+		m.removeElement(el)
+	}
+	return nil
+}
+
+// Exists checks if unexpired weather data is in memory.
+func (m *MemoryCache) Exists(ctx context.Context, loc contracts.Location) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	el, ok := m.entries[weatherCachePrefix+loc.Key()]
+	return ok && m.now().Before(el.Value.(*memoryEntry).expiresAt), nil
+}
+
+// Health is always successful for the in-memory cache.
+func (m *MemoryCache) Health(ctx context.Context) error {
+	return nil
+}
+
+// Close drops all entries.
+func (m *MemoryCache) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = make(map[string]*list.Element)
+	m.lru.Init()
+	return nil
+}
+
+// GetStats returns in-memory cache statistics.
+func (m *MemoryCache) GetStats(ctx context.Context) (map[string]interface{}, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return map[string]interface{}{
+		"memory": map[string]interface{}{
+			"entries":     m.lru.Len(),
+			"max_entries": m.maxEntries,
+			"ttl_seconds": m.maxTTL.Seconds(),
+		},
+	}, nil
+}
+
+// Inspect returns the weather entry of a location with its remaining TTL.
+func (m *MemoryCache) Inspect(ctx context.Context, loc contracts.Location) (contracts.CacheEntry, bool, error) {
+	key := weatherCachePrefix + loc.Key()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	el, ok := m.entries[key]
+	if !ok {
+		return contracts.CacheEntry{}, false, nil
+	}
+	entry := el.Value.(*memoryEntry)
+	ttl := entry.expiresAt.Sub(m.now())
+	if ttl <= 0 {
+		return contracts.CacheEntry{}, false, nil
+	}
+	return contracts.CacheEntry{Key: key, Data: entry.value.(contracts.WeatherData), TTL: ttl}, true, nil
+}
+
+// InvalidateLocation removes weather and all forecasts of a location.
+func (m *MemoryCache) InvalidateLocation(ctx context.Context, loc contracts.Location) (int64, error) {
+	weatherKey := weatherCachePrefix + loc.Key()
+	forecastPrefix := forecastCachePrefix + loc.Key() + ":"
+	return m.removeIf(func(key string) bool {
+		return key == weatherKey || strings.HasPrefix(key, forecastPrefix)
+	}), nil
+}
+
+// InvalidatePattern removes entries whose location key matches the glob pattern.
+// The syntax is that of path.Match, which covers the Redis "*", "?" and "[...]" forms.
+func (m *MemoryCache) InvalidatePattern(ctx context.Context, pattern string) (int64, error) {
+	if strings.TrimSpace(pattern) == "" {
+		return 0, apierrors.ErrInvalidPattern
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return 0, fmt.Errorf("%w: %v", apierrors.ErrInvalidPattern, err)
+	}
+	return m.removeIf(func(key string) bool {
+		for _, prefix := range cachePrefixes {
+			if rest, ok := strings.CutPrefix(key, prefix); ok {
+				matched, _ := path.Match(pattern, rest)
+				return matched
+			}
+		}
+		return false
+	}), nil
+}
+
+// Flush drops all entries.
+func (m *MemoryCache) Flush(ctx context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	flushed := int64(m.lru.Len())
+	m.entries = make(map[string]*list.Element)
+	m.lru.Init()
+	return flushed, nil
+}
+
+// Len returns the number of entries, including expired ones not yet evicted.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lru.Len()
+}
+
+func (m *MemoryCache) get(key string) (any, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.entries[key]
+	if !ok {
+		m.metrics.IncMemoryMisses()
+		return nil, false
+	}
+	entry := el.Value.(*memoryEntry)
+	if !m.now().Before(entry.expiresAt) {
+		m.removeElement(el)
+		m.metrics.IncMemoryMisses()
+		return nil, false
+	}
+	m.lru.MoveToFront(el)
+	m.metrics.IncMemoryHits()
+	return entry.value, true
+}
+
+func (m *MemoryCache) set(key string, value any, expiration time.Duration) {
+	if expiration <= 0 || expiration > m.maxTTL {
+		expiration = m.maxTTL
+	}
+	expiresAt := m.now().Add(expiration)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.entries[key]; ok {
+		entry := el.Value.(*memoryEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		m.lru.MoveToFront(el)
+		return
+	}
+
+	m.entries[key] = m.lru.PushFront(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+	for m.lru.Len() > m.maxEntries {
+		m.removeElement(m.lru.Back())
+		m.metrics.IncMemoryEvictions()
+	}
+}
+
+// removeIf removes all entries whose key satisfies the predicate.
+func (m *MemoryCache) removeIf(match func(key string) bool) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var removed int64
+	for key, el := range m.entries {
+		if match(key) {
+			m.removeElement(el)
+			removed++
+		}
+	}
+	return removed
+}
+
+// removeElement must be called with mu held.
+func (m *MemoryCache) removeElement(el *list.Element) {
+	m.lru.Remove(el)
+	delete(m.entries, el.Value.(*memoryEntry).key)
+}
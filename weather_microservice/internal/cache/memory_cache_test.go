@@ -0,0 +1,140 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache_SetAndGet(t *testing.T) {
+	m := NewMemoryCache(10, time.Minute, nil)
+	ctx := context.Background()
+	data := contracts.WeatherData{Temperature: 21, Humidity: 40, Description: "Sunny"}
+
+	require.NoError(t, m.Set(ctx, contracts.CityLocation("Kyiv"), data, time.Minute))
+
+	got, err := m.Get(ctx, contracts.CityLocation(" KYIV "))
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+
+	_, err = m.Get(ctx, contracts.CityLocation("Lviv"))
+	assert.Error(t, err)
+}
+
+func TestMemoryCache_Expiration(t *testing.T) {
+	m := NewMemoryCache(10, time.Minute, nil)
+	now := time.Now()
+	m.now = func() time.Time { return now }
+	ctx := context.Background()
+	loc := contracts.CityLocation("Kyiv")
+
+	// Expiration longer than the cache TTL is capped.
+	require.NoError(t, m.Set(ctx, loc, contracts.WeatherData{Temperature: 1}, time.Hour))
+
+	now = now.Add(59 * time.Second)
+	_, err := m.Get(ctx, loc)
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Second)
+	_, err = m.Get(ctx, loc)
+	assert.Error(t, err)
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestMemoryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	m := NewMemoryCache(2, time.Minute, nil)
+	ctx := context.Background()
+
+	require.NoError(t, m.Set(ctx, contracts.CityLocation("Kyiv"), contracts.WeatherData{}, 0))
+	require.NoError(t, m.Set(ctx, contracts.CityLocation("Lviv"), contracts.WeatherData{}, 0))
+
+	// Touch Kyiv so Lviv becomes the least recently used entry.
+	_, err := m.Get(ctx, contracts.CityLocation("Kyiv"))
+	require.NoError(t, err)
+
+	require.NoError(t, m.Set(ctx, contracts.CityLocation("Odesa"), contracts.WeatherData{}, 0))
+
+	assert.Equal(t, 2, m.Len())
+	_, err = m.Get(ctx, contracts.CityLocation("Lviv"))
+	assert.Error(t, err)
+	_, err = m.Get(ctx, contracts.CityLocation("Kyiv"))
+	assert.NoError(t, err)
+	_, err = m.Get(ctx, contracts.CityLocation("Odesa"))
+	assert.NoError(t, err)
+}
+
+func TestMemoryCache_Forecast(t *testing.T) {
+	m := NewMemoryCache(10, time.Minute, nil)
+	ctx := context.Background()
+	loc := contracts.CityLocation("Kyiv")
+	forecast := contracts.ForecastData{City: "Kyiv", Daily: []contracts.DailyForecast{{Date: "2025-06-24"}}}
+
+	require.NoError(t, m.SetForecast(ctx, loc, 3, forecast, time.Minute))
+
+	got, err := m.GetForecast(ctx, loc, 3)
+	require.NoError(t, err)
+	assert.Equal(t, forecast, got)
+
+	_, err = m.GetForecast(ctx, loc, 2)
+	assert.Error(t, err)
+	_, err = m.Get(ctx, loc)
+	assert.Error(t, err, "forecast must not collide with current weather")
+}
+
+func TestMemoryCache_Delete(t *testing.T) {
+	m := NewMemoryCache(10, time.Minute, nil)
+	ctx := context.Background()
+	loc := contracts.CityLocation("Kyiv")
+
+	require.NoError(t, m.Set(ctx, loc, contracts.WeatherData{}, 0))
+	exists, _ := m.Exists(ctx, loc)
+	assert.True(t, exists)
+
+	require.NoError(t, m.Delete(ctx, loc))
+	exists, _ = m.Exists(ctx, loc)
+	assert.False(t, exists)
+}
+
+func TestMemoryCache_AdminOperations(t *testing.T) {
+	m := NewMemoryCache(10, time.Minute, nil)
+	now := time.Now()
+	m.now = func() time.Time { return now }
+	ctx := context.Background()
+	kyiv := contracts.CityLocation("Kyiv")
+	data := contracts.WeatherData{Temperature: 21}
+
+	require.NoError(t, m.Set(ctx, kyiv, data, 30*time.Second))
+	require.NoError(t, m.SetForecast(ctx, kyiv, 3, contracts.ForecastData{City: "Kyiv"}, 0))
+	require.NoError(t, m.Set(ctx, contracts.CityLocation("Kyivska"), data, 0))
+	require.NoError(t, m.Set(ctx, contracts.CityLocation("Lviv"), data, 0))
+
+	now = now.Add(10 * time.Second)
+	entry, ok, err := m.Inspect(ctx, kyiv)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, contracts.CacheEntry{Key: "weather:kyiv", Data: data, TTL: 20 * time.Second}, entry)
+
+	// Only exact location keys are removed, not other cities sharing the prefix.
+	deleted, err := m.InvalidateLocation(ctx, kyiv)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	assert.Equal(t, 2, m.Len())
+
+	deleted, err = m.InvalidatePattern(ctx, "kyiv*")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	_, err = m.InvalidatePattern(ctx, "[")
+	assert.ErrorIs(t, err, apierrors.ErrInvalidPattern)
+
+	deleted, err = m.Flush(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	assert.Equal(t, 0, m.Len())
+}
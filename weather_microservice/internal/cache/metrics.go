@@ -5,6 +5,16 @@ type Metrics interface {
 	IncCacheMisses()
 	IncCacheSets()
 	IncCacheDeletes()
+	IncNegativeHits()
+	IncNegativeMisses()
+	IncNegativeSets()
+}
+
+// MemoryMetrics збирає метрики in-memory рівня кешу.
+type MemoryMetrics interface {
+	IncMemoryHits()
+	IncMemoryMisses()
+	IncMemoryEvictions()
 }
 
 // NoopMetrics – пуста реалізація, якщо метрики не потрібні.
@@ -14,3 +24,11 @@ func (NoopMetrics) IncCacheHits()    {}
 func (NoopMetrics) IncCacheMisses()  {}
 func (NoopMetrics) IncCacheSets()    {}
 func (NoopMetrics) IncCacheDeletes() {}
+
+func (NoopMetrics) IncNegativeHits()   {}
+func (NoopMetrics) IncNegativeMisses() {}
+func (NoopMetrics) IncNegativeSets()   {}
+
+func (NoopMetrics) IncMemoryHits()      {}
+func (NoopMetrics) IncMemoryMisses()    {}
+func (NoopMetrics) IncMemoryEvictions() {}
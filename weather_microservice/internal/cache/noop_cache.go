@@ -12,22 +12,52 @@ import (
 type NoopWeatherCache struct{}
 
 // Get завжди повертає помилку кеш-місу.
-func (NoopWeatherCache) Get(ctx context.Context, city string) (contracts.WeatherData, error) {
-	return contracts.WeatherData{}, fmt.Errorf("noop cache miss for city: %s", city)
+func (NoopWeatherCache) Get(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error) {
+	return contracts.WeatherData{}, fmt.Errorf("noop cache miss for location: %s", loc)
+}
+
+// GetMany завжди повертає порожній результат.
+func (NoopWeatherCache) GetMany(ctx context.Context, locs []contracts.Location) (map[string]contracts.WeatherData, error) {
+	return map[string]contracts.WeatherData{}, nil
 }
 
 // Set нічого не зберігає.
-func (NoopWeatherCache) Set(ctx context.Context, city string, data contracts.WeatherData, expiration time.Duration) error {
+func (NoopWeatherCache) Set(ctx context.Context, loc contracts.Location, data contracts.WeatherData, expiration time.Duration) error {
+	return nil
+}
+
+// GetForecast завжди повертає помилку кеш-місу.
+func (NoopWeatherCache) GetForecast(ctx context.Context, loc contracts.Location, days int) (contracts.ForecastData, error) {
+	return contracts.ForecastData{}, fmt.Errorf("noop cache miss for forecast: %s", loc)
+}
+
+// SetForecast нічого не зберігає.
+func (NoopWeatherCache) SetForecast(ctx context.Context, loc contracts.Location, days int, data contracts.ForecastData, expiration time.Duration) error {
 	return nil
 }
 
+// IsNotFound завжди повертає false.
+func (NoopWeatherCache) IsNotFound(ctx context.Context, loc contracts.Location) (bool, error) {
+	return false, nil
+}
+
+// SetNotFound нічого не зберігає.
+func (NoopWeatherCache) SetNotFound(ctx context.Context, loc contracts.Location, expiration time.Duration) error {
+	return nil
+}
+
+// PurgeNotFound нічого не видаляє.
+func (NoopWeatherCache) PurgeNotFound(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
 // Delete нічого не видаляє.
-func (NoopWeatherCache) Delete(ctx context.Context, city string) error {
+func (NoopWeatherCache) Delete(ctx context.Context, loc contracts.Location) error {
 	return nil
 }
 
 // Exists завжди повертає false.
-func (NoopWeatherCache) Exists(ctx context.Context, city string) (bool, error) {
+func (NoopWeatherCache) Exists(ctx context.Context, loc contracts.Location) (bool, error) {
 	return false, nil
 }
 
@@ -45,3 +75,33 @@ func (NoopWeatherCache) Close() error {
 func (NoopWeatherCache) GetStats(ctx context.Context) (map[string]interface{}, error) {
 	return map[string]interface{}{}, nil
 }
+
+// Inspect завжди повідомляє, що запису немає.
+func (NoopWeatherCache) Inspect(ctx context.Context, loc contracts.Location) (contracts.CacheEntry, bool, error) {
+	return contracts.CacheEntry{}, false, nil
+}
+
+// InvalidateLocation нічого не видаляє.
+func (NoopWeatherCache) InvalidateLocation(ctx context.Context, loc contracts.Location) (int64, error) {
+	return 0, nil
+}
+
+// InvalidatePattern нічого не видаляє.
+func (NoopWeatherCache) InvalidatePattern(ctx context.Context, pattern string) (int64, error) {
+	return 0, nil
+}
+
+// Flush нічого не видаляє.
+func (NoopWeatherCache) Flush(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+// GetCities завжди повертає помилку кеш-місу.
+func (NoopWeatherCache) GetCities(ctx context.Context, query string, limit int) ([]contracts.City, error) {
+	return nil, fmt.Errorf("noop cache miss for cities: %s", query)
+}
+
+// SetCities нічого не зберігає.
+func (NoopWeatherCache) SetCities(ctx context.Context, query string, limit int, cities []contracts.City, expiration time.Duration) error {
+	return nil
+}
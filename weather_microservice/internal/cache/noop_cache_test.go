@@ -11,16 +11,17 @@ import (
 func TestNoopWeatherCache(t *testing.T) {
 	var c contracts.WeatherCache = NoopWeatherCache{}
 	ctx := context.Background()
+	kyiv := contracts.CityLocation("Kyiv")
 
 	t.Run("Get returns cache miss error", func(t *testing.T) {
-		_, err := c.Get(ctx, "Kyiv")
+		_, err := c.Get(ctx, kyiv)
 		if err == nil {
 			t.Error("expected error from Get, got nil")
 		}
 	})
 
 	t.Run("Set does nothing and returns nil", func(t *testing.T) {
-		err := c.Set(ctx, "Kyiv", contracts.WeatherData{
+		err := c.Set(ctx, kyiv, contracts.WeatherData{
 			Temperature: 20,
 			Humidity:    50,
 			Description: "Sunny",
@@ -31,14 +32,14 @@ func TestNoopWeatherCache(t *testing.T) {
 	})
 
 	t.Run("Delete returns nil", func(t *testing.T) {
-		err := c.Delete(ctx, "Kyiv")
+		err := c.Delete(ctx, kyiv)
 		if err != nil {
 			t.Errorf("expected nil from Delete, got: %v", err)
 		}
 	})
 
 	t.Run("Exists always returns false", func(t *testing.T) {
-		exists, err := c.Exists(ctx, "Kyiv")
+		exists, err := c.Exists(ctx, kyiv)
 		if err != nil {
 			t.Errorf("expected nil from Exists, got: %v", err)
 		}
@@ -7,6 +7,14 @@ type PrometheusMetrics struct {
 	cacheMisses  prometheus.Counter
 	cacheSets    prometheus.Counter
 	cacheDeletes prometheus.Counter
+
+	negativeHits   prometheus.Counter
+	negativeMisses prometheus.Counter
+	negativeSets   prometheus.Counter
+
+	memoryHits      prometheus.Counter
+	memoryMisses    prometheus.Counter
+	memoryEvictions prometheus.Counter
 }
 
 func NewPrometheusMetrics() *PrometheusMetrics {
@@ -27,6 +35,30 @@ func NewPrometheusMetrics() *PrometheusMetrics {
 			Name: "weather_cache_deletes_total",
 			Help: "Total number of cache deletes",
 		}),
+		negativeHits: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "weather_cache_negative_hits_total",
+			Help: "Total number of lookups answered from the unknown city cache",
+		}),
+		negativeMisses: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "weather_cache_negative_misses_total",
+			Help: "Total number of unknown city cache misses",
+		}),
+		negativeSets: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "weather_cache_negative_sets_total",
+			Help: "Total number of unknown cities stored in cache",
+		}),
+		memoryHits: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "weather_cache_memory_hits_total",
+			Help: "Total number of in-memory cache hits",
+		}),
+		memoryMisses: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "weather_cache_memory_misses_total",
+			Help: "Total number of in-memory cache misses",
+		}),
+		memoryEvictions: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "weather_cache_memory_evictions_total",
+			Help: "Total number of entries evicted from the in-memory cache to respect its size limit",
+		}),
 	}
 }
 
@@ -36,6 +68,12 @@ func (m *PrometheusMetrics) Register() {
 		m.cacheMisses,
 		m.cacheSets,
 		m.cacheDeletes,
+		m.negativeHits,
+		m.negativeMisses,
+		m.negativeSets,
+		m.memoryHits,
+		m.memoryMisses,
+		m.memoryEvictions,
 	)
 }
 
@@ -43,3 +81,11 @@ func (m *PrometheusMetrics) IncCacheHits()    { m.cacheHits.Inc() }
 func (m *PrometheusMetrics) IncCacheMisses()  { m.cacheMisses.Inc() }
 func (m *PrometheusMetrics) IncCacheSets()    { m.cacheSets.Inc() }
 func (m *PrometheusMetrics) IncCacheDeletes() { m.cacheDeletes.Inc() }
+
+func (m *PrometheusMetrics) IncNegativeHits()   { m.negativeHits.Inc() }
+func (m *PrometheusMetrics) IncNegativeMisses() { m.negativeMisses.Inc() }
+func (m *PrometheusMetrics) IncNegativeSets()   { m.negativeSets.Inc() }
+
+func (m *PrometheusMetrics) IncMemoryHits()      { m.memoryHits.Inc() }
+func (m *PrometheusMetrics) IncMemoryMisses()    { m.memoryMisses.Inc() }
+func (m *PrometheusMetrics) IncMemoryEvictions() { m.memoryEvictions.Inc() }
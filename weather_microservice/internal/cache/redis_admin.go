@@ -0,0 +1,175 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// cachePrefixes lists all key prefixes owned by the weather cache.
+var cachePrefixes = []string{weatherCachePrefix, forecastCachePrefix, notFoundCachePrefix, citiesCachePrefix}
+
+// compile-time гарантія, що реалізує інтерфейс.
+var _ contracts.CacheAdmin = (*RedisCache)(nil)
+
+// Inspect returns the cached weather entry for a location together with its remaining TTL.
+func (r *RedisCache) Inspect(ctx context.Context, loc contracts.Location) (contracts.CacheEntry, bool, error) {
+	if !r.isEnabled() {
+		return contracts.CacheEntry{}, false, nil
+	}
+
+	key := r.generateCacheKey(loc)
+	result, err := r.client.Get(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return contracts.CacheEntry{}, false, nil
+		}
+		return contracts.CacheEntry{}, false, fmt.Errorf("failed to get from cache: %w", err)
+	}
+
+	var data contracts.WeatherData
+	if err := json.Unmarshal([]byte(result), &data); err != nil {
+		return contracts.CacheEntry{}, false, fmt.Errorf("failed to unmarshal cached data: %w", err)
+	}
+
+	ttl, err := r.client.TTL(ctx, key).Result()
+	if err != nil {
+		return contracts.CacheEntry{}, false, fmt.Errorf("failed to get cache entry TTL: %w", err)
+	}
+	// Redis reports -1 for keys without expiration and -2 for missing keys.
+	if ttl < 0 {
+		ttl = 0
+	}
+
+	return contracts.CacheEntry{Key: key, Data: data, TTL: ttl}, true, nil
+}
+
+// InvalidateLocation removes weather, all forecasts and the not found marker of a location.
+func (r *RedisCache) InvalidateLocation(ctx context.Context, loc contracts.Location) (int64, error) {
+	if !r.isEnabled() {
+		return 0, nil
+	}
+
+	deleted, err := r.client.Del(ctx, r.generateCacheKey(loc), notFoundCachePrefix+loc.Key()).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to invalidate location: %w", err)
+	}
+	forecasts, err := r.deleteMatching(ctx, forecastCachePrefix+escapeGlob(loc.Key())+":*")
+	deleted += forecasts
+	if err != nil {
+		return deleted, fmt.Errorf("failed to invalidate location forecasts: %w", err)
+	}
+	return deleted, nil
+}
+
+// InvalidatePattern removes all entries whose location key matches the Redis glob pattern,
+// e.g. "kyiv*" or "coord:50.4*". Forecast keys carry a ":<days>" suffix.
+func (r *RedisCache) InvalidatePattern(ctx context.Context, pattern string) (int64, error) {
+	if strings.TrimSpace(pattern) == "" {
+		return 0, apierrors.ErrInvalidPattern
+	}
+	if !r.isEnabled() {
+		return 0, nil
+	}
+	return r.deleteWithPrefixes(ctx, pattern)
+}
+
+// Flush removes every weather cache entry. Other keys in the Redis database are kept.
+func (r *RedisCache) Flush(ctx context.Context) (int64, error) {
+	if !r.isEnabled() {
+		return 0, nil
+	}
+	return r.deleteWithPrefixes(ctx, "*")
+}
+
+// deleteWithPrefixes removes keys matching the pattern under every cache prefix.
+func (r *RedisCache) deleteWithPrefixes(ctx context.Context, pattern string) (int64, error) {
+	var deleted int64
+	for _, prefix := range cachePrefixes {
+		n, err := r.deleteMatching(ctx, prefix+pattern)
+		deleted += n
+		if err != nil {
+			return deleted, fmt.Errorf("failed to invalidate cache: %w", err)
+		}
+	}
+	return deleted, nil
+}
+
+// deleteMatching removes all keys matching the glob using SCAN, so Redis is not blocked
+// the way KEYS would block it.
+func (r *RedisCache) deleteMatching(ctx context.Context, match string) (int64, error) {
+	var deleted int64
+	var cursor uint64
+	for {
+		keys, next, err := r.client.Scan(ctx, cursor, match, scanBatchSize).Result()
+		if err != nil {
+			return deleted, fmt.Errorf("failed to scan keys: %w", err)
+		}
+		if len(keys) > 0 {
+			n, err := r.client.Del(ctx, keys...).Result()
+			if err != nil {
+				return deleted, fmt.Errorf("failed to delete keys: %w", err)
+			}
+			deleted += n
+		}
+		if next == 0 {
+			return deleted, nil
+		}
+		cursor = next
+	}
+}
+
+// escapeGlob escapes Redis glob special characters in a literal key part.
+func escapeGlob(s string) string {
+	var b strings.Builder
+	for _, c := range s {
+		switch c {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// parseRedisInfo converts the INFO output into {section: {field: value}}.
+// Numeric values are returned as numbers, everything else as strings.
+func parseRedisInfo(info string) map[string]interface{} {
+	sections := make(map[string]interface{})
+	var current map[string]interface{}
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			current = make(map[string]interface{})
+			sections[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(line, "#")))] = current
+			continue
+		}
+		field, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		if current == nil {
+			current = make(map[string]interface{})
+			sections["default"] = current
+		}
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			current[field] = n
+		} else if f, err := strconv.ParseFloat(value, 64); err == nil {
+			current[field] = f
+		} else {
+			current[field] = value
+		}
+	}
+	return sections
+}
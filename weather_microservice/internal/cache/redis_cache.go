@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"strings"
 	"time"
 
 	"weather_microservice/internal/contracts"
@@ -17,6 +16,12 @@ import (
 const (
 	// Cache key prefix for weather data.
 	weatherCachePrefix = "weather:"
+	// Cache key prefix for forecast data.
+	forecastCachePrefix = "forecast:"
+	// Cache key prefix for locations no provider knows.
+	notFoundCachePrefix = "notfound:"
+	// Number of keys requested per SCAN iteration.
+	scanBatchSize = 100
 	// Default Redis configuration.
 	defaultRedisDB       = 0
 	defaultRedisPoolSize = 10
@@ -25,9 +30,12 @@ const (
 
 type RedisClient interface {
 	Get(ctx context.Context, key string) *redis.StringCmd
+	MGet(ctx context.Context, keys ...string) *redis.SliceCmd
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
 	Del(ctx context.Context, keys ...string) *redis.IntCmd
 	Exists(ctx context.Context, keys ...string) *redis.IntCmd
+	Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd
+	TTL(ctx context.Context, key string) *redis.DurationCmd
 	Ping(ctx context.Context) *redis.StatusCmd
 	Close() error
 	Info(ctx context.Context, section ...string) *redis.StringCmd
@@ -49,6 +57,9 @@ type RedisCache struct {
 
 // compile-time гарантія, що реалізує інтерфейс.
 var _ contracts.WeatherCache = (*RedisCache)(nil)
+var _ contracts.ForecastCache = (*RedisCache)(nil)
+var _ contracts.NegativeCache = (*RedisCache)(nil)
+var _ contracts.BatchWeatherCache = (*RedisCache)(nil)
 
 // RedisConfig holds Redis connection configuration.
 type RedisConfig struct {
@@ -111,23 +122,33 @@ func (r *RedisCache) isEnabled() bool {
 	return r.config.IsEnabled
 }
 
-// generateCacheKey creates a cache key for a city.
-func (r *RedisCache) generateCacheKey(city string) string {
-	// Normalize city name (lowercase, trim spaces).
-	normalizedCity := strings.ToLower(strings.TrimSpace(city))
-	return weatherCachePrefix + normalizedCity
+// generateCacheKey creates a cache key for a location.
+// City names are normalized (lowercase, trimmed), coordinates are rounded,
+// see contracts.Location.Key.
+func (r *RedisCache) generateCacheKey(loc contracts.Location) string {
+	return weatherCachePrefix + loc.Key()
+}
+
+// generateForecastCacheKey creates a cache key for a location forecast.
+func (r *RedisCache) generateForecastCacheKey(loc contracts.Location, days int) string {
+	return forecastKey(loc, days)
+}
+
+// forecastKey builds the forecast cache key shared by all cache tiers.
+func forecastKey(loc contracts.Location, days int) string {
+	return fmt.Sprintf("%s%s:%d", forecastCachePrefix, loc.Key(), days)
 }
 
 // Get retrieves weather data from Redis cache.
-func (r *RedisCache) Get(ctx context.Context, city string) (contracts.WeatherData, error) {
+func (r *RedisCache) Get(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error) {
 
 	// Return cache miss immediately if caching is disabled.
 	if !r.isEnabled() {
 		r.metrics.IncCacheMisses()
-		return contracts.WeatherData{}, fmt.Errorf("cache disabled for city: %s", city)
+		return contracts.WeatherData{}, fmt.Errorf("cache disabled for location: %s", loc)
 	}
 
-	key := r.generateCacheKey(city)
+	key := r.generateCacheKey(loc)
 
 	// Get data from Redis
 	result, err := r.client.Get(ctx, key).Result()
@@ -135,7 +156,7 @@ func (r *RedisCache) Get(ctx context.Context, city string) (contracts.WeatherDat
 		if errors.Is(err, redis.Nil) {
 			// Cache miss - return empty data with no error.
 			r.metrics.IncCacheMisses()
-			return contracts.WeatherData{}, fmt.Errorf("cache miss for city: %s", city)
+			return contracts.WeatherData{}, fmt.Errorf("cache miss for location: %s", loc)
 		}
 		return contracts.WeatherData{}, fmt.Errorf("failed to get from cache: %w", err)
 	}
@@ -149,13 +170,47 @@ func (r *RedisCache) Get(ctx context.Context, city string) (contracts.WeatherDat
 	return data, nil
 }
 
+// GetMany retrieves weather data of several locations with a single MGET.
+// Entries that are missing or cannot be decoded are left out.
+func (r *RedisCache) GetMany(ctx context.Context, locs []contracts.Location) (map[string]contracts.WeatherData, error) {
+	found := make(map[string]contracts.WeatherData, len(locs))
+	if !r.isEnabled() || len(locs) == 0 {
+		return found, nil
+	}
+
+	keys := make([]string, len(locs))
+	for i, loc := range locs {
+		keys[i] = r.generateCacheKey(loc)
+	}
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get from cache: %w", err)
+	}
+
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			r.metrics.IncCacheMisses()
+			continue
+		}
+		var data contracts.WeatherData
+		if err := json.Unmarshal([]byte(raw), &data); err != nil {
+			log.Printf("Skipping corrupted cache entry %s: %v", keys[i], err)
+			continue
+		}
+		r.metrics.IncCacheHits()
+		found[locs[i].Key()] = data
+	}
+	return found, nil
+}
+
 // Set stores weather data in Redis cache with expiration.
-func (r *RedisCache) Set(ctx context.Context, city string, data contracts.WeatherData, expiration time.Duration) error {
+func (r *RedisCache) Set(ctx context.Context, loc contracts.Location, data contracts.WeatherData, expiration time.Duration) error {
 	// Skip setting if caching is disabled.
 	if !r.isEnabled() {
 		return nil
 	}
-	key := r.generateCacheKey(city)
+	key := r.generateCacheKey(loc)
 
 	// Serialize data to JSON
 	jsonData, err := json.Marshal(data)
@@ -176,13 +231,112 @@ func (r *RedisCache) Set(ctx context.Context, city string, data contracts.Weathe
 	return nil
 }
 
+// GetForecast retrieves forecast data from Redis cache.
+func (r *RedisCache) GetForecast(ctx context.Context, loc contracts.Location, days int) (contracts.ForecastData, error) {
+	if !r.isEnabled() {
+		r.metrics.IncCacheMisses()
+		return contracts.ForecastData{}, fmt.Errorf("cache disabled for location: %s", loc)
+	}
+
+	key := r.generateForecastCacheKey(loc, days)
+
+	result, err := r.client.Get(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			r.metrics.IncCacheMisses()
+			return contracts.ForecastData{}, fmt.Errorf("forecast cache miss for location: %s", loc)
+		}
+		return contracts.ForecastData{}, fmt.Errorf("failed to get forecast from cache: %w", err)
+	}
+	r.metrics.IncCacheHits()
+
+	var data contracts.ForecastData
+	if err := json.Unmarshal([]byte(result), &data); err != nil {
+		return contracts.ForecastData{}, fmt.Errorf("failed to unmarshal cached forecast: %w", err)
+	}
+
+	return data, nil
+}
+
+// SetForecast stores forecast data in Redis cache with expiration.
+func (r *RedisCache) SetForecast(ctx context.Context, loc contracts.Location, days int, data contracts.ForecastData, expiration time.Duration) error {
+	if !r.isEnabled() {
+		return nil
+	}
+	key := r.generateForecastCacheKey(loc, days)
+
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal forecast data: %w", err)
+	}
+
+	if expiration <= 0 {
+		expiration = r.config.DefaultExpiration
+	}
+
+	if err := r.client.Set(ctx, key, jsonData, expiration).Err(); err != nil {
+		return fmt.Errorf("failed to set forecast cache: %w", err)
+	}
+	r.metrics.IncCacheSets()
+	return nil
+}
+
+// IsNotFound checks if the location is cached as unknown to every provider.
+func (r *RedisCache) IsNotFound(ctx context.Context, loc contracts.Location) (bool, error) {
+	if !r.isEnabled() {
+		return false, nil
+	}
+	key := notFoundCachePrefix + loc.Key()
+
+	count, err := r.client.Exists(ctx, key).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check not found cache: %w", err)
+	}
+	if count == 0 {
+		r.metrics.IncNegativeMisses()
+		return false, nil
+	}
+	r.metrics.IncNegativeHits()
+	return true, nil
+}
+
+// SetNotFound caches the location as unknown to every provider.
+func (r *RedisCache) SetNotFound(ctx context.Context, loc contracts.Location, expiration time.Duration) error {
+	if !r.isEnabled() {
+		return nil
+	}
+	key := notFoundCachePrefix + loc.Key()
+
+	if expiration <= 0 {
+		expiration = r.config.DefaultExpiration
+	}
+
+	if err := r.client.Set(ctx, key, "1", expiration).Err(); err != nil {
+		return fmt.Errorf("failed to set not found cache: %w", err)
+	}
+	r.metrics.IncNegativeSets()
+	return nil
+}
+
+// PurgeNotFound removes all cached unknown locations and returns how many were removed.
+func (r *RedisCache) PurgeNotFound(ctx context.Context) (int64, error) {
+	if !r.isEnabled() {
+		return 0, nil
+	}
+	purged, err := r.deleteMatching(ctx, notFoundCachePrefix+"*")
+	if err != nil {
+		return purged, fmt.Errorf("failed to purge not found cache: %w", err)
+	}
+	return purged, nil
+}
+
 // Delete removes weather data from Redis cache.
-func (r *RedisCache) Delete(ctx context.Context, city string) error {
+func (r *RedisCache) Delete(ctx context.Context, loc contracts.Location) error {
 	// Skip deletion if caching is disabled.
 	if !r.isEnabled() {
 		return nil // Silent skip, no error.
 	}
-	key := r.generateCacheKey(city)
+	key := r.generateCacheKey(loc)
 
 	if err := r.client.Del(ctx, key).Err(); err != nil {
 		return fmt.Errorf("failed to delete from cache: %w", err)
@@ -192,12 +346,12 @@ func (r *RedisCache) Delete(ctx context.Context, city string) error {
 }
 
 // Exists checks if weather data exists in Redis cache.
-func (r *RedisCache) Exists(ctx context.Context, city string) (bool, error) {
+func (r *RedisCache) Exists(ctx context.Context, loc contracts.Location) (bool, error) {
 	// Return false if caching is disabled
 	if !r.isEnabled() {
 		return false, nil
 	}
-	key := r.generateCacheKey(city)
+	key := r.generateCacheKey(loc)
 
 	count, err := r.client.Exists(ctx, key).Result()
 	if err != nil {
@@ -238,13 +392,16 @@ func (r *RedisCache) GetStats(ctx context.Context) (map[string]interface{}, erro
 	poolStats := r.client.PoolStats()
 
 	stats := map[string]interface{}{
-		"redis_info":    info,
-		"pool_hits":     poolStats.Hits,
-		"pool_misses":   poolStats.Misses,
-		"pool_timeouts": poolStats.Timeouts,
-		"pool_total":    poolStats.TotalConns,
-		"pool_idle":     poolStats.IdleConns,
-		"pool_stale":    poolStats.StaleConns,
+		"cache_enabled": true,
+		"redis":         parseRedisInfo(info),
+		"pool": map[string]interface{}{
+			"hits":     poolStats.Hits,
+			"misses":   poolStats.Misses,
+			"timeouts": poolStats.Timeouts,
+			"total":    poolStats.TotalConns,
+			"idle":     poolStats.IdleConns,
+			"stale":    poolStats.StaleConns,
+		},
 	}
 
 	return stats, nil
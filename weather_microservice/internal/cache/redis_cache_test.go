@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"weather_microservice/internal/apierrors"
 	"weather_microservice/internal/contracts"
 
 	"github.com/redis/go-redis/v9"
@@ -24,6 +25,11 @@ func (m *MockRedis) Get(ctx context.Context, key string) *redis.StringCmd {
 	return redis.NewStringResult(args.String(0), args.Error(1))
 }
 
+func (m *MockRedis) MGet(ctx context.Context, keys ...string) *redis.SliceCmd {
+	args := m.Called(ctx, keys)
+	return redis.NewSliceResult(args.Get(0).([]interface{}), args.Error(1))
+}
+
 func (m *MockRedis) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
 	args := m.Called(ctx, key, value, expiration)
 	return redis.NewStatusResult("", args.Error(0))
@@ -39,6 +45,16 @@ func (m *MockRedis) Exists(ctx context.Context, keys ...string) *redis.IntCmd {
 	return redis.NewIntResult(int64(args.Int(0)), args.Error(1))
 }
 
+func (m *MockRedis) Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd {
+	args := m.Called(ctx, cursor, match, count)
+	return redis.NewScanCmdResult(args.Get(0).([]string), args.Get(1).(uint64), args.Error(2))
+}
+
+func (m *MockRedis) TTL(ctx context.Context, key string) *redis.DurationCmd {
+	args := m.Called(ctx, key)
+	return redis.NewDurationResult(args.Get(0).(time.Duration), args.Error(1))
+}
+
 func (m *MockRedis) Ping(ctx context.Context) *redis.StatusCmd {
 	args := m.Called(ctx)
 	return redis.NewStatusResult("", args.Error(0))
@@ -72,7 +88,7 @@ func TestRedisCache_SetAndGet(t *testing.T) {
 		metrics: NoopMetrics{},
 	}
 
-	city := "Kyiv"
+	city := contracts.CityLocation("Kyiv")
 	data := contracts.WeatherData{
 		Temperature: 22.5,
 		Humidity:    65,
@@ -103,6 +119,148 @@ func TestRedisCache_SetAndGet(t *testing.T) {
 	assert.Equal(t, data, got)
 }
 
+// Entries written before the extended weather model must still decode, and
+// the new fields must survive a round trip through the cache.
+func TestRedisCache_WeatherDataCompatibility(t *testing.T) {
+	mockRedis := new(MockRedis)
+	cache := &RedisCache{
+		client:  mockRedis,
+		config:  CacheConfig{IsEnabled: true, DefaultExpiration: 10 * time.Minute},
+		metrics: NoopMetrics{},
+	}
+
+	mockRedis.On("Get", mock.Anything, "weather:kyiv").
+		Return(`{"temperature":22.5,"humidity":65,"description":"Cloudy"}`, nil)
+	got, err := cache.Get(context.Background(), contracts.CityLocation("Kyiv"))
+	assert.NoError(t, err)
+	assert.Equal(t, contracts.WeatherData{Temperature: 22.5, Humidity: 65, Description: "Cloudy"}, got)
+
+	uv := 3.0
+	data := contracts.WeatherData{
+		Temperature:   18,
+		WindSpeed:     4.5,
+		WindDirection: 270,
+		Pressure:      1013,
+		UVIndex:       &uv,
+		Sunrise:       time.Date(2024, 6, 3, 1, 47, 0, 0, time.UTC),
+		ObservedAt:    time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC),
+	}
+	jsonData, _ := json.Marshal(data)
+	mockRedis.On("Get", mock.Anything, "weather:lviv").Return(string(jsonData), nil)
+
+	got, err = cache.Get(context.Background(), contracts.CityLocation("Lviv"))
+	assert.NoError(t, err)
+	assert.Equal(t, data, got)
+}
+
+func TestRedisCache_GetMany(t *testing.T) {
+	mockRedis := new(MockRedis)
+	cache := &RedisCache{
+		client:  mockRedis,
+		config:  CacheConfig{IsEnabled: true},
+		metrics: NoopMetrics{},
+	}
+
+	kyiv := contracts.WeatherData{Temperature: 20, Description: "Clear"}
+	jsonData, _ := json.Marshal(kyiv)
+	mockRedis.On("MGet", mock.Anything, []string{"weather:kyiv", "weather:lviv", "weather:odesa"}).
+		Return([]interface{}{string(jsonData), nil, "not json"}, nil)
+
+	got, err := cache.GetMany(context.Background(), []contracts.Location{
+		contracts.CityLocation("Kyiv"),
+		contracts.CityLocation("Lviv"),
+		contracts.CityLocation("Odesa"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]contracts.WeatherData{"kyiv": kyiv}, got)
+	mockRedis.AssertExpectations(t)
+}
+
+func TestRedisCache_SetAndGetForecast(t *testing.T) {
+	mockRedis := new(MockRedis)
+	cache := &RedisCache{
+		client: mockRedis,
+		config: CacheConfig{
+			IsEnabled:         true,
+			DefaultExpiration: 10 * time.Minute,
+		},
+		metrics: NoopMetrics{},
+	}
+
+	data := contracts.ForecastData{
+		City: "Kyiv",
+		Daily: []contracts.DailyForecast{
+			{Date: "2024-06-15", MinTemperature: 14, MaxTemperature: 25, Humidity: 55, Description: "Sunny"},
+		},
+	}
+
+	jsonData, _ := json.Marshal(data)
+	key := "forecast:kyiv:3"
+
+	mockRedis.On("Set", mock.Anything, key, mock.Anything, 30*time.Minute).Return(nil)
+
+	err := cache.SetForecast(context.Background(), contracts.CityLocation(" Kyiv "), 3, data, 30*time.Minute)
+	assert.NoError(t, err)
+
+	mockRedis.On("Get", mock.Anything, key).Return(string(jsonData), nil)
+
+	got, err := cache.GetForecast(context.Background(), contracts.CityLocation("Kyiv"), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, data, got)
+}
+
+func TestRedisCache_SetAndGetCities(t *testing.T) {
+	mockRedis := new(MockRedis)
+	cache := &RedisCache{
+		client: mockRedis,
+		config: CacheConfig{
+			IsEnabled:         true,
+			DefaultExpiration: 10 * time.Minute,
+		},
+		metrics: NoopMetrics{},
+	}
+
+	cities := []contracts.City{{ID: "kyiv,ua", Name: "Kyiv", CountryCode: "UA", Lat: 50.45, Lon: 30.52}}
+	jsonData, _ := json.Marshal(cities)
+	key := "cities:kyiv:5"
+
+	mockRedis.On("Set", mock.Anything, key, mock.Anything, 24*time.Hour).Return(nil)
+
+	err := cache.SetCities(context.Background(), " Kyiv ", 5, cities, 24*time.Hour)
+	assert.NoError(t, err)
+
+	mockRedis.On("Get", mock.Anything, key).Return(string(jsonData), nil)
+	mockRedis.On("Get", mock.Anything, "cities:lviv:5").Return("", redis.Nil)
+
+	got, err := cache.GetCities(context.Background(), "KYIV", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, cities, got)
+
+	_, err = cache.GetCities(context.Background(), "Lviv", 5)
+	assert.ErrorIs(t, err, apierrors.ErrCacheMiss)
+}
+
+func TestRedisCache_GenerateCacheKey(t *testing.T) {
+	cache := &RedisCache{}
+
+	tests := []struct {
+		name     string
+		location contracts.Location
+		expected string
+	}{
+		{"city is normalized", contracts.CityLocation("  Kyiv "), "weather:kyiv"},
+		{"country narrows city", contracts.Location{City: "Paris", CountryCode: "US"}, "weather:paris,us"},
+		{"coordinates are rounded", contracts.CoordinatesLocation(48.856613, 2.352222), "weather:coord:48.8566,2.3522"},
+		{"coordinates win over city", contracts.Location{City: "Paris", Coordinates: &contracts.Coordinates{Lat: 1, Lon: 2}}, "weather:coord:1.0000,2.0000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, cache.generateCacheKey(tt.location))
+		})
+	}
+}
+
 func TestRedisCache_Get_NotFound(t *testing.T) {
 	mockRedis := new(MockRedis)
 	cache := &RedisCache{
@@ -116,7 +274,7 @@ func TestRedisCache_Get_NotFound(t *testing.T) {
 	key := "weather:unknown"
 	mockRedis.On("Get", mock.Anything, key).Return("", redis.Nil)
 
-	_, err := cache.Get(context.Background(), "unknown")
+	_, err := cache.Get(context.Background(), contracts.CityLocation("unknown"))
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "cache miss")
 }
@@ -134,7 +292,7 @@ func TestRedisCache_Exists(t *testing.T) {
 	key := "weather:kyiv"
 	mockRedis.On("Exists", mock.Anything, []string{key}).Return(1, nil)
 
-	exists, err := cache.Exists(context.Background(), "Kyiv")
+	exists, err := cache.Exists(context.Background(), contracts.CityLocation("Kyiv"))
 	assert.NoError(t, err)
 	assert.True(t, exists)
 }
@@ -150,7 +308,7 @@ func TestRedisCache_Delete(t *testing.T) {
 	key := "weather:kyiv"
 	mockRedis.On("Del", mock.Anything, []string{key}).Return(1, nil)
 
-	err := cache.Delete(context.Background(), "Kyiv")
+	err := cache.Delete(context.Background(), contracts.CityLocation("Kyiv"))
 	assert.NoError(t, err)
 }
 
@@ -167,3 +325,168 @@ func TestRedisCache_Health(t *testing.T) {
 	err := cache.Health(context.Background())
 	assert.NoError(t, err)
 }
+
+func TestRedisCache_NotFound(t *testing.T) {
+	mockRedis := new(MockRedis)
+	cache := &RedisCache{
+		client: mockRedis,
+		config: CacheConfig{
+			IsEnabled:         true,
+			DefaultExpiration: 10 * time.Minute,
+		},
+		metrics: NoopMetrics{},
+	}
+
+	key := "notfound:atlantis"
+	mockRedis.On("Set", mock.Anything, key, "1", 5*time.Minute).Return(nil)
+	mockRedis.On("Exists", mock.Anything, []string{key}).Return(1, nil)
+	mockRedis.On("Exists", mock.Anything, []string{"notfound:kyiv"}).Return(0, nil)
+
+	err := cache.SetNotFound(context.Background(), contracts.CityLocation(" Atlantis "), 5*time.Minute)
+	assert.NoError(t, err)
+
+	notFound, err := cache.IsNotFound(context.Background(), contracts.CityLocation("Atlantis"))
+	assert.NoError(t, err)
+	assert.True(t, notFound)
+
+	notFound, err = cache.IsNotFound(context.Background(), contracts.CityLocation("Kyiv"))
+	assert.NoError(t, err)
+	assert.False(t, notFound)
+}
+
+func TestRedisCache_PurgeNotFound(t *testing.T) {
+	mockRedis := new(MockRedis)
+	cache := &RedisCache{
+		client:  mockRedis,
+		config:  CacheConfig{IsEnabled: true},
+		metrics: NoopMetrics{},
+	}
+
+	mockRedis.On("Scan", mock.Anything, uint64(0), "notfound:*", int64(scanBatchSize)).
+		Return([]string{"notfound:a", "notfound:b"}, uint64(7), nil)
+	mockRedis.On("Scan", mock.Anything, uint64(7), "notfound:*", int64(scanBatchSize)).
+		Return([]string{"notfound:c"}, uint64(0), nil)
+	mockRedis.On("Del", mock.Anything, []string{"notfound:a", "notfound:b"}).Return(2, nil)
+	mockRedis.On("Del", mock.Anything, []string{"notfound:c"}).Return(1, nil)
+
+	purged, err := cache.PurgeNotFound(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+	mockRedis.AssertExpectations(t)
+}
+
+func TestRedisCache_Inspect(t *testing.T) {
+	mockRedis := new(MockRedis)
+	cache := &RedisCache{
+		client:  mockRedis,
+		config:  CacheConfig{IsEnabled: true},
+		metrics: NoopMetrics{},
+	}
+	data := contracts.WeatherData{Temperature: 20, Humidity: 50, Description: "Clear"}
+	raw, _ := json.Marshal(data)
+
+	mockRedis.On("Get", mock.Anything, "weather:kyiv").Return(string(raw), nil)
+	mockRedis.On("TTL", mock.Anything, "weather:kyiv").Return(90*time.Second, nil)
+	mockRedis.On("Get", mock.Anything, "weather:lviv").Return("", redis.Nil)
+
+	entry, ok, err := cache.Inspect(context.Background(), contracts.CityLocation("Kyiv"))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, contracts.CacheEntry{Key: "weather:kyiv", Data: data, TTL: 90 * time.Second}, entry)
+
+	_, ok, err = cache.Inspect(context.Background(), contracts.CityLocation("Lviv"))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	mockRedis.AssertExpectations(t)
+}
+
+func TestRedisCache_InvalidateLocation(t *testing.T) {
+	mockRedis := new(MockRedis)
+	cache := &RedisCache{
+		client:  mockRedis,
+		config:  CacheConfig{IsEnabled: true},
+		metrics: NoopMetrics{},
+	}
+
+	mockRedis.On("Del", mock.Anything, []string{"weather:kyiv", "notfound:kyiv"}).Return(1, nil)
+	mockRedis.On("Scan", mock.Anything, uint64(0), "forecast:kyiv:*", int64(scanBatchSize)).
+		Return([]string{"forecast:kyiv:1", "forecast:kyiv:3"}, uint64(0), nil)
+	mockRedis.On("Del", mock.Anything, []string{"forecast:kyiv:1", "forecast:kyiv:3"}).Return(2, nil)
+
+	deleted, err := cache.InvalidateLocation(context.Background(), contracts.CityLocation("Kyiv"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+	mockRedis.AssertExpectations(t)
+}
+
+func TestRedisCache_InvalidatePatternAndFlush(t *testing.T) {
+	mockRedis := new(MockRedis)
+	cache := &RedisCache{
+		client:  mockRedis,
+		config:  CacheConfig{IsEnabled: true},
+		metrics: NoopMetrics{},
+	}
+
+	for _, prefix := range cachePrefixes {
+		mockRedis.On("Scan", mock.Anything, uint64(0), prefix+"kyiv*", int64(scanBatchSize)).
+			Return([]string{prefix + "kyiv"}, uint64(0), nil)
+		mockRedis.On("Del", mock.Anything, []string{prefix + "kyiv"}).Return(1, nil)
+		mockRedis.On("Scan", mock.Anything, uint64(0), prefix+"*", int64(scanBatchSize)).
+			Return([]string{}, uint64(0), nil)
+	}
+
+	deleted, err := cache.InvalidatePattern(context.Background(), "kyiv*")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(cachePrefixes)), deleted)
+
+	_, err = cache.InvalidatePattern(context.Background(), " ")
+	assert.ErrorIs(t, err, apierrors.ErrInvalidPattern)
+
+	flushed, err := cache.Flush(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), flushed)
+	mockRedis.AssertExpectations(t)
+}
+
+func TestRedisCache_FlushRemovesCitySearches(t *testing.T) {
+	mockRedis := new(MockRedis)
+	cache := &RedisCache{
+		client:  mockRedis,
+		config:  CacheConfig{IsEnabled: true},
+		metrics: NoopMetrics{},
+	}
+
+	for _, prefix := range []string{"weather:", "forecast:", "notfound:"} {
+		mockRedis.On("Scan", mock.Anything, uint64(0), prefix+"*", int64(scanBatchSize)).
+			Return([]string{}, uint64(0), nil)
+	}
+	mockRedis.On("Scan", mock.Anything, uint64(0), "cities:*", int64(scanBatchSize)).
+		Return([]string{"cities:kyi:5"}, uint64(0), nil)
+	mockRedis.On("Del", mock.Anything, []string{"cities:kyi:5"}).Return(1, nil)
+
+	flushed, err := cache.Flush(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), flushed)
+	mockRedis.AssertExpectations(t)
+}
+
+func TestRedisCache_GetStats(t *testing.T) {
+	mockRedis := new(MockRedis)
+	cache := &RedisCache{
+		client:  mockRedis,
+		config:  CacheConfig{IsEnabled: true},
+		metrics: NoopMetrics{},
+	}
+
+	info := "# Memory\r\nused_memory:1024\r\nused_memory_human:1.00K\r\n\r\n# Stats\r\nkeyspace_hits:7\r\ninstantaneous_input_kbps:0.25\r\n"
+	mockRedis.On("Info", mock.Anything, []string{"memory", "stats"}).Return(info, nil)
+	mockRedis.On("PoolStats").Return(&redis.PoolStats{Hits: 3, TotalConns: 2})
+
+	stats, err := cache.GetStats(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"memory": map[string]interface{}{"used_memory": int64(1024), "used_memory_human": "1.00K"},
+		"stats":  map[string]interface{}{"keyspace_hits": int64(7), "instantaneous_input_kbps": 0.25},
+	}, stats["redis"])
+	assert.Equal(t, uint32(3), stats["pool"].(map[string]interface{})["hits"])
+}
@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+)
+
+// Cache key prefix for city search results.
+const citiesCachePrefix = "cities:"
+
+var _ contracts.CityCache = (*RedisCache)(nil)
+
+// citiesKey builds the cache key of a city search; queries differing only in
+// case or surrounding spaces share a key.
+func citiesKey(query string, limit int) string {
+	return fmt.Sprintf("%s%s:%d", citiesCachePrefix, strings.ToLower(strings.TrimSpace(query)), limit)
+}
+
+// GetCities retrieves city search results from Redis cache.
+func (r *RedisCache) GetCities(ctx context.Context, query string, limit int) ([]contracts.City, error) {
+	if !r.isEnabled() {
+		r.metrics.IncCacheMisses()
+		return nil, apierrors.ErrCacheMiss
+	}
+
+	result, err := r.client.Get(ctx, citiesKey(query, limit)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			r.metrics.IncCacheMisses()
+			return nil, apierrors.ErrCacheMiss
+		}
+		return nil, fmt.Errorf("failed to get cities from cache: %w", err)
+	}
+	r.metrics.IncCacheHits()
+
+	var cities []contracts.City
+	if err := json.Unmarshal([]byte(result), &cities); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cached cities: %w", err)
+	}
+	return cities, nil
+}
+
+// SetCities stores city search results in Redis cache with expiration.
+func (r *RedisCache) SetCities(ctx context.Context, query string, limit int, cities []contracts.City, expiration time.Duration) error {
+	if !r.isEnabled() {
+		return nil
+	}
+
+	jsonData, err := json.Marshal(cities)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cities: %w", err)
+	}
+
+	if expiration <= 0 {
+		expiration = r.config.DefaultExpiration
+	}
+
+	if err := r.client.Set(ctx, citiesKey(query, limit), jsonData, expiration).Err(); err != nil {
+		return fmt.Errorf("failed to set cities cache: %w", err)
+	}
+	r.metrics.IncCacheSets()
+	return nil
+}
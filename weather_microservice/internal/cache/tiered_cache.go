@@ -0,0 +1,206 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"weather_microservice/internal/contracts"
+)
+
+// Backend is a cache that stores both current weather and forecasts.
+type Backend interface {
+	contracts.WeatherCache
+	contracts.ForecastCache
+}
+
+// TieredCache checks the in-memory tier first, then the remote tier (Redis),
+// and back-fills memory on remote hits. Writes go to both tiers, so the
+// service keeps serving hot locations from memory while Redis is unreachable.
+type TieredCache struct {
+	local  *MemoryCache
+	remote Backend
+}
+
+// compile-time гарантія, що реалізує інтерфейс.
+var _ Backend = (*TieredCache)(nil)
+var _ contracts.CacheAdmin = (*TieredCache)(nil)
+var _ contracts.BatchWeatherCache = (*TieredCache)(nil)
+
+// NewTieredCache creates a two-tier cache.
+func NewTieredCache(local *MemoryCache, remote Backend) *TieredCache {
+	return &TieredCache{
+		local:  local,
+		remote: remote,
+	}
+}
+
+// Get retrieves weather data from memory, falling back to the remote tier.
+func (t *TieredCache) Get(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error) {
+	if data, err := t.local.Get(ctx, loc); err == nil {
+		return data, nil
+	}
+	data, err := t.remote.Get(ctx, loc)
+	if err != nil {
+		return contracts.WeatherData{}, err
+	}
+	_ = t.local.Set(ctx, loc, data, 0)
+	return data, nil
+}
+
+// GetMany retrieves weather data of several locations from memory and the
+// rest from the remote tier in one batch, back-filling memory. When the
+// remote tier fails, the memory hits are still returned.
+func (t *TieredCache) GetMany(ctx context.Context, locs []contracts.Location) (map[string]contracts.WeatherData, error) {
+	found, _ := t.local.GetMany(ctx, locs)
+	var missing []contracts.Location
+	for _, loc := range locs {
+		if _, ok := found[loc.Key()]; !ok {
+			missing = append(missing, loc)
+		}
+	}
+	if len(missing) == 0 {
+		return found, nil
+	}
+
+	batch, ok := t.remote.(contracts.BatchWeatherCache)
+	if !ok {
+		for _, loc := range missing {
+			if data, err := t.Get(ctx, loc); err == nil {
+				found[loc.Key()] = data
+			}
+		}
+		return found, nil
+	}
+	remote, err := batch.GetMany(ctx, missing)
+	if err != nil {
+		return found, nil
+	}
+	for _, loc := range missing {
+		if data, ok := remote[loc.Key()]; ok {
+			_ = t.local.Set(ctx, loc, data, 0)
+			found[loc.Key()] = data
+		}
+	}
+	return found, nil
+}
+
+// Set stores weather data in both tiers.
+func (t *TieredCache) Set(ctx context.Context, loc contracts.Location, data contracts.WeatherData, expiration time.Duration) error {
+	_ = t.local.Set(ctx, loc, data, expiration)
+	return t.remote.Set(ctx, loc, data, expiration)
+}
+
+// GetForecast retrieves forecast data from memory, falling back to the remote tier.
+func (t *TieredCache) GetForecast(ctx context.Context, loc contracts.Location, days int) (contracts.ForecastData, error) {
+	if data, err := t.local.GetForecast(ctx, loc, days); err == nil {
+		return data, nil
+	}
+	data, err := t.remote.GetForecast(ctx, loc, days)
+	if err != nil {
+		return contracts.ForecastData{}, err
+	}
+	_ = t.local.SetForecast(ctx, loc, days, data, 0)
+	return data, nil
+}
+
+// SetForecast stores forecast data in both tiers.
+func (t *TieredCache) SetForecast(ctx context.Context, loc contracts.Location, days int, data contracts.ForecastData, expiration time.Duration) error {
+	_ = t.local.SetForecast(ctx, loc, days, data, expiration)
+	return t.remote.SetForecast(ctx, loc, days, data, expiration)
+}
+
+// Delete removes weather data from both tiers.
+func (t *TieredCache) Delete(ctx context.Context, loc contracts.Location) error {
+	_ = t.local.Delete(ctx, loc)
+	return t.remote.Delete(ctx, loc)
+}
+
+// Exists checks if weather data exists in either tier.
+func (t *TieredCache) Exists(ctx context.Context, loc contracts.Location) (bool, error) {
+	if ok, _ := t.local.Exists(ctx, loc); ok {
+		return true, nil
+	}
+	return t.remote.Exists(ctx, loc)
+}
+
+// Health reports the remote tier health.
+func (t *TieredCache) Health(ctx context.Context) error {
+	return t.remote.Health(ctx)
+}
+
+// Close closes both tiers.
+func (t *TieredCache) Close() error {
+	_ = t.local.Close()
+	return t.remote.Close()
+}
+
+// GetStats merges statistics of both tiers.
+func (t *TieredCache) GetStats(ctx context.Context) (map[string]interface{}, error) {
+	stats, err := t.remote.GetStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get remote cache stats: %w", err)
+	}
+	localStats, _ := t.local.GetStats(ctx)
+	merged := make(map[string]interface{}, len(stats)+len(localStats))
+	for k, v := range stats {
+		merged[k] = v
+	}
+	for k, v := range localStats {
+		merged[k] = v
+	}
+	return merged, nil
+}
+
+// Inspect returns the remote entry, which carries the authoritative TTL,
+// falling back to memory when the remote tier cannot be inspected.
+func (t *TieredCache) Inspect(ctx context.Context, loc contracts.Location) (contracts.CacheEntry, bool, error) {
+	if admin, ok := t.remote.(contracts.CacheAdmin); ok {
+		entry, found, err := admin.Inspect(ctx, loc)
+		if err == nil {
+			return entry, found, nil
+		}
+	}
+	return t.local.Inspect(ctx, loc)
+}
+
+// InvalidateLocation removes a location from both tiers.
+func (t *TieredCache) InvalidateLocation(ctx context.Context, loc contracts.Location) (int64, error) {
+	deleted, _ := t.local.InvalidateLocation(ctx, loc)
+	return t.remoteAdmin(deleted, func(admin contracts.CacheAdmin) (int64, error) {
+		return admin.InvalidateLocation(ctx, loc)
+	})
+}
+
+// InvalidatePattern removes matching entries from both tiers.
+func (t *TieredCache) InvalidatePattern(ctx context.Context, pattern string) (int64, error) {
+	deleted, err := t.local.InvalidatePattern(ctx, pattern)
+	if err != nil {
+		return 0, err
+	}
+	return t.remoteAdmin(deleted, func(admin contracts.CacheAdmin) (int64, error) {
+		return admin.InvalidatePattern(ctx, pattern)
+	})
+}
+
+// Flush drops all entries of both tiers.
+func (t *TieredCache) Flush(ctx context.Context) (int64, error) {
+	deleted, _ := t.local.Flush(ctx)
+	return t.remoteAdmin(deleted, func(admin contracts.CacheAdmin) (int64, error) {
+		return admin.Flush(ctx)
+	})
+}
+
+// remoteAdmin applies op to the remote tier if it supports admin operations
+// and adds its result to the number of entries removed from memory.
+func (t *TieredCache) remoteAdmin(local int64, op func(contracts.CacheAdmin) (int64, error)) (int64, error) {
+	admin, ok := t.remote.(contracts.CacheAdmin)
+	if !ok {
+		return local, nil
+	}
+	remote, err := op(admin)
+	if err != nil {
+		return local + remote, fmt.Errorf("failed to invalidate remote cache: %w", err)
+	}
+	return local + remote, nil
+}
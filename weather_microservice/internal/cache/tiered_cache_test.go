@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"weather_microservice/internal/contracts"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unreachableBackend simulates Redis being down.
+type unreachableBackend struct {
+	NoopWeatherCache
+}
+
+var errUnreachable = errors.New("redis unreachable")
+
+func (unreachableBackend) Get(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error) {
+	return contracts.WeatherData{}, errUnreachable
+}
+
+func (unreachableBackend) Set(ctx context.Context, loc contracts.Location, data contracts.WeatherData, expiration time.Duration) error {
+	return errUnreachable
+}
+
+func TestTieredCache_BackfillsMemoryFromRemote(t *testing.T) {
+	ctx := context.Background()
+	loc := contracts.CityLocation("Kyiv")
+	data := contracts.WeatherData{Temperature: 18, Description: "Cloudy"}
+
+	local := NewMemoryCache(10, time.Minute, nil)
+	remote := NewMemoryCache(10, time.Hour, nil)
+	require.NoError(t, remote.Set(ctx, loc, data, time.Hour))
+
+	tiered := NewTieredCache(local, remote)
+
+	got, err := tiered.Get(ctx, loc)
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+
+	got, err = local.Get(ctx, loc)
+	require.NoError(t, err, "remote hit must back-fill the memory tier")
+	assert.Equal(t, data, got)
+}
+
+func TestTieredCache_GetMany(t *testing.T) {
+	ctx := context.Background()
+	kyiv, lviv := contracts.CityLocation("Kyiv"), contracts.CityLocation("Lviv")
+
+	local := NewMemoryCache(10, time.Minute, nil)
+	remote := NewMemoryCache(10, time.Hour, nil)
+	require.NoError(t, local.Set(ctx, kyiv, contracts.WeatherData{Description: "local"}, time.Hour))
+	require.NoError(t, remote.Set(ctx, lviv, contracts.WeatherData{Description: "remote"}, time.Hour))
+
+	got, err := NewTieredCache(local, remote).GetMany(ctx, []contracts.Location{kyiv, lviv, contracts.CityLocation("Odesa")})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]contracts.WeatherData{
+		"kyiv": {Description: "local"},
+		"lviv": {Description: "remote"},
+	}, got)
+
+	_, err = local.Get(ctx, lviv)
+	assert.NoError(t, err, "remote hit must back-fill the memory tier")
+}
+
+func TestTieredCache_SetWritesBothTiers(t *testing.T) {
+	ctx := context.Background()
+	loc := contracts.CityLocation("Kyiv")
+
+	local := NewMemoryCache(10, time.Minute, nil)
+	remote := NewMemoryCache(10, time.Hour, nil)
+	tiered := NewTieredCache(local, remote)
+
+	require.NoError(t, tiered.Set(ctx, loc, contracts.WeatherData{Temperature: 5}, time.Hour))
+
+	_, err := local.Get(ctx, loc)
+	assert.NoError(t, err)
+	_, err = remote.Get(ctx, loc)
+	assert.NoError(t, err)
+
+	require.NoError(t, tiered.Delete(ctx, loc))
+	_, err = tiered.Get(ctx, loc)
+	assert.Error(t, err)
+}
+
+func TestTieredCache_ServesFromMemoryWhenRemoteIsDown(t *testing.T) {
+	ctx := context.Background()
+	loc := contracts.CityLocation("Kyiv")
+
+	tiered := NewTieredCache(NewMemoryCache(10, time.Minute, nil), unreachableBackend{})
+
+	err := tiered.Set(ctx, loc, contracts.WeatherData{Temperature: 7}, time.Minute)
+	assert.ErrorIs(t, err, errUnreachable)
+
+	got, err := tiered.Get(ctx, loc)
+	require.NoError(t, err)
+	assert.Equal(t, 7.0, got.Temperature)
+}
+
+func TestTieredCache_InvalidatesBothTiers(t *testing.T) {
+	ctx := context.Background()
+	loc := contracts.CityLocation("Kyiv")
+
+	local := NewMemoryCache(10, time.Minute, nil)
+	remote := NewMemoryCache(10, time.Hour, nil)
+	tiered := NewTieredCache(local, remote)
+	require.NoError(t, tiered.Set(ctx, loc, contracts.WeatherData{Temperature: 18}, time.Hour))
+
+	// Inspect reports the remote TTL, which is longer than the memory one.
+	entry, ok, err := tiered.Inspect(ctx, loc)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Greater(t, entry.TTL, time.Minute)
+
+	deleted, err := tiered.InvalidateLocation(ctx, loc)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	assert.Equal(t, 0, local.Len())
+	assert.Equal(t, 0, remote.Len())
+}
@@ -0,0 +1,246 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+)
+
+// CircuitState is the state of a provider circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets every request through to the provider.
+	CircuitClosed CircuitState = iota
+	// CircuitHalfOpen lets a single probe request through after the cool-down.
+	CircuitHalfOpen
+	// CircuitOpen skips the provider until the cool-down elapses.
+	CircuitOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitHalfOpen:
+		return "half-open"
+	case CircuitOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerConfig holds circuit breaker thresholds.
+type CircuitBreakerConfig struct {
+	FailureThreshold int           // Consecutive failures that open the circuit.
+	SuccessThreshold int           // Consecutive half-open successes that close it again.
+	CoolDown         time.Duration // Time the circuit stays open before a probe.
+}
+
+// DefaultCircuitBreakerConfig returns default circuit breaker configuration.
+func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		FailureThreshold: 5,
+		SuccessThreshold: 1,
+		CoolDown:         30 * time.Second,
+	}
+}
+
+// CircuitBreakerHandler decorates a handler with a circuit breaker.
+// The wrapped handler must not have its own next handler: the decorator owns
+// the fallback so it can tell the provider's own failures from those further
+// down the chain.
+type CircuitBreakerHandler struct {
+	inner   WeatherHandler
+	next    WeatherHandler
+	config  CircuitBreakerConfig
+	metrics Metrics
+	now     func() time.Time
+
+	mu        sync.Mutex
+	state     CircuitState
+	failures  int
+	successes int
+	openedAt  time.Time
+	probing   bool
+}
+
+// NewCircuitBreakerHandler wraps a handler with a circuit breaker.
+func NewCircuitBreakerHandler(inner WeatherHandler, config CircuitBreakerConfig, metrics Metrics) *CircuitBreakerHandler {
+	defaults := DefaultCircuitBreakerConfig()
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = defaults.FailureThreshold
+	}
+	if config.SuccessThreshold <= 0 {
+		config.SuccessThreshold = defaults.SuccessThreshold
+	}
+	if config.CoolDown <= 0 {
+		config.CoolDown = defaults.CoolDown
+	}
+
+	cb := &CircuitBreakerHandler{
+		inner:   inner,
+		config:  config,
+		metrics: metrics,
+		now:     time.Now,
+		state:   CircuitClosed,
+	}
+	metrics.SetCircuitState(inner.GetProviderName(), CircuitClosed)
+	return cb
+}
+
+func (cb *CircuitBreakerHandler) SetNext(handler WeatherHandler) WeatherHandler {
+	cb.next = handler
+	return handler
+}
+
+func (cb *CircuitBreakerHandler) GetProviderName() string {
+	return cb.inner.GetProviderName()
+}
+
+// State returns the current circuit state.
+func (cb *CircuitBreakerHandler) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state
+}
+
+func (cb *CircuitBreakerHandler) Handle(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error) {
+	if !cb.allow() {
+		if cb.next != nil && fallbackEnabled(ctx) {
+			cb.metrics.IncProviderFallbacks(cb.GetProviderName(), "weather")
+			return cb.next.Handle(ctx, loc)
+		}
+		return contracts.WeatherData{}, cb.openError()
+	}
+
+	data, err := cb.inner.Handle(ctx, loc)
+	cb.record(ctx, err)
+
+	if err != nil {
+		if cb.next != nil && fallbackEnabled(ctx) {
+			cb.metrics.IncProviderFallbacks(cb.GetProviderName(), "weather")
+			return cb.next.Handle(ctx, loc)
+		}
+		return contracts.WeatherData{}, err
+	}
+	return data, nil
+}
+
+func (cb *CircuitBreakerHandler) HandleForecast(ctx context.Context, loc contracts.Location, days int) (contracts.ForecastData, error) {
+	if !cb.allow() {
+		if cb.next != nil && fallbackEnabled(ctx) {
+			cb.metrics.IncProviderFallbacks(cb.GetProviderName(), "forecast")
+			return cb.next.HandleForecast(ctx, loc, days)
+		}
+		return contracts.ForecastData{}, cb.openError()
+	}
+
+	data, err := cb.inner.HandleForecast(ctx, loc, days)
+	cb.record(ctx, err)
+
+	if err != nil {
+		if cb.next != nil && fallbackEnabled(ctx) {
+			cb.metrics.IncProviderFallbacks(cb.GetProviderName(), "forecast")
+			return cb.next.HandleForecast(ctx, loc, days)
+		}
+		return contracts.ForecastData{}, err
+	}
+	return data, nil
+}
+
+func (cb *CircuitBreakerHandler) openError() error {
+	return fmt.Errorf("%w, %s skipped: %w", apierrors.ErrAllProvidersFailed, cb.GetProviderName(), apierrors.ErrCircuitOpen)
+}
+
+// allow reports whether a request may be sent to the provider.
+func (cb *CircuitBreakerHandler) allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case CircuitOpen:
+		if cb.now().Sub(cb.openedAt) < cb.config.CoolDown {
+			cb.metrics.IncCircuitRejections(cb.GetProviderName())
+			return false
+		}
+		cb.transition(CircuitHalfOpen)
+		cb.probing = true
+		return true
+	case CircuitHalfOpen:
+		// Only one probe at a time, the rest keep falling through.
+		if cb.probing {
+			cb.metrics.IncCircuitRejections(cb.GetProviderName())
+			return false
+		}
+		cb.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record updates the circuit state with the result of a provider call.
+func (cb *CircuitBreakerHandler) record(ctx context.Context, err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == CircuitHalfOpen {
+		cb.probing = false
+	}
+
+	// A request canceled by the caller says nothing about the provider,
+	// and one skipped for its call budget did not reach it at all.
+	if err != nil && (ctx.Err() != nil || errors.Is(err, apierrors.ErrProviderBudgetExhausted)) {
+		return
+	}
+
+	if !isProviderFailure(err) {
+		cb.failures = 0
+		if cb.state == CircuitHalfOpen {
+			cb.successes++
+			if cb.successes >= cb.config.SuccessThreshold {
+				cb.transition(CircuitClosed)
+			}
+		}
+		return
+	}
+
+	cb.successes = 0
+	cb.failures++
+	if cb.state == CircuitHalfOpen || cb.failures >= cb.config.FailureThreshold {
+		cb.transition(CircuitOpen)
+	}
+}
+
+// transition switches the circuit state. Must be called with mu held.
+func (cb *CircuitBreakerHandler) transition(to CircuitState) {
+	from := cb.state
+	if from == to {
+		return
+	}
+	cb.state = to
+	cb.failures = 0
+	cb.successes = 0
+	if to == CircuitOpen {
+		cb.openedAt = cb.now()
+	}
+
+	provider := cb.GetProviderName()
+	log.Printf("Circuit breaker for %s: %s -> %s", provider, from, to)
+	cb.metrics.SetCircuitState(provider, to)
+	cb.metrics.IncCircuitTransitions(provider, from, to)
+}
+
+// isProviderFailure reports whether an error means the provider is unhealthy.
+// A "city not found" answer is a healthy response.
+func isProviderFailure(err error) bool {
+	return err != nil && !errors.Is(err, apierrors.ErrCityNotFound)
+}
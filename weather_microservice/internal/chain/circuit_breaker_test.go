@@ -0,0 +1,130 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+)
+
+type stubProvider struct {
+	calls int
+	data  contracts.WeatherData
+	err   error
+}
+
+func (p *stubProvider) FetchWeather(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error) {
+	p.calls++
+	return p.data, p.err
+}
+
+func (p *stubProvider) FetchForecast(ctx context.Context, loc contracts.Location, days int) (contracts.ForecastData, error) {
+	p.calls++
+	return contracts.ForecastData{}, p.err
+}
+
+func newTestBreaker(provider *stubProvider, fallback *stubProvider) (*CircuitBreakerHandler, *time.Time) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cb := NewCircuitBreakerHandler(NewBaseWeatherHandler(provider, "primary"), CircuitBreakerConfig{
+		FailureThreshold: 2,
+		SuccessThreshold: 1,
+		CoolDown:         time.Minute,
+	}, NoopMetrics{})
+	cb.now = func() time.Time { return now }
+	if fallback != nil {
+		cb.SetNext(NewBaseWeatherHandler(fallback, "fallback"))
+	}
+	return cb, &now
+}
+
+func TestCircuitBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	primary := &stubProvider{err: errors.New("timeout")}
+	fallback := &stubProvider{data: contracts.WeatherData{Description: "from fallback"}}
+	cb, _ := newTestBreaker(primary, fallback)
+	kyiv := contracts.CityLocation("Kyiv")
+
+	for i := 0; i < 2; i++ {
+		data, err := cb.Handle(context.Background(), kyiv)
+		require.NoError(t, err)
+		require.Equal(t, "from fallback", data.Description)
+	}
+	require.Equal(t, CircuitOpen, cb.State())
+	require.Equal(t, 2, primary.calls)
+
+	// The open circuit skips the primary provider right away.
+	data, err := cb.Handle(context.Background(), kyiv)
+	require.NoError(t, err)
+	require.Equal(t, "from fallback", data.Description)
+	require.Equal(t, 2, primary.calls)
+	require.Equal(t, 3, fallback.calls)
+}
+
+func TestCircuitBreaker_OpenWithoutFallback(t *testing.T) {
+	primary := &stubProvider{err: errors.New("timeout")}
+	cb, _ := newTestBreaker(primary, nil)
+	kyiv := contracts.CityLocation("Kyiv")
+
+	for i := 0; i < 2; i++ {
+		_, _ = cb.Handle(context.Background(), kyiv)
+	}
+
+	_, err := cb.Handle(context.Background(), kyiv)
+	require.ErrorIs(t, err, apierrors.ErrCircuitOpen)
+}
+
+func TestCircuitBreaker_HalfOpenProbe(t *testing.T) {
+	primary := &stubProvider{err: errors.New("timeout")}
+	cb, now := newTestBreaker(primary, nil)
+	kyiv := contracts.CityLocation("Kyiv")
+
+	for i := 0; i < 2; i++ {
+		_, _ = cb.Handle(context.Background(), kyiv)
+	}
+	require.Equal(t, CircuitOpen, cb.State())
+
+	t.Run("failed probe reopens the circuit", func(t *testing.T) {
+		*now = now.Add(time.Minute)
+		_, err := cb.Handle(context.Background(), kyiv)
+		require.Error(t, err)
+		require.Equal(t, 3, primary.calls)
+		require.Equal(t, CircuitOpen, cb.State())
+	})
+
+	t.Run("successful probe closes the circuit", func(t *testing.T) {
+		*now = now.Add(time.Minute)
+		primary.err = nil
+		_, err := cb.Handle(context.Background(), kyiv)
+		require.NoError(t, err)
+		require.Equal(t, CircuitClosed, cb.State())
+	})
+}
+
+func TestCircuitBreaker_CityNotFoundDoesNotTrip(t *testing.T) {
+	primary := &stubProvider{err: apierrors.ErrCityNotFound}
+	cb, _ := newTestBreaker(primary, nil)
+
+	for i := 0; i < 5; i++ {
+		_, err := cb.Handle(context.Background(), contracts.CityLocation("Nowhere"))
+		require.ErrorIs(t, err, apierrors.ErrCityNotFound)
+	}
+	require.Equal(t, CircuitClosed, cb.State())
+	require.Equal(t, 5, primary.calls)
+}
+
+func TestCircuitBreaker_SharedWithForecast(t *testing.T) {
+	primary := &stubProvider{err: errors.New("timeout")}
+	cb, _ := newTestBreaker(primary, nil)
+	kyiv := contracts.CityLocation("Kyiv")
+
+	for i := 0; i < 2; i++ {
+		_, _ = cb.HandleForecast(context.Background(), kyiv, 1)
+	}
+
+	_, err := cb.Handle(context.Background(), kyiv)
+	require.ErrorIs(t, err, apierrors.ErrCircuitOpen)
+}
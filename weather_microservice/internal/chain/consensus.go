@@ -0,0 +1,208 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"sync"
+
+	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+)
+
+// ConsensusProvider is the Provider of weather merged from several providers.
+const ConsensusProvider = "consensus"
+
+// MinOutlierProviders is how many answers are needed to tell which provider
+// is wrong: with two disagreeing answers either of them may be the outlier.
+const MinOutlierProviders = 3
+
+// ConsensusOptions configures StrategyConsensus.
+type ConsensusOptions struct {
+	// TemperatureThreshold is how far, in °C, a provider may be from the
+	// median temperature before it is treated as an outlier.
+	TemperatureThreshold float64
+	// HumidityThreshold is the same for humidity, in percentage points.
+	HumidityThreshold float64
+}
+
+// DefaultConsensusOptions are used until SetConsensusOptions is called.
+var DefaultConsensusOptions = ConsensusOptions{
+	TemperatureThreshold: 5,
+	HumidityThreshold:    20,
+}
+
+type providerAnswer struct {
+	provider string
+	data     contracts.WeatherData
+}
+
+// consensus asks all handlers concurrently and merges their answers: median
+// temperature and humidity, the majority condition, and outliers excluded.
+func (c *WeatherChain) consensus(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error) {
+	isolated := withoutFallback(ctx)
+
+	// Every goroutine writes only its own slot, so no locking is needed.
+	var (
+		wg      sync.WaitGroup
+		answers = make([]*providerAnswer, len(c.handlers))
+		errs    = make([]error, len(c.handlers))
+	)
+	for i, h := range c.handlers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, err := h.Handle(isolated, loc)
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", h.GetProviderName(), err)
+				return
+			}
+			answers[i] = &providerAnswer{provider: h.GetProviderName(), data: data}
+		}()
+	}
+	wg.Wait()
+
+	// Keep the chain order, so ties are resolved in favor of earlier providers.
+	var ok []providerAnswer
+	for _, a := range answers {
+		if a != nil {
+			ok = append(ok, *a)
+		}
+	}
+	if len(ok) == 0 {
+		if err := ctx.Err(); err != nil {
+			return contracts.WeatherData{}, err
+		}
+		return contracts.WeatherData{}, consensusError(errs)
+	}
+
+	merged, outliers := mergeAnswers(ok, c.consensusOptions)
+	for _, provider := range outliers {
+		log.Printf("Weather provider %s disagrees with the consensus for %s, ignoring its answer", provider, loc)
+		c.metrics.IncProviderOutliers(provider)
+	}
+	if len(ok) < MinOutlierProviders && disagree(ok, c.consensusOptions) {
+		log.Printf("Weather providers %v disagree for %s, but are too few to tell the outlier", merged.Providers, loc)
+		c.metrics.IncConsensusDisagreements()
+	}
+	return merged, nil
+}
+
+// disagree reports whether answers are further apart than the thresholds.
+func disagree(answers []providerAnswer, opts ConsensusOptions) bool {
+	spread := func(field func(contracts.WeatherData) float64) float64 {
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for _, a := range answers {
+			lo, hi = min(lo, field(a.data)), max(hi, field(a.data))
+		}
+		return hi - lo
+	}
+	return spread(func(d contracts.WeatherData) float64 { return d.Temperature }) > opts.TemperatureThreshold ||
+		spread(func(d contracts.WeatherData) float64 { return d.Humidity }) > opts.HumidityThreshold
+}
+
+// consensusError prefers "city not found" so callers can tell it from an outage.
+func consensusError(errs []error) error {
+	var lastErr error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, apierrors.ErrCityNotFound) {
+			return fmt.Errorf("%w: %w", apierrors.ErrAllProvidersFailed, err)
+		}
+		lastErr = err
+	}
+	return fmt.Errorf("%w, last error from %w", apierrors.ErrAllProvidersFailed, lastErr)
+}
+
+// mergeAnswers combines successful answers into one and returns the providers
+// whose answers were discarded as outliers.
+func mergeAnswers(answers []providerAnswer, opts ConsensusOptions) (contracts.WeatherData, []string) {
+	var outliers []string
+	if len(answers) >= MinOutlierProviders {
+		tempMedian := median(answers, func(d contracts.WeatherData) float64 { return d.Temperature })
+		humidityMedian := median(answers, func(d contracts.WeatherData) float64 { return d.Humidity })
+
+		kept := answers[:0:0]
+		for _, a := range answers {
+			if math.Abs(a.data.Temperature-tempMedian) > opts.TemperatureThreshold ||
+				math.Abs(a.data.Humidity-humidityMedian) > opts.HumidityThreshold {
+				outliers = append(outliers, a.provider)
+				continue
+			}
+			kept = append(kept, a)
+		}
+		// When nobody agrees there is no majority to trust, so keep everyone.
+		if len(kept) > len(answers)/2 {
+			answers = kept
+		} else {
+			outliers = nil
+		}
+	}
+
+	temperature := median(answers, func(d contracts.WeatherData) float64 { return d.Temperature })
+	humidity := median(answers, func(d contracts.WeatherData) float64 { return d.Humidity })
+	condition := majorityCondition(answers)
+
+	// The other fields come from the provider that reported the winning
+	// condition and is closest to the median temperature.
+	var base *providerAnswer
+	for i := range answers {
+		a := &answers[i]
+		if a.data.Condition != condition {
+			continue
+		}
+		if base == nil || math.Abs(a.data.Temperature-temperature) < math.Abs(base.data.Temperature-temperature) {
+			base = a
+		}
+	}
+
+	merged := base.data
+	merged.Temperature = temperature
+	merged.Humidity = humidity
+	merged.Provider = ConsensusProvider
+	merged.Providers = make([]string, 0, len(answers))
+	for _, a := range answers {
+		merged.Providers = append(merged.Providers, a.provider)
+	}
+	merged.Outliers = outliers
+	return merged, outliers
+}
+
+// median returns the median of the field over all answers.
+func median(answers []providerAnswer, field func(contracts.WeatherData) float64) float64 {
+	values := make([]float64, len(answers))
+	for i, a := range answers {
+		values[i] = field(a.data)
+	}
+	sort.Float64s(values)
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+	return values[mid]
+}
+
+// majorityCondition returns the most reported condition. Ties go to the more
+// severe report, then to the provider earlier in the chain.
+func majorityCondition(answers []providerAnswer) contracts.Condition {
+	votes := make(map[contracts.Condition]int)
+	severity := make(map[contracts.Condition]int)
+	for _, a := range answers {
+		votes[a.data.Condition]++
+		severity[a.data.Condition] = max(severity[a.data.Condition], a.data.Severity.Rank())
+	}
+
+	best := answers[0].data.Condition
+	for _, a := range answers {
+		c := a.data.Condition
+		if votes[c] > votes[best] || (votes[c] == votes[best] && severity[c] > severity[best]) {
+			best = c
+		}
+	}
+	return best
+}
@@ -0,0 +1,148 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+	"weather_microservice/internal/logging"
+)
+
+// outlierMetrics remembers providers reported as outliers.
+type outlierMetrics struct {
+	NoopMetrics
+	outliers chan string
+}
+
+func (m *outlierMetrics) IncProviderOutliers(provider string) {
+	m.outliers <- provider
+}
+
+func (m *outlierMetrics) IncConsensusDisagreements() {
+	m.outliers <- "disagreement"
+}
+
+func newConsensusChain(providers ...*delayedProvider) *WeatherChain {
+	c := NewWeatherChain(logging.NewMockLogger())
+	c.SetStrategy(StrategyConsensus, 0)
+	for i, p := range providers {
+		c.AddHandler(NewBaseWeatherHandler(p, string(rune('a'+i))))
+	}
+	return c
+}
+
+func providerWith(temp, humidity float64, condition contracts.Condition, description string) *delayedProvider {
+	return &delayedProvider{data: contracts.WeatherData{
+		Temperature: temp,
+		Humidity:    humidity,
+		Condition:   condition,
+		Severity:    contracts.SeverityNone,
+		Description: description,
+	}}
+}
+
+func TestWeatherChain_ConsensusDiscardsOutlier(t *testing.T) {
+	metrics := &outlierMetrics{outliers: make(chan string, 3)}
+	c := newConsensusChain(
+		providerWith(20, 60, contracts.ConditionCloudy, "cloudy a"),
+		providerWith(21, 64, contracts.ConditionCloudy, "cloudy b"),
+		providerWith(45, 62, contracts.ConditionClear, "broken"),
+	)
+	c.SetMetrics(metrics)
+
+	data, err := c.GetWeather(context.Background(), contracts.CityLocation("Kyiv"))
+	require.NoError(t, err)
+	require.Equal(t, 20.5, data.Temperature)
+	require.Equal(t, 62.0, data.Humidity)
+	require.Equal(t, contracts.ConditionCloudy, data.Condition)
+	require.Equal(t, ConsensusProvider, data.Provider)
+	require.Equal(t, []string{"a", "b"}, data.Providers)
+	require.Equal(t, []string{"c"}, data.Outliers)
+	require.Equal(t, "c", <-metrics.outliers)
+}
+
+func TestWeatherChain_ConsensusMajorityCondition(t *testing.T) {
+	c := newConsensusChain(
+		providerWith(10, 80, contracts.ConditionCloudy, "overcast"),
+		providerWith(11, 85, contracts.ConditionRain, "light rain"),
+		providerWith(12, 90, contracts.ConditionRain, "moderate rain"),
+	)
+
+	data, err := c.GetWeather(context.Background(), contracts.CityLocation("Lviv"))
+	require.NoError(t, err)
+	require.Equal(t, 11.0, data.Temperature)
+	require.Equal(t, contracts.ConditionRain, data.Condition)
+	// The description comes from the rain report closest to the median.
+	require.Equal(t, "light rain", data.Description)
+	require.Empty(t, data.Outliers)
+}
+
+func TestWeatherChain_ConsensusTwoProvidersKeepBoth(t *testing.T) {
+	metrics := &outlierMetrics{outliers: make(chan string, 2)}
+	c := newConsensusChain(
+		providerWith(10, 50, contracts.ConditionClear, "clear"),
+		providerWith(30, 50, contracts.ConditionClear, "clear"),
+	)
+	c.SetMetrics(metrics)
+
+	data, err := c.GetWeather(context.Background(), contracts.CityLocation("Odesa"))
+	require.NoError(t, err)
+	// Two answers cannot tell which one is wrong.
+	require.Equal(t, 20.0, data.Temperature)
+	require.Equal(t, []string{"a", "b"}, data.Providers)
+	require.Empty(t, data.Outliers)
+	// The disagreement is still reported.
+	require.Equal(t, []string{"disagreement"}, drain(metrics.outliers))
+}
+
+func TestWeatherChain_ConsensusTwoProvidersAgree(t *testing.T) {
+	metrics := &outlierMetrics{outliers: make(chan string, 2)}
+	c := newConsensusChain(
+		providerWith(10, 50, contracts.ConditionClear, "clear"),
+		providerWith(12, 55, contracts.ConditionClear, "clear"),
+	)
+	c.SetMetrics(metrics)
+
+	_, err := c.GetWeather(context.Background(), contracts.CityLocation("Odesa"))
+	require.NoError(t, err)
+	require.Empty(t, drain(metrics.outliers))
+}
+
+// drain returns what was sent to ch so far.
+func drain(ch chan string) []string {
+	var got []string
+	for {
+		select {
+		case s := <-ch:
+			got = append(got, s)
+		default:
+			return got
+		}
+	}
+}
+
+func TestWeatherChain_ConsensusSkipsFailures(t *testing.T) {
+	broken := &delayedProvider{err: errors.New("boom")}
+	c := newConsensusChain(broken, providerWith(5, 70, contracts.ConditionSnow, "snow"))
+
+	data, err := c.GetWeather(context.Background(), contracts.CityLocation("Kyiv"))
+	require.NoError(t, err)
+	require.Equal(t, 5.0, data.Temperature)
+	require.Equal(t, []string{"b"}, data.Providers)
+	// Each provider is asked once, without falling back down the chain.
+	require.EqualValues(t, 1, broken.calls.Load())
+}
+
+func TestWeatherChain_ConsensusAllFailed(t *testing.T) {
+	c := newConsensusChain(
+		&delayedProvider{err: errors.New("timeout")},
+		&delayedProvider{err: apierrors.ErrCityNotFound},
+	)
+
+	_, err := c.GetWeather(context.Background(), contracts.CityLocation("Nowhere"))
+	require.ErrorIs(t, err, apierrors.ErrCityNotFound)
+}
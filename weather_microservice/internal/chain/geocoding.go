@@ -0,0 +1,72 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+)
+
+type namedGeocoder struct {
+	name     string
+	provider GeocodingProvider
+}
+
+// AddGeocoder appends a provider to the ones asked by SearchCities.
+func (c *WeatherChain) AddGeocoder(name string, provider GeocodingProvider) {
+	c.geocoders = append(c.geocoders, namedGeocoder{name: name, provider: provider})
+}
+
+// SearchCities asks geocoding providers in the order they were added and
+// returns the first successful answer, without duplicate IDs. An empty answer
+// is a valid one: it means the provider knows no such city.
+func (c *WeatherChain) SearchCities(ctx context.Context, query string, limit int) ([]contracts.City, error) {
+	if len(c.geocoders) == 0 {
+		return nil, apierrors.ErrGeocodingUnavailable
+	}
+
+	var lastErr error
+	for i, g := range c.geocoders {
+		if i > 0 {
+			c.metrics.IncProviderFallbacks(c.geocoders[i-1].name, "geocoding")
+		}
+		if c.quota != nil && !c.quota.Allow(ctx, g.name) {
+			lastErr = fmt.Errorf("%s: %w", g.name, apierrors.ErrProviderBudgetExhausted)
+			continue
+		}
+		start := time.Now()
+		cities, err := g.provider.SearchCities(ctx, query, limit)
+		c.metrics.ObserveProviderRequest(g.name, "geocoding", time.Since(start), err)
+		if c.quota != nil {
+			c.quota.Record(ctx, g.name)
+		}
+		if err == nil {
+			return uniqueCities(cities, limit), nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		lastErr = fmt.Errorf("%s: %w", g.name, err)
+	}
+	return nil, fmt.Errorf("%w, last error from %w", apierrors.ErrAllProvidersFailed, lastErr)
+}
+
+// uniqueCities drops later cities with an ID already seen, such as districts
+// named after their city, and cuts the result to limit.
+func uniqueCities(cities []contracts.City, limit int) []contracts.City {
+	seen := make(map[string]bool, len(cities))
+	unique := make([]contracts.City, 0, min(len(cities), limit))
+	for _, city := range cities {
+		if len(unique) == limit {
+			break
+		}
+		if seen[city.ID] {
+			continue
+		}
+		seen[city.ID] = true
+		unique = append(unique, city)
+	}
+	return unique
+}
@@ -0,0 +1,64 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+)
+
+type stubGeocoder struct {
+	cities []contracts.City
+	err    error
+}
+
+func (g stubGeocoder) SearchCities(ctx context.Context, query string, limit int) ([]contracts.City, error) {
+	return g.cities, g.err
+}
+
+func TestWeatherChain_SearchCitiesFallsBack(t *testing.T) {
+	metrics := &recordingMetrics{}
+	weatherChain := NewWeatherChain(nil)
+	weatherChain.SetMetrics(metrics)
+	weatherChain.AddGeocoder("primary", stubGeocoder{err: errors.New("quota exceeded")})
+	weatherChain.AddGeocoder("fallback", stubGeocoder{cities: []contracts.City{
+		{ID: "kyiv,ua", Name: "Kyiv"},
+		{ID: "kyiv,ua", Name: "Kyiv"},
+		{ID: "kyivska,ua", Name: "Kyivska"},
+		{ID: "kyivets,ua", Name: "Kyivets"},
+	}})
+
+	cities, err := weatherChain.SearchCities(context.Background(), "kyiv", 2)
+	require.NoError(t, err)
+	require.Equal(t, []contracts.City{{ID: "kyiv,ua", Name: "Kyiv"}, {ID: "kyivska,ua", Name: "Kyivska"}}, cities)
+	require.Equal(t, []string{"primary/geocoding/error", "fallback/geocoding/success"}, metrics.requests)
+	require.Equal(t, []string{"primary/geocoding"}, metrics.fallbacks)
+}
+
+func TestWeatherChain_SearchCitiesErrors(t *testing.T) {
+	weatherChain := NewWeatherChain(nil)
+	_, err := weatherChain.SearchCities(context.Background(), "kyiv", 5)
+	require.ErrorIs(t, err, apierrors.ErrGeocodingUnavailable)
+
+	weatherChain.AddGeocoder("broken", stubGeocoder{err: errors.New("boom")})
+	_, err = weatherChain.SearchCities(context.Background(), "kyiv", 5)
+	require.ErrorIs(t, err, apierrors.ErrAllProvidersFailed)
+	require.ErrorContains(t, err, "broken: boom")
+}
+
+func TestWeatherChain_SearchCitiesSkipsExhaustedBudget(t *testing.T) {
+	quota := &stubQuota{exhausted: map[string]bool{"primary": true}}
+	weatherChain := NewWeatherChain(nil)
+	weatherChain.SetQuota(quota)
+	weatherChain.AddGeocoder("primary", stubGeocoder{cities: []contracts.City{{ID: "lviv,ua", Name: "Lviv"}}})
+	weatherChain.AddGeocoder("fallback", stubGeocoder{cities: []contracts.City{{ID: "kyiv,ua", Name: "Kyiv"}}})
+
+	cities, err := weatherChain.SearchCities(context.Background(), "kyiv", 5)
+	require.NoError(t, err)
+	require.Equal(t, []contracts.City{{ID: "kyiv,ua", Name: "Kyiv"}}, cities)
+	require.Equal(t, []string{"fallback"}, quota.recorded)
+}
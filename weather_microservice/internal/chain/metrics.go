@@ -0,0 +1,31 @@
+package chain
+
+import "time"
+
+// Metrics collects provider chain metrics.
+type Metrics interface {
+	SetCircuitState(provider string, state CircuitState)
+	IncCircuitTransitions(provider string, from, to CircuitState)
+	IncCircuitRejections(provider string)
+	// ObserveProviderRequest records a provider call; operation is "weather", "forecast" or "geocoding".
+	ObserveProviderRequest(provider, operation string, duration time.Duration, err error)
+	// IncProviderFallbacks counts requests passed on to the next provider after this one failed.
+	IncProviderFallbacks(provider, operation string)
+	// IncProviderOutliers counts answers discarded by the consensus strategy.
+	IncProviderOutliers(provider string)
+	// IncConsensusDisagreements counts consensus answers too few to tell the
+	// outlier that disagreed beyond the thresholds.
+	IncConsensusDisagreements()
+}
+
+// NoopMetrics is an empty implementation used when metrics are not needed.
+type NoopMetrics struct{}
+
+func (NoopMetrics) SetCircuitState(provider string, state CircuitState)          {}
+func (NoopMetrics) IncCircuitTransitions(provider string, from, to CircuitState) {}
+func (NoopMetrics) IncCircuitRejections(provider string)                         {}
+func (NoopMetrics) IncProviderFallbacks(provider, operation string)              {}
+func (NoopMetrics) IncProviderOutliers(provider string)                          {}
+func (NoopMetrics) IncConsensusDisagreements()                                   {}
+func (NoopMetrics) ObserveProviderRequest(provider, operation string, duration time.Duration, err error) {
+}
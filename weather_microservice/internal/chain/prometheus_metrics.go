@@ -0,0 +1,110 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type PrometheusMetrics struct {
+	circuitState       *prometheus.GaugeVec
+	circuitTransitions *prometheus.CounterVec
+	circuitRejections  *prometheus.CounterVec
+
+	providerDuration  *prometheus.HistogramVec
+	providerErrors    *prometheus.CounterVec
+	providerFallbacks *prometheus.CounterVec
+	providerOutliers  *prometheus.CounterVec
+
+	consensusDisagreements prometheus.Counter
+}
+
+func NewPrometheusMetrics() *PrometheusMetrics {
+	return &PrometheusMetrics{
+		circuitState: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "weather_provider_circuit_state",
+			Help: "Circuit breaker state per provider (0 - closed, 1 - half-open, 2 - open)",
+		}, []string{"provider"}),
+		circuitTransitions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "weather_provider_circuit_transitions_total",
+			Help: "Total number of circuit breaker state transitions",
+		}, []string{"provider", "from", "to"}),
+		circuitRejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "weather_provider_circuit_rejections_total",
+			Help: "Total number of requests that skipped a provider because its circuit was open",
+		}, []string{"provider"}),
+		providerDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "weather_provider_request_duration_seconds",
+			Help:    "Duration of weather provider requests",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"provider", "operation", "outcome"}),
+		providerErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "weather_provider_errors_total",
+			Help: "Total number of failed weather provider requests",
+		}, []string{"provider", "operation"}),
+		providerFallbacks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "weather_provider_fallbacks_total",
+			Help: "Total number of requests passed on to the next provider after this one failed",
+		}, []string{"provider", "operation"}),
+		providerOutliers: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "weather_provider_outliers_total",
+			Help: "Total number of provider answers discarded for disagreeing with the consensus",
+		}, []string{"provider"}),
+		consensusDisagreements: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "weather_consensus_disagreements_total",
+			Help: "Total number of consensus requests whose providers disagreed without enough answers to find the outlier",
+		}),
+	}
+}
+
+func (m *PrometheusMetrics) Register() {
+	prometheus.MustRegister(
+		m.circuitState,
+		m.circuitTransitions,
+		m.circuitRejections,
+		m.providerDuration,
+		m.providerErrors,
+		m.providerFallbacks,
+		m.providerOutliers,
+		m.consensusDisagreements,
+	)
+}
+
+func (m *PrometheusMetrics) SetCircuitState(provider string, state CircuitState) {
+	m.circuitState.WithLabelValues(provider).Set(float64(state))
+}
+
+func (m *PrometheusMetrics) IncCircuitTransitions(provider string, from, to CircuitState) {
+	m.circuitTransitions.WithLabelValues(provider, from.String(), to.String()).Inc()
+}
+
+func (m *PrometheusMetrics) IncCircuitRejections(provider string) {
+	m.circuitRejections.WithLabelValues(provider).Inc()
+}
+
+func (m *PrometheusMetrics) ObserveProviderRequest(provider, operation string, duration time.Duration, err error) {
+	outcome := "success"
+	switch {
+	case errors.Is(err, context.Canceled):
+		// Losers of a race are canceled, that is not a provider failure.
+		outcome = "canceled"
+	case err != nil:
+		outcome = "error"
+		m.providerErrors.WithLabelValues(provider, operation).Inc()
+	}
+	m.providerDuration.WithLabelValues(provider, operation, outcome).Observe(duration.Seconds())
+}
+
+func (m *PrometheusMetrics) IncProviderFallbacks(provider, operation string) {
+	m.providerFallbacks.WithLabelValues(provider, operation).Inc()
+}
+
+func (m *PrometheusMetrics) IncProviderOutliers(provider string) {
+	m.providerOutliers.WithLabelValues(provider).Inc()
+}
+
+func (m *PrometheusMetrics) IncConsensusDisagreements() {
+	m.consensusDisagreements.Inc()
+}
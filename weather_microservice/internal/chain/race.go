@@ -0,0 +1,118 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"weather_microservice/internal/apierrors"
+)
+
+type noFallbackKeyType struct{}
+
+var noFallbackKey = noFallbackKeyType{}
+
+// withoutFallback marks the context so that handlers only ask their own
+// provider and do not pass the request down the chain.
+func withoutFallback(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noFallbackKey, true)
+}
+
+// fallbackEnabled reports whether handlers may pass a failed request to the next handler.
+func fallbackEnabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(noFallbackKey).(bool)
+	return !disabled
+}
+
+type raceResult[T any] struct {
+	provider string
+	data     T
+	err      error
+}
+
+// race queries handlers concurrently and returns the first successful result,
+// canceling the rest. With a positive hedgeDelay handlers are started one by
+// one: the next one starts after the delay or once the previous one fails.
+func race[T any](
+	ctx context.Context,
+	handlers []WeatherHandler,
+	hedgeDelay time.Duration,
+	call func(ctx context.Context, h WeatherHandler) (T, error),
+) (T, error) {
+	var zero T
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	isolated := withoutFallback(ctx)
+
+	results := make(chan raceResult[T], len(handlers))
+	started, pending := 0, 0
+	launch := func() {
+		h := handlers[started]
+		started++
+		pending++
+		go func() {
+			data, err := call(isolated, h)
+			results <- raceResult[T]{provider: h.GetProviderName(), data: data, err: err}
+		}()
+	}
+
+	launch()
+	if hedgeDelay <= 0 {
+		for started < len(handlers) {
+			launch()
+		}
+	}
+
+	var hedge *time.Timer
+	var hedgeC <-chan time.Time
+	resetHedge := func() {
+		if hedgeDelay <= 0 || started >= len(handlers) {
+			hedgeC = nil
+			return
+		}
+		if hedge == nil {
+			hedge = time.NewTimer(hedgeDelay)
+		} else {
+			hedge.Reset(hedgeDelay)
+		}
+		hedgeC = hedge.C
+	}
+	resetHedge()
+	defer func() {
+		if hedge != nil {
+			hedge.Stop()
+		}
+	}()
+
+	var lastErr, notFoundErr error
+	for pending > 0 {
+		select {
+		case res := <-results:
+			pending--
+			if res.err == nil {
+				return res.data, nil
+			}
+			if errors.Is(res.err, apierrors.ErrCityNotFound) {
+				notFoundErr = res.err
+			}
+			lastErr = fmt.Errorf("%s: %w", res.provider, res.err)
+			if started < len(handlers) {
+				launch()
+				resetHedge()
+			}
+		case <-hedgeC:
+			launch()
+			resetHedge()
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+	}
+
+	// Prefer "city not found" so callers can tell it from an outage.
+	if notFoundErr != nil {
+		return zero, fmt.Errorf("%w: %w", apierrors.ErrAllProvidersFailed, notFoundErr)
+	}
+	return zero, fmt.Errorf("%w, last error from %w", apierrors.ErrAllProvidersFailed, lastErr)
+}
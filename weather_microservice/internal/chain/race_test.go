@@ -0,0 +1,127 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+	"weather_microservice/internal/logging"
+)
+
+type delayedProvider struct {
+	delay    time.Duration
+	data     contracts.WeatherData
+	err      error
+	calls    atomic.Int32
+	canceled atomic.Bool
+}
+
+func (p *delayedProvider) FetchWeather(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error) {
+	p.calls.Add(1)
+	select {
+	case <-time.After(p.delay):
+		return p.data, p.err
+	case <-ctx.Done():
+		p.canceled.Store(true)
+		return contracts.WeatherData{}, ctx.Err()
+	}
+}
+
+func (p *delayedProvider) FetchForecast(ctx context.Context, loc contracts.Location, days int) (contracts.ForecastData, error) {
+	return contracts.ForecastData{}, errors.New("not implemented")
+}
+
+func newRaceChain(hedgeDelay time.Duration, providers ...*delayedProvider) *WeatherChain {
+	c := NewWeatherChain(logging.NewMockLogger())
+	c.SetStrategy(StrategyRace, hedgeDelay)
+	for i, p := range providers {
+		c.AddHandler(NewBaseWeatherHandler(p, string(rune('a'+i))))
+	}
+	return c
+}
+
+func TestWeatherChain_RaceReturnsFastest(t *testing.T) {
+	slow := &delayedProvider{delay: time.Second, data: contracts.WeatherData{Description: "slow"}}
+	fast := &delayedProvider{delay: 10 * time.Millisecond, data: contracts.WeatherData{Description: "fast"}}
+	c := newRaceChain(0, slow, fast)
+
+	start := time.Now()
+	data, err := c.GetWeather(context.Background(), contracts.CityLocation("Kyiv"))
+	require.NoError(t, err)
+	require.Equal(t, "fast", data.Description)
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+
+	require.Eventually(t, slow.canceled.Load, time.Second, 5*time.Millisecond)
+}
+
+func TestWeatherChain_RaceSkipsFailures(t *testing.T) {
+	broken := &delayedProvider{err: errors.New("boom")}
+	ok := &delayedProvider{delay: 20 * time.Millisecond, data: contracts.WeatherData{Description: "ok"}}
+	c := newRaceChain(0, broken, ok)
+
+	data, err := c.GetWeather(context.Background(), contracts.CityLocation("Kyiv"))
+	require.NoError(t, err)
+	require.Equal(t, "ok", data.Description)
+	// Fallback is done by the race, not by the failed handler.
+	require.EqualValues(t, 1, ok.calls.Load())
+}
+
+func TestWeatherChain_HedgeDelay(t *testing.T) {
+	t.Run("next provider is not started when the first answers in time", func(t *testing.T) {
+		first := &delayedProvider{delay: 5 * time.Millisecond, data: contracts.WeatherData{Description: "first"}}
+		second := &delayedProvider{data: contracts.WeatherData{Description: "second"}}
+		c := newRaceChain(200*time.Millisecond, first, second)
+
+		data, err := c.GetWeather(context.Background(), contracts.CityLocation("Kyiv"))
+		require.NoError(t, err)
+		require.Equal(t, "first", data.Description)
+		require.EqualValues(t, 0, second.calls.Load())
+	})
+
+	t.Run("slow provider is hedged after the delay", func(t *testing.T) {
+		first := &delayedProvider{delay: time.Second, data: contracts.WeatherData{Description: "first"}}
+		second := &delayedProvider{data: contracts.WeatherData{Description: "second"}}
+		c := newRaceChain(20*time.Millisecond, first, second)
+
+		data, err := c.GetWeather(context.Background(), contracts.CityLocation("Kyiv"))
+		require.NoError(t, err)
+		require.Equal(t, "second", data.Description)
+	})
+
+	t.Run("failure starts the next provider without waiting", func(t *testing.T) {
+		first := &delayedProvider{err: errors.New("boom")}
+		second := &delayedProvider{data: contracts.WeatherData{Description: "second"}}
+		c := newRaceChain(time.Minute, first, second)
+
+		data, err := c.GetWeather(context.Background(), contracts.CityLocation("Kyiv"))
+		require.NoError(t, err)
+		require.Equal(t, "second", data.Description)
+	})
+}
+
+func TestWeatherChain_RaceAllFailed(t *testing.T) {
+	notFound := &delayedProvider{err: apierrors.ErrCityNotFound}
+	broken := &delayedProvider{delay: 10 * time.Millisecond, err: errors.New("boom")}
+	c := newRaceChain(0, notFound, broken)
+
+	_, err := c.GetWeather(context.Background(), contracts.CityLocation("Nowhere"))
+	require.ErrorIs(t, err, apierrors.ErrCityNotFound)
+}
+
+func TestWeatherChain_SequentialAddHandler(t *testing.T) {
+	broken := &delayedProvider{err: errors.New("boom")}
+	ok := &delayedProvider{data: contracts.WeatherData{Description: "ok"}}
+	c := NewWeatherChain(logging.NewMockLogger())
+	c.AddHandler(NewBaseWeatherHandler(broken, "broken"))
+	c.AddHandler(NewBaseWeatherHandler(ok, "ok"))
+
+	data, err := c.GetWeather(context.Background(), contracts.CityLocation("Kyiv"))
+	require.NoError(t, err)
+	require.Equal(t, "ok", data.Description)
+}
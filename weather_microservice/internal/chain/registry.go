@@ -0,0 +1,57 @@
+package chain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ProviderSettings holds per-provider settings passed to a provider factory.
+type ProviderSettings struct {
+	APIKey  string
+	Timeout time.Duration
+}
+
+// ProviderFactory creates a weather provider from its settings.
+type ProviderFactory func(settings ProviderSettings) (WeatherAPIProvider, error)
+
+// ProviderRegistry maps provider names to their factories.
+type ProviderRegistry struct {
+	factories map[string]ProviderFactory
+}
+
+func NewProviderRegistry() *ProviderRegistry {
+	return &ProviderRegistry{
+		factories: make(map[string]ProviderFactory),
+	}
+}
+
+// Register adds a provider factory under the given name, replacing any previous one.
+func (r *ProviderRegistry) Register(name string, factory ProviderFactory) {
+	r.factories[name] = factory
+}
+
+// Has reports whether a provider is registered under name.
+func (r *ProviderRegistry) Has(name string) bool {
+	_, ok := r.factories[name]
+	return ok
+}
+
+// Create builds the named provider.
+func (r *ProviderRegistry) Create(name string, settings ProviderSettings) (WeatherAPIProvider, error) {
+	factory, ok := r.factories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown weather provider %q, known providers: %v", name, r.Names())
+	}
+	return factory(settings)
+}
+
+// Names returns registered provider names in alphabetical order.
+func (r *ProviderRegistry) Names() []string {
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
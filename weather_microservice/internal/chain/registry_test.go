@@ -0,0 +1,43 @@
+package chain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderRegistry(t *testing.T) {
+	registry := NewProviderRegistry()
+
+	var got ProviderSettings
+	registry.Register("stub", func(settings ProviderSettings) (WeatherAPIProvider, error) {
+		got = settings
+		return &stubProvider{}, nil
+	})
+	registry.Register("broken", func(settings ProviderSettings) (WeatherAPIProvider, error) {
+		return nil, errors.New("API key is not configured")
+	})
+
+	t.Run("creates registered provider with settings", func(t *testing.T) {
+		provider, err := registry.Create("stub", ProviderSettings{APIKey: "key", Timeout: 3 * time.Second})
+		require.NoError(t, err)
+		require.NotNil(t, provider)
+		require.Equal(t, ProviderSettings{APIKey: "key", Timeout: 3 * time.Second}, got)
+	})
+
+	t.Run("returns factory error", func(t *testing.T) {
+		_, err := registry.Create("broken", ProviderSettings{})
+		require.Error(t, err)
+	})
+
+	t.Run("rejects unknown provider", func(t *testing.T) {
+		_, err := registry.Create("unknown", ProviderSettings{})
+		require.ErrorContains(t, err, "unknown weather provider")
+	})
+
+	require.True(t, registry.Has("stub"))
+	require.False(t, registry.Has("unknown"))
+	require.Equal(t, []string{"broken", "stub"}, registry.Names())
+}
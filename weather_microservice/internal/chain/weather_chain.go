@@ -3,34 +3,73 @@ package chain
 import (
 	"context"
 	"fmt"
+	"log"
+	"strings"
+	"time"
+	"weather_microservice/internal/apierrors"
 	"weather_microservice/internal/contracts"
 )
 
+// recordTimeout bounds persisting one weather observation.
+const recordTimeout = 5 * time.Second
+
 // WeatherHandler defines the interface for weather handlers in the chain.
 type WeatherHandler interface {
 	SetNext(handler WeatherHandler) WeatherHandler
-	Handle(ctx context.Context, city string) (contracts.WeatherData, error)
+	Handle(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error)
+	HandleForecast(ctx context.Context, loc contracts.Location, days int) (contracts.ForecastData, error)
 	GetProviderName() string
 }
 
 // BaseWeatherHandler provides common functionality for all handlers.
 type BaseWeatherHandler struct {
-	next WeatherHandler
-	api  WeatherAPIProvider
-	name string
+	next    WeatherHandler
+	api     WeatherAPIProvider
+	name    string
+	metrics Metrics
+	quota   Quota
 }
 
 type WeatherAPIProvider interface {
-	FetchWeather(ctx context.Context, city string) (contracts.WeatherData, error)
+	FetchWeather(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error)
+	FetchForecast(ctx context.Context, loc contracts.Location, days int) (contracts.ForecastData, error)
+}
+
+// GeocodingProvider is implemented by providers that can search cities by name.
+type GeocodingProvider interface {
+	// SearchCities returns at most limit places matching query, best match first.
+	SearchCities(ctx context.Context, query string, limit int) ([]contracts.City, error)
+}
+
+// Quota tracks provider call budgets.
+type Quota interface {
+	// Allow reports whether the provider has budget left for one more call.
+	Allow(ctx context.Context, provider string) bool
+	// Record counts a call made to the provider.
+	Record(ctx context.Context, provider string)
 }
 
 func NewBaseWeatherHandler(api WeatherAPIProvider, name string) *BaseWeatherHandler {
 	return &BaseWeatherHandler{
-		api:  api,
-		name: name,
+		api:     api,
+		name:    name,
+		metrics: NoopMetrics{},
 	}
 }
 
+// SetMetrics enables provider latency, error and fallback metrics.
+func (h *BaseWeatherHandler) SetMetrics(metrics Metrics) {
+	if metrics != nil {
+		h.metrics = metrics
+	}
+}
+
+// SetQuota counts every call to the provider and skips it, falling back to
+// the next handler, once its call budget is exhausted.
+func (h *BaseWeatherHandler) SetQuota(quota Quota) {
+	h.quota = quota
+}
+
 func (h *BaseWeatherHandler) SetNext(handler WeatherHandler) WeatherHandler {
 	h.next = handler
 	return handler
@@ -40,37 +79,113 @@ func (h *BaseWeatherHandler) GetProviderName() string {
 	return h.name
 }
 
-func (h *BaseWeatherHandler) Handle(ctx context.Context, city string) (contracts.WeatherData, error) {
-	data, err := h.api.FetchWeather(ctx, city)
+func (h *BaseWeatherHandler) Handle(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error) {
+	data, err := h.fetchWeather(ctx, loc)
+	if err != nil {
+		if h.next != nil && fallbackEnabled(ctx) {
+			h.metrics.IncProviderFallbacks(h.name, "weather")
+			return h.next.Handle(ctx, loc)
+		}
+		return contracts.WeatherData{}, fmt.Errorf("%w, last error from %s: %w", apierrors.ErrAllProvidersFailed, h.name, err)
+	}
+	if data.Provider == "" {
+		data.Provider = h.name
+	}
+	return data, nil
+}
+
+func (h *BaseWeatherHandler) HandleForecast(ctx context.Context, loc contracts.Location, days int) (contracts.ForecastData, error) {
+	data, err := h.fetchForecast(ctx, loc, days)
+	if err != nil {
+		if h.next != nil && fallbackEnabled(ctx) {
+			h.metrics.IncProviderFallbacks(h.name, "forecast")
+			return h.next.HandleForecast(ctx, loc, days)
+		}
+		return contracts.ForecastData{}, fmt.Errorf("%w, last error from %s: %w", apierrors.ErrAllProvidersFailed, h.name, err)
+	}
+	return data, nil
+}
+
+// fetchWeather asks the provider unless its call budget is exhausted.
+func (h *BaseWeatherHandler) fetchWeather(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error) {
+	if h.quota != nil && !h.quota.Allow(ctx, h.name) {
+		return contracts.WeatherData{}, apierrors.ErrProviderBudgetExhausted
+	}
+
+	start := time.Now()
+	data, err := h.api.FetchWeather(ctx, loc)
+	h.metrics.ObserveProviderRequest(h.name, "weather", time.Since(start), err)
+	if h.quota != nil {
+		h.quota.Record(ctx, h.name)
+	}
 	// Logging result every provider
 	if logger := ctx.Value(weatherLoggerKey); logger != nil {
 		if wl, ok := logger.(WeatherLogger); ok {
 			wl.LogResponse(h.name, data, err)
 		}
 	}
+	return data, err
+}
 
-	if err != nil {
-		if h.next != nil {
-			return h.next.Handle(ctx, city)
+// fetchForecast asks the provider unless its call budget is exhausted.
+func (h *BaseWeatherHandler) fetchForecast(ctx context.Context, loc contracts.Location, days int) (contracts.ForecastData, error) {
+	if h.quota != nil && !h.quota.Allow(ctx, h.name) {
+		return contracts.ForecastData{}, apierrors.ErrProviderBudgetExhausted
+	}
+
+	start := time.Now()
+	data, err := h.api.FetchForecast(ctx, loc, days)
+	h.metrics.ObserveProviderRequest(h.name, "forecast", time.Since(start), err)
+	if h.quota != nil {
+		h.quota.Record(ctx, h.name)
+	}
+	if logger := ctx.Value(weatherLoggerKey); logger != nil {
+		if wl, ok := logger.(WeatherLogger); ok {
+			wl.LogForecastResponse(h.name, data, err)
 		}
-		return contracts.WeatherData{}, fmt.Errorf("all weather providers failed, last error from %s: %w", h.name, err)
 	}
-	return data, nil
+	return data, err
 }
 
+// Strategy defines how the chain queries its providers.
+type Strategy string
+
+const (
+	// StrategySequential asks providers one by one, falling back on failure.
+	StrategySequential Strategy = "sequential"
+	// StrategyRace asks providers concurrently (or hedged, see SetStrategy)
+	// and returns the first successful answer.
+	StrategyRace Strategy = "race"
+	// StrategyConsensus asks all providers concurrently and merges their
+	// answers, discarding outliers. Forecasts are fetched sequentially.
+	StrategyConsensus Strategy = "consensus"
+)
+
 // WeatherChain manages the chain of weather providers.
 type WeatherChain struct {
-	firstHandler WeatherHandler
-	logger       WeatherLogger
+	firstHandler     WeatherHandler
+	handlers         []WeatherHandler
+	logger           WeatherLogger
+	strategy         Strategy
+	hedgeDelay       time.Duration
+	history          contracts.HistoryStore
+	consensusOptions ConsensusOptions
+	metrics          Metrics
+	geocoders        []namedGeocoder
+	quota            Quota
 }
 
 type WeatherLogger interface {
 	LogResponse(provider string, data contracts.WeatherData, err error)
+	LogForecastResponse(provider string, data contracts.ForecastData, err error)
 }
 
 func NewWeatherChain(logger WeatherLogger) *WeatherChain {
 	return &WeatherChain{
-		logger: logger,
+		logger:           logger,
+		strategy:         StrategySequential,
+		consensusOptions: DefaultConsensusOptions,
+		metrics:          NoopMetrics{},
 	}
 }
 
@@ -78,11 +193,56 @@ func (c *WeatherChain) SetFirstHandler(handler WeatherHandler) {
 	c.firstHandler = handler
 }
 
+// AddHandler appends a handler to the end of the chain.
+func (c *WeatherChain) AddHandler(handler WeatherHandler) {
+	if len(c.handlers) == 0 {
+		c.firstHandler = handler
+	} else {
+		c.handlers[len(c.handlers)-1].SetNext(handler)
+	}
+	c.handlers = append(c.handlers, handler)
+}
+
+// SetStrategy switches how providers are queried. In race mode a zero
+// hedgeDelay starts all providers at once; a positive one starts the next
+// provider only after the delay or as soon as the previous one fails.
+// Race mode needs the handlers to be added with AddHandler.
+func (c *WeatherChain) SetStrategy(strategy Strategy, hedgeDelay time.Duration) {
+	c.strategy = strategy
+	c.hedgeDelay = hedgeDelay
+}
+
+// SetConsensusOptions sets the outlier thresholds of StrategyConsensus.
+func (c *WeatherChain) SetConsensusOptions(opts ConsensusOptions) {
+	c.consensusOptions = opts
+}
+
+// SetMetrics enables chain-level metrics such as consensus outliers.
+func (c *WeatherChain) SetMetrics(metrics Metrics) {
+	if metrics != nil {
+		c.metrics = metrics
+	}
+}
+
+// SetQuota counts geocoding calls against provider call budgets and skips
+// geocoders whose budget is exhausted. Weather handlers get their own, see
+// BaseWeatherHandler.SetQuota.
+func (c *WeatherChain) SetQuota(quota Quota) {
+	c.quota = quota
+}
+
+// SetHistory enables persisting every successful GetWeather result as an
+// observation. Observations are written in the background, so a slow store
+// does not delay responses.
+func (c *WeatherChain) SetHistory(history contracts.HistoryStore) {
+	c.history = history
+}
+
 type weatherLoggerKeyType struct{}
 
 var weatherLoggerKey = weatherLoggerKeyType{}
 
-func (c *WeatherChain) GetWeather(ctx context.Context, city string) (contracts.WeatherData, error) {
+func (c *WeatherChain) GetWeather(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error) {
 	if c.firstHandler == nil {
 		return contracts.WeatherData{}, fmt.Errorf("no weather providers configured")
 	}
@@ -90,6 +250,66 @@ func (c *WeatherChain) GetWeather(ctx context.Context, city string) (contracts.W
 	// Insert logger in context using a custom key type.
 	ctx = context.WithValue(ctx, weatherLoggerKey, c.logger)
 
-	return c.firstHandler.Handle(ctx, city)
+	var (
+		data contracts.WeatherData
+		err  error
+	)
+	switch {
+	case c.strategy == StrategyConsensus && len(c.handlers) > 1:
+		data, err = c.consensus(ctx, loc)
+	case c.strategy == StrategyRace && len(c.handlers) > 1:
+		data, err = race(ctx, c.handlers, c.hedgeDelay, func(ctx context.Context, h WeatherHandler) (contracts.WeatherData, error) {
+			return h.Handle(ctx, loc)
+		})
+	default:
+		data, err = c.firstHandler.Handle(ctx, loc)
+	}
+	if err != nil {
+		return contracts.WeatherData{}, err
+	}
+	c.record(loc, data)
+	return data, nil
+}
+
+// record stores the weather as an observation when history is enabled. It is
+// dated when the provider measured it, or now if the provider did not say.
+func (c *WeatherChain) record(loc contracts.Location, data contracts.WeatherData) {
+	if c.history == nil {
+		return
+	}
+	observedAt := data.ObservedAt
+	if observedAt.IsZero() {
+		observedAt = time.Now()
+	}
+	obs := contracts.Observation{
+		City:        strings.TrimSpace(loc.String()),
+		Provider:    data.Provider,
+		ObservedAt:  observedAt.UTC(),
+		Temperature: data.Temperature,
+		Humidity:    data.Humidity,
+		Description: data.Description,
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), recordTimeout)
+		defer cancel()
+		if err := c.history.Record(ctx, loc, obs); err != nil {
+			log.Printf("Failed to record weather observation for %s: %v", loc, err)
+		}
+	}()
 }
 
+func (c *WeatherChain) GetForecast(ctx context.Context, loc contracts.Location, days int) (contracts.ForecastData, error) {
+	if c.firstHandler == nil {
+		return contracts.ForecastData{}, fmt.Errorf("no weather providers configured")
+	}
+
+	ctx = context.WithValue(ctx, weatherLoggerKey, c.logger)
+
+	if c.strategy == StrategyRace && len(c.handlers) > 1 {
+		return race(ctx, c.handlers, c.hedgeDelay, func(ctx context.Context, h WeatherHandler) (contracts.ForecastData, error) {
+			return h.HandleForecast(ctx, loc, days)
+		})
+	}
+
+	return c.firstHandler.HandleForecast(ctx, loc, days)
+}
@@ -0,0 +1,149 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+)
+
+// recordingMetrics remembers provider requests and fallbacks.
+type recordingMetrics struct {
+	NoopMetrics
+	requests  []string
+	fallbacks []string
+}
+
+func (m *recordingMetrics) ObserveProviderRequest(provider, operation string, duration time.Duration, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	m.requests = append(m.requests, provider+"/"+operation+"/"+outcome)
+}
+
+func (m *recordingMetrics) IncProviderFallbacks(provider, operation string) {
+	m.fallbacks = append(m.fallbacks, provider+"/"+operation)
+}
+
+func TestBaseWeatherHandler_RecordsProviderMetrics(t *testing.T) {
+	metrics := &recordingMetrics{}
+	primary := NewBaseWeatherHandler(&stubProvider{err: errors.New("timeout")}, "primary")
+	primary.SetMetrics(metrics)
+	fallback := NewBaseWeatherHandler(&stubProvider{data: contracts.WeatherData{Description: "from fallback"}}, "fallback")
+	fallback.SetMetrics(metrics)
+
+	weatherChain := NewWeatherChain(nil)
+	weatherChain.AddHandler(primary)
+	weatherChain.AddHandler(fallback)
+
+	data, err := weatherChain.GetWeather(context.Background(), contracts.CityLocation("Kyiv"))
+	require.NoError(t, err)
+	require.Equal(t, "from fallback", data.Description)
+	require.Equal(t, []string{"primary/weather/error", "fallback/weather/success"}, metrics.requests)
+	require.Equal(t, []string{"primary/weather"}, metrics.fallbacks)
+}
+
+// stubQuota skips exhausted providers and remembers recorded calls.
+type stubQuota struct {
+	exhausted map[string]bool
+	recorded  []string
+}
+
+func (q *stubQuota) Allow(ctx context.Context, provider string) bool {
+	return !q.exhausted[provider]
+}
+
+func (q *stubQuota) Record(ctx context.Context, provider string) {
+	q.recorded = append(q.recorded, provider)
+}
+
+func TestBaseWeatherHandler_SkipsExhaustedBudget(t *testing.T) {
+	quota := &stubQuota{exhausted: map[string]bool{"primary": true}}
+	primaryAPI := &stubProvider{data: contracts.WeatherData{Description: "from primary"}}
+	primary := NewBaseWeatherHandler(primaryAPI, "primary")
+	primary.SetQuota(quota)
+	fallback := NewBaseWeatherHandler(&stubProvider{data: contracts.WeatherData{Description: "from fallback"}}, "fallback")
+	fallback.SetQuota(quota)
+
+	// The circuit breaker does not count skipped calls as failures.
+	cb := NewCircuitBreakerHandler(primary, CircuitBreakerConfig{FailureThreshold: 1}, NoopMetrics{})
+	weatherChain := NewWeatherChain(nil)
+	weatherChain.AddHandler(cb)
+	weatherChain.AddHandler(fallback)
+
+	for range 2 {
+		data, err := weatherChain.GetWeather(context.Background(), contracts.CityLocation("Kyiv"))
+		require.NoError(t, err)
+		require.Equal(t, "from fallback", data.Description)
+	}
+	require.Equal(t, 0, primaryAPI.calls)
+	require.Equal(t, []string{"fallback", "fallback"}, quota.recorded)
+	require.Equal(t, CircuitClosed, cb.State())
+
+	quota.exhausted["fallback"] = true
+	_, err := weatherChain.GetForecast(context.Background(), contracts.CityLocation("Kyiv"), 3)
+	require.ErrorIs(t, err, apierrors.ErrAllProvidersFailed)
+	require.ErrorIs(t, err, apierrors.ErrProviderBudgetExhausted)
+}
+
+type recordingHistory struct {
+	recorded chan contracts.Observation
+}
+
+func (h *recordingHistory) Record(ctx context.Context, loc contracts.Location, obs contracts.Observation) error {
+	h.recorded <- obs
+	return nil
+}
+
+func (h *recordingHistory) History(ctx context.Context, loc contracts.Location, from, to time.Time, limit int) ([]contracts.Observation, error) {
+	return nil, nil
+}
+
+func (h *recordingHistory) Close() error { return nil }
+
+func TestWeatherChain_RecordsHistory(t *testing.T) {
+	history := &recordingHistory{recorded: make(chan contracts.Observation, 1)}
+	measured := time.Date(2025, 6, 15, 11, 45, 0, 0, time.FixedZone("EEST", 3*60*60))
+	weatherChain := NewWeatherChain(nil)
+	weatherChain.AddHandler(NewBaseWeatherHandler(&stubProvider{data: contracts.WeatherData{Temperature: 21, Description: "Clear", ObservedAt: measured}}, "primary"))
+	weatherChain.SetHistory(history)
+
+	data, err := weatherChain.GetWeather(context.Background(), contracts.CityLocation("Kyiv"))
+	require.NoError(t, err)
+	require.Equal(t, "primary", data.Provider)
+
+	select {
+	case obs := <-history.recorded:
+		require.Equal(t, "Kyiv", obs.City)
+		require.Equal(t, "primary", obs.Provider)
+		require.Equal(t, 21.0, obs.Temperature)
+		require.Equal(t, time.Date(2025, 6, 15, 8, 45, 0, 0, time.UTC), obs.ObservedAt)
+	case <-time.After(time.Second):
+		t.Fatal("observation was not recorded")
+	}
+}
+
+func TestWeatherChain_RecordsHistoryAtNowWithoutProviderTime(t *testing.T) {
+	history := &recordingHistory{recorded: make(chan contracts.Observation, 1)}
+	weatherChain := NewWeatherChain(nil)
+	weatherChain.AddHandler(NewBaseWeatherHandler(&stubProvider{data: contracts.WeatherData{Temperature: 21}}, "primary"))
+	weatherChain.SetHistory(history)
+
+	before := time.Now()
+	_, err := weatherChain.GetWeather(context.Background(), contracts.CityLocation("Kyiv"))
+	require.NoError(t, err)
+
+	select {
+	case obs := <-history.recorded:
+		require.False(t, obs.ObservedAt.Before(before.Truncate(time.Second)))
+		require.Equal(t, time.UTC, obs.ObservedAt.Location())
+	case <-time.After(time.Second):
+		t.Fatal("observation was not recorded")
+	}
+}
@@ -1,8 +1,12 @@
 package client
 
 import (
+	"context"
 	"net/http"
 
+	"connectrpc.com/connect"
+
+	subscriptionv1 "weather_microservice/gen/go/subscription/v1"
 	subpb "weather_microservice/gen/go/subscription/v1/subscriptionv1connect"
 )
 
@@ -18,3 +22,14 @@ func NewSubscriptionClient(baseURL string) *SubscriptionClient {
 		),
 	}
 }
+
+// ListCities returns distinct cities of confirmed subscriptions for a frequency.
+func (c *SubscriptionClient) ListCities(ctx context.Context, frequency string) ([]string, error) {
+	resp, err := c.Client.ListCities(ctx, connect.NewRequest(&subscriptionv1.ListCitiesRequest{
+		Frequency: frequency,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg.Cities, nil
+}
@@ -17,13 +17,124 @@ type Config struct {
 	SubscriptionServiceURL string
 	NATSUrl                string
 	Environment            string
+	AdminToken             string
 	Cache                  CacheConfig
+	CircuitBreaker         CircuitBreakerConfig
+	Warmup                 WarmupConfig
+	Chain                  ChainConfig
+	History                HistoryConfig
+	RateLimit              RateLimitConfig
+	Auth                   AuthConfig
+	ProviderQuota          ProviderQuotaConfig
+	Providers              []ProviderConfig
 }
 
 type CacheConfig struct {
+	Enabled            bool
+	Expiration         time.Duration
+	ForecastExpiration time.Duration
+	// NotFoundExpiration — скільки кешувати міста, яких не знає жоден провайдер.
+	NotFoundExpiration time.Duration
+	// StaleWhileRevalidate — скільки після Expiration віддавати застарілі дані,
+	// оновлюючи їх у фоні.
+	StaleWhileRevalidate time.Duration
+	// StaleIfError — скільки після Expiration віддавати застарілі дані,
+	// якщо всі провайдери недоступні.
+	StaleIfError time.Duration
+	// CitiesExpiration — скільки кешувати результати пошуку міст.
+	CitiesExpiration time.Duration
+	Memory           MemoryCacheConfig
+	Redis            RedisConfig
+}
+
+// MemoryCacheConfig — налаштування in-memory рівня кешу перед Redis.
+type MemoryCacheConfig struct {
 	Enabled    bool
-	Expiration time.Duration
-	Redis      RedisConfig
+	MaxEntries int
+	TTL        time.Duration
+}
+
+// WarmupConfig — налаштування прогріву кешу перед розсилками планувальника.
+type WarmupConfig struct {
+	Enabled     bool
+	Lead        time.Duration
+	DailyHour   int
+	Concurrency int
+}
+
+// HistoryConfig — налаштування збереження історії спостережень погоди в Postgres.
+type HistoryConfig struct {
+	Enabled     bool
+	DatabaseURL string
+}
+
+// RateLimitConfig — обмеження частоти запитів до HTTP API для кожного клієнта.
+// Вимкнене за замовчуванням: планувальник отримує погоду для всіх підписок
+// з однієї IP-адреси на початку години і впирався б у ліміти.
+type RateLimitConfig struct {
+	Enabled bool
+	// Store — де зберігаються лічильники: "redis" (спільно для всіх реплік,
+	// типово) або "memory" (окремо в кожній репліці). Без кешу Redis не
+	// використовується, і лічильники лишаються в пам'яті.
+	Store string
+	// TrustProxy — визначати IP клієнта за X-Forwarded-For.
+	TrustProxy bool
+	// Routes — ліміти за назвою маршруту.
+	Routes map[string]RateLimitRule
+}
+
+// RateLimitRule — ліміт маршруту: PerMinute запитів за хвилину з піковими Burst.
+// Нульове значення вимикає обмеження.
+type RateLimitRule struct {
+	PerMinute int
+	Burst     int
+}
+
+// AuthConfig — автентифікація клієнтів публічного API за API ключами.
+type AuthConfig struct {
+	Enabled bool
+	// KeyStore — джерело ключів: "file" (JSON файл KeysFile) або "postgres".
+	KeyStore    string
+	KeysFile    string
+	DatabaseURL string
+}
+
+// ProviderQuotaConfig — облік викликів провайдерів погоди за добу та місяць.
+// Бюджети задаються для кожного провайдера, див. ProviderConfig.
+type ProviderQuotaConfig struct {
+	Enabled bool
+	// Store — де зберігаються лічильники: "memory" (окремо в кожній репліці)
+	// або "redis" (спільно для всіх реплік).
+	Store string
+}
+
+type CircuitBreakerConfig struct {
+	Enabled          bool
+	FailureThreshold int
+	SuccessThreshold int
+	CoolDown         time.Duration
+}
+
+type ChainConfig struct {
+	Strategy   string
+	HedgeDelay time.Duration
+	// Пороги відхилення від медіани, після яких відповідь провайдера
+	// вважається викидом у режимі consensus: °C та відсоткові пункти вологості.
+	OutlierTemperature float64
+	OutlierHumidity    float64
+}
+
+// ProviderConfig describes one link of the weather provider chain.
+type ProviderConfig struct {
+	Name    string
+	Enabled bool
+	APIKey  string
+	Timeout time.Duration
+	// DailyBudget and MonthlyBudget limit calls to the provider per UTC day
+	// and month; the provider is skipped once either is used up. Zero means
+	// no limit.
+	DailyBudget   int
+	MonthlyBudget int
 }
 
 type RedisConfig struct {
@@ -47,13 +158,29 @@ func Load() *Config {
 		expirationMinutes = 10
 	}
 
+	forecastExpirationMinutes, err := strconv.Atoi(getEnv("CACHE_FORECAST_EXPIRATION_MINUTES", "30"))
+	if err != nil {
+		fmt.Printf("Invalid CACHE_FORECAST_EXPIRATION_MINUTES, using default 30 minutes: %v\n", err)
+		forecastExpirationMinutes = 30
+	}
+
 	redisDB, _ := strconv.Atoi(getEnv("REDIS_DB", "0"))
 	redisPoolSize, _ := strconv.Atoi(getEnv("REDIS_POOL_SIZE", "10"))
 	redisTimeoutSec, _ := strconv.Atoi(getEnv("REDIS_TIMEOUT_SECONDS", "5"))
 
 	cacheConfig := CacheConfig{
-		Enabled:    enabled,
-		Expiration: time.Duration(expirationMinutes) * time.Minute,
+		Enabled:              enabled,
+		Expiration:           time.Duration(expirationMinutes) * time.Minute,
+		ForecastExpiration:   time.Duration(forecastExpirationMinutes) * time.Minute,
+		NotFoundExpiration:   time.Duration(getEnvInt("CACHE_NOT_FOUND_EXPIRATION_MINUTES", 5)) * time.Minute,
+		StaleWhileRevalidate: time.Duration(getEnvInt("CACHE_STALE_WHILE_REVALIDATE_MINUTES", 5)) * time.Minute,
+		StaleIfError:         time.Duration(getEnvInt("CACHE_STALE_IF_ERROR_MINUTES", 60)) * time.Minute,
+		CitiesExpiration:     time.Duration(getEnvInt("CACHE_CITIES_EXPIRATION_HOURS", 24)) * time.Hour,
+		Memory: MemoryCacheConfig{
+			Enabled:    getEnvBool("CACHE_MEMORY_ENABLED", true),
+			MaxEntries: getEnvInt("CACHE_MEMORY_MAX_ENTRIES", 1000),
+			TTL:        time.Duration(getEnvInt("CACHE_MEMORY_TTL_SECONDS", 60)) * time.Second,
+		},
 		Redis: RedisConfig{
 			Addr:     getEnv("REDIS_ADDR", "localhost:6379"),
 			Password: getEnv("REDIS_PASSWORD", ""),
@@ -63,16 +190,64 @@ func Load() *Config {
 		},
 	}
 
+	circuitBreakerConfig := CircuitBreakerConfig{
+		Enabled:          getEnvBool("CIRCUIT_BREAKER_ENABLED", true),
+		FailureThreshold: getEnvInt("CIRCUIT_BREAKER_FAILURE_THRESHOLD", 5),
+		SuccessThreshold: getEnvInt("CIRCUIT_BREAKER_SUCCESS_THRESHOLD", 1),
+		CoolDown:         time.Duration(getEnvInt("CIRCUIT_BREAKER_COOLDOWN_SECONDS", 30)) * time.Second,
+	}
+
+	openWeatherKey := getEnv("OPENWEATHER_API_KEY", "")
+	weatherKey := getEnv("WEATHER_API_KEY", "")
+
 	return &Config{
 		AppBaseURL:             getEnv("APP_BASE_URL", "http://localhost:8080"),
 		Port:                   getEnv("PORT", "8080"),
 		GRPCPort:               getEnv("GRPC_PORT", "8081"),
-		OpenWeatherKey:         getEnv("OPENWEATHER_API_KEY", ""),
-		WeatherKey:             getEnv("WEATHER_API_KEY", ""),
+		OpenWeatherKey:         openWeatherKey,
+		WeatherKey:             weatherKey,
 		SubscriptionServiceURL: getEnv("SUBSCRIPTION_SERVICE_URL", "http://localhost:8091"),
 		NATSUrl:                getEnv("NATS_URL", "nats://localhost:4222"),
 		Environment:            strings.ToLower(getEnv("ENVIRONMENT", "development")),
+		AdminToken:             getEnv("ADMIN_API_TOKEN", ""),
 		Cache:                  cacheConfig,
+		CircuitBreaker:         circuitBreakerConfig,
+		Warmup: WarmupConfig{
+			Enabled:     getEnvBool("CACHE_WARMUP_ENABLED", true),
+			Lead:        time.Duration(getEnvInt("CACHE_WARMUP_LEAD_SECONDS", 120)) * time.Second,
+			DailyHour:   getEnvInt("CACHE_WARMUP_DAILY_HOUR", 8),
+			Concurrency: getEnvInt("CACHE_WARMUP_CONCURRENCY", 5),
+		},
+		Chain: ChainConfig{
+			Strategy:           strings.ToLower(getEnv("WEATHER_CHAIN_STRATEGY", "sequential")),
+			HedgeDelay:         time.Duration(getEnvInt("WEATHER_CHAIN_HEDGE_DELAY_MS", 0)) * time.Millisecond,
+			OutlierTemperature: getEnvFloat("WEATHER_CHAIN_OUTLIER_TEMPERATURE", 5),
+			OutlierHumidity:    getEnvFloat("WEATHER_CHAIN_OUTLIER_HUMIDITY", 20),
+		},
+		History: HistoryConfig{
+			Enabled:     getEnvBool("HISTORY_ENABLED", false),
+			DatabaseURL: getEnv("HISTORY_DATABASE_URL", ""),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:    getEnvBool("RATE_LIMIT_ENABLED", false),
+			Store:      strings.ToLower(getEnv("RATE_LIMIT_STORE", "memory")),
+			TrustProxy: getEnvBool("RATE_LIMIT_TRUST_PROXY", false),
+			Routes:     loadRateLimitRoutes(),
+		},
+		Auth: AuthConfig{
+			Enabled:     getEnvBool("API_AUTH_ENABLED", false),
+			KeyStore:    strings.ToLower(getEnv("API_KEY_STORE", "file")),
+			KeysFile:    getEnv("API_KEYS_FILE", "api_keys.json"),
+			DatabaseURL: getEnv("API_KEYS_DATABASE_URL", ""),
+		},
+		ProviderQuota: ProviderQuotaConfig{
+			Enabled: getEnvBool("PROVIDER_QUOTA_ENABLED", true),
+			Store:   strings.ToLower(getEnv("PROVIDER_QUOTA_STORE", "redis")),
+		},
+		Providers: loadProviders(map[string]string{
+			"openweather": openWeatherKey,
+			"weatherapi":  weatherKey,
+		}),
 	}
 
 }
@@ -104,8 +279,42 @@ func (c *Config) IsTest() bool {
 func (c *Config) Validate() error {
 	var errors []string
 
-	if c.OpenWeatherKey == "" {
-		errors = append(errors, "OPENWEATHER_API_KEY is required")
+	if !c.hasUsableProvider() {
+		errors = append(errors, "at least one enabled weather provider with an API key is required")
+	}
+
+	if c.Chain.Strategy == "consensus" && (c.Chain.OutlierTemperature <= 0 || c.Chain.OutlierHumidity <= 0) {
+		errors = append(errors, "WEATHER_CHAIN_OUTLIER_TEMPERATURE and WEATHER_CHAIN_OUTLIER_HUMIDITY must be positive")
+	}
+
+	if c.History.Enabled && c.History.DatabaseURL == "" {
+		errors = append(errors, "HISTORY_DATABASE_URL is required when HISTORY_ENABLED is true")
+	}
+
+	if c.RateLimit.Enabled && c.RateLimit.Store != "memory" && c.RateLimit.Store != "redis" {
+		errors = append(errors, "RATE_LIMIT_STORE must be memory or redis")
+	}
+
+	if c.ProviderQuota.Enabled && c.ProviderQuota.Store != "memory" && c.ProviderQuota.Store != "redis" {
+		errors = append(errors, "PROVIDER_QUOTA_STORE must be memory or redis")
+	}
+
+	for _, p := range c.Providers {
+		if p.DailyBudget < 0 || p.MonthlyBudget < 0 {
+			errors = append(errors, fmt.Sprintf("call budgets of weather provider %s must not be negative", p.Name))
+		}
+	}
+
+	if c.Auth.Enabled {
+		switch c.Auth.KeyStore {
+		case "file":
+		case "postgres":
+			if c.Auth.DatabaseURL == "" {
+				errors = append(errors, "API_KEYS_DATABASE_URL is required when API_KEY_STORE is postgres")
+			}
+		default:
+			errors = append(errors, "API_KEY_STORE must be file or postgres")
+		}
 	}
 
 	if len(errors) > 0 {
@@ -115,6 +324,91 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// loadProviders читає список провайдерів з WEATHER_PROVIDERS (у порядку опитування)
+// та їхні налаштування з WEATHER_PROVIDER_<NAME>_* змінних.
+// Open-Meteo вмикається лише явно, додаванням "openmeteo" до списку.
+func loadProviders(legacyKeys map[string]string) []ProviderConfig {
+	var providers []ProviderConfig
+	for _, name := range strings.Split(getEnv("WEATHER_PROVIDERS", "openweather,weatherapi"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		prefix := "WEATHER_PROVIDER_" + envName(name) + "_"
+		providers = append(providers, ProviderConfig{
+			Name:          name,
+			Enabled:       getEnvBool(prefix+"ENABLED", true),
+			APIKey:        getEnv(prefix+"API_KEY", legacyKeys[name]),
+			Timeout:       time.Duration(getEnvInt(prefix+"TIMEOUT_SECONDS", 10)) * time.Second,
+			DailyBudget:   getEnvInt(prefix+"DAILY_BUDGET", 0),
+			MonthlyBudget: getEnvInt(prefix+"MONTHLY_BUDGET", 0),
+		})
+	}
+	return providers
+}
+
+// defaultRateLimits — ліміти маршрутів за замовчуванням.
+// Підписка суворіша, бо кожен запит надсилає лист підтвердження, а пошук
+// міст м'якший, бо автодоповнення робить запит на кожне натискання клавіші.
+// grpc — спільний ліміт усіх викликів Connect/gRPC API погоди.
+// auth_failures — невдалі перевірки API ключа з однієї IP-адреси; цей ліміт
+// діє при ввімкненій автентифікації навіть без RATE_LIMIT_ENABLED.
+var defaultRateLimits = map[string]RateLimitRule{
+	"weather":   {PerMinute: 60, Burst: 20},
+	"forecast":  {PerMinute: 60, Burst: 20},
+	"history":   {PerMinute: 30, Burst: 10},
+	"stream":    {PerMinute: 10, Burst: 5},
+	"cities":    {PerMinute: 120, Burst: 30},
+	"subscribe": {PerMinute: 5, Burst: 3},
+	"grpc":      {PerMinute: 60, Burst: 20},
+
+	"auth_failures": {PerMinute: 10, Burst: 20},
+}
+
+// loadRateLimitRoutes читає ліміти маршрутів з RATE_LIMIT_<ROUTE>_PER_MINUTE
+// та RATE_LIMIT_<ROUTE>_BURST.
+func loadRateLimitRoutes() map[string]RateLimitRule {
+	routes := make(map[string]RateLimitRule, len(defaultRateLimits))
+	for name, rule := range defaultRateLimits {
+		prefix := "RATE_LIMIT_" + envName(name) + "_"
+		routes[name] = RateLimitRule{
+			PerMinute: getEnvInt(prefix+"PER_MINUTE", rule.PerMinute),
+			Burst:     getEnvInt(prefix+"BURST", rule.Burst),
+		}
+	}
+	return routes
+}
+
+// keylessProviders — провайдери, яким не потрібен API ключ.
+var keylessProviders = map[string]bool{
+	"openmeteo": true,
+}
+
+// hasUsableProvider перевіряє чи є хоча б один увімкнений провайдер з ключем
+// або провайдер, якому ключ не потрібен.
+// Без явного списку провайдерів перевіряються ключі OpenWeather та WeatherAPI.
+func (c *Config) hasUsableProvider() bool {
+	if len(c.Providers) == 0 {
+		return c.OpenWeatherKey != "" || c.WeatherKey != ""
+	}
+	for _, p := range c.Providers {
+		if p.Enabled && (p.APIKey != "" || keylessProviders[p.Name]) {
+			return true
+		}
+	}
+	return false
+}
+
+// envName перетворює назву провайдера на частину імені змінної оточення.
+func envName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, strings.ToUpper(name))
+}
+
 // getEnv отримує значення змінної оточення або повертає значення за замовчуванням.
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -122,3 +416,35 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvInt отримує цілочисельну змінну оточення або повертає значення за замовчуванням.
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(getEnv(key, strconv.Itoa(defaultValue)))
+	if err != nil {
+		fmt.Printf("Invalid %s, using default %d: %v\n", key, defaultValue, err)
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvFloat отримує дробову змінну оточення або повертає значення за замовчуванням.
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(getEnv(key, strconv.FormatFloat(defaultValue, 'f', -1, 64)), 64)
+	if err != nil {
+		fmt.Printf("Invalid %s, using default %g: %v\n", key, defaultValue, err)
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvBool отримує булеву змінну оточення або повертає значення за замовчуванням.
+func getEnvBool(key string, defaultValue bool) bool {
+	switch strings.ToLower(os.Getenv(key)) {
+	case "true", "1", "yes":
+		return true
+	case "false", "0", "no":
+		return false
+	default:
+		return defaultValue
+	}
+}
@@ -28,6 +28,11 @@ func TestLoad_DefaultValues(t *testing.T) {
 	t.Setenv("OPENWEATHER_API_KEY", "abc123") // to pass validation
 	t.Setenv("CACHE_ENABLED", "")
 	t.Setenv("CACHE_EXPIRATION_MINUTES", "")
+	t.Setenv("CACHE_STALE_WHILE_REVALIDATE_MINUTES", "")
+	t.Setenv("CACHE_STALE_IF_ERROR_MINUTES", "")
+	t.Setenv("CIRCUIT_BREAKER_ENABLED", "")
+	t.Setenv("CIRCUIT_BREAKER_FAILURE_THRESHOLD", "")
+	t.Setenv("CIRCUIT_BREAKER_COOLDOWN_SECONDS", "")
 
 	cfg := Load()
 
@@ -43,6 +48,21 @@ func TestLoad_DefaultValues(t *testing.T) {
 	if cfg.Cache.Enabled {
 		t.Errorf("expected cache to be disabled by default")
 	}
+	if cfg.Cache.StaleWhileRevalidate != 5*time.Minute {
+		t.Errorf("expected stale-while-revalidate 5m, got %v", cfg.Cache.StaleWhileRevalidate)
+	}
+	if cfg.Cache.StaleIfError != time.Hour {
+		t.Errorf("expected stale-if-error 1h, got %v", cfg.Cache.StaleIfError)
+	}
+	if !cfg.CircuitBreaker.Enabled {
+		t.Errorf("expected circuit breaker to be enabled by default")
+	}
+	if cfg.CircuitBreaker.FailureThreshold != 5 {
+		t.Errorf("expected failure threshold 5, got %v", cfg.CircuitBreaker.FailureThreshold)
+	}
+	if cfg.CircuitBreaker.CoolDown != 30*time.Second {
+		t.Errorf("expected cool-down 30s, got %v", cfg.CircuitBreaker.CoolDown)
+	}
 }
 
 func TestLoad_WithOverrides(t *testing.T) {
@@ -91,3 +111,181 @@ func TestLoad_WithOverrides(t *testing.T) {
 		t.Errorf("expected Redis password secret, got %v", cfg.Cache.Redis.Password)
 	}
 }
+
+func TestLoadProviders_Defaults(t *testing.T) {
+	t.Setenv("WEATHER_PROVIDERS", "")
+	t.Setenv("WEATHER_PROVIDER_OPENWEATHER_ENABLED", "")
+	t.Setenv("WEATHER_PROVIDER_OPENWEATHER_API_KEY", "")
+	t.Setenv("WEATHER_PROVIDER_OPENWEATHER_TIMEOUT_SECONDS", "")
+	t.Setenv("WEATHER_PROVIDER_WEATHERAPI_API_KEY", "")
+
+	providers := loadProviders(map[string]string{"openweather": "legacy-key"})
+
+	if len(providers) != 2 {
+		t.Fatalf("expected 2 providers, got %d", len(providers))
+	}
+	if providers[0].Name != "openweather" || providers[1].Name != "weatherapi" {
+		t.Errorf("unexpected provider order: %v, %v", providers[0].Name, providers[1].Name)
+	}
+	if !providers[0].Enabled {
+		t.Errorf("expected openweather to be enabled by default")
+	}
+	if providers[0].APIKey != "legacy-key" {
+		t.Errorf("expected legacy API key fallback, got %q", providers[0].APIKey)
+	}
+	if providers[0].Timeout != 10*time.Second {
+		t.Errorf("expected timeout 10s, got %v", providers[0].Timeout)
+	}
+}
+
+func TestLoad_DefaultProvidersRequireAPIKey(t *testing.T) {
+	t.Setenv("WEATHER_PROVIDERS", "")
+	t.Setenv("OPENWEATHER_API_KEY", "")
+	t.Setenv("WEATHER_API_KEY", "")
+	t.Setenv("WEATHER_PROVIDER_OPENWEATHER_API_KEY", "")
+	t.Setenv("WEATHER_PROVIDER_WEATHERAPI_API_KEY", "")
+
+	if err := Load().Validate(); err == nil {
+		t.Errorf("expected error when no API key is configured")
+	}
+}
+
+func TestLoadProviders_WithOverrides(t *testing.T) {
+	t.Setenv("WEATHER_PROVIDERS", " WeatherAPI , openweather,,")
+	t.Setenv("WEATHER_PROVIDER_WEATHERAPI_API_KEY", "wa-key")
+	t.Setenv("WEATHER_PROVIDER_WEATHERAPI_TIMEOUT_SECONDS", "3")
+	t.Setenv("WEATHER_PROVIDER_WEATHERAPI_DAILY_BUDGET", "950")
+	t.Setenv("WEATHER_PROVIDER_WEATHERAPI_MONTHLY_BUDGET", "")
+	t.Setenv("WEATHER_PROVIDER_OPENWEATHER_ENABLED", "false")
+
+	providers := loadProviders(map[string]string{"weatherapi": "legacy-key"})
+
+	if len(providers) != 2 {
+		t.Fatalf("expected 2 providers, got %d", len(providers))
+	}
+	if providers[0].Name != "weatherapi" {
+		t.Errorf("expected weatherapi first, got %v", providers[0].Name)
+	}
+	if providers[0].APIKey != "wa-key" {
+		t.Errorf("expected per-provider API key, got %q", providers[0].APIKey)
+	}
+	if providers[0].Timeout != 3*time.Second {
+		t.Errorf("expected timeout 3s, got %v", providers[0].Timeout)
+	}
+	if providers[0].DailyBudget != 950 || providers[0].MonthlyBudget != 0 {
+		t.Errorf("expected daily budget 950 and no monthly budget, got %d and %d", providers[0].DailyBudget, providers[0].MonthlyBudget)
+	}
+	if providers[1].Enabled {
+		t.Errorf("expected openweather to be disabled")
+	}
+}
+
+func TestConfig_Validate_Providers(t *testing.T) {
+	cfg := &Config{Providers: []ProviderConfig{
+		{Name: "openweather", Enabled: false, APIKey: "abc"},
+		{Name: "weatherapi", Enabled: true},
+	}}
+	if err := cfg.Validate(); err == nil {
+		t.Errorf("expected error when no enabled provider has an API key")
+	}
+
+	cfg.Providers[1].APIKey = "xyz"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+}
+
+func TestConfig_Validate_KeylessProvider(t *testing.T) {
+	cfg := &Config{Providers: []ProviderConfig{
+		{Name: "openweather", Enabled: true},
+		{Name: "openmeteo", Enabled: true},
+	}}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected keyless provider to pass validation, got: %v", err)
+	}
+
+	cfg.Providers[1].Enabled = false
+	if err := cfg.Validate(); err == nil {
+		t.Errorf("expected error when keyless provider is disabled")
+	}
+}
+
+func TestConfig_Validate_History(t *testing.T) {
+	cfg := &Config{OpenWeatherKey: "abc123", History: HistoryConfig{Enabled: true}}
+	if err := cfg.Validate(); err == nil {
+		t.Errorf("expected error when history is enabled without a database URL")
+	}
+
+	cfg.History.DatabaseURL = "postgres://localhost/weather"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+}
+
+func TestLoad_ConsensusChain(t *testing.T) {
+	t.Setenv("WEATHER_CHAIN_STRATEGY", "Consensus")
+	t.Setenv("WEATHER_CHAIN_OUTLIER_TEMPERATURE", "2.5")
+
+	cfg := Load()
+	if cfg.Chain.Strategy != "consensus" || cfg.Chain.OutlierTemperature != 2.5 || cfg.Chain.OutlierHumidity != 20 {
+		t.Errorf("unexpected chain config: %+v", cfg.Chain)
+	}
+
+	cfg.OpenWeatherKey = "abc123"
+	cfg.Chain.OutlierHumidity = 0
+	if err := cfg.Validate(); err == nil {
+		t.Errorf("expected error for non-positive outlier threshold")
+	}
+}
+
+func TestLoad_ProviderQuotaStore(t *testing.T) {
+	t.Setenv("PROVIDER_QUOTA_STORE", "")
+	if got := Load().ProviderQuota.Store; got != "redis" {
+		t.Errorf("expected redis provider quota store by default, got %q", got)
+	}
+}
+
+func TestLoad_RateLimit(t *testing.T) {
+	t.Setenv("RATE_LIMIT_ENABLED", "")
+	if Load().RateLimit.Enabled {
+		t.Errorf("expected rate limiting to be disabled by default")
+	}
+
+	t.Setenv("RATE_LIMIT_ENABLED", "true")
+	t.Setenv("RATE_LIMIT_STORE", "Redis")
+	t.Setenv("RATE_LIMIT_SUBSCRIBE_PER_MINUTE", "2")
+
+	cfg := Load()
+	if !cfg.RateLimit.Enabled || cfg.RateLimit.Store != "redis" {
+		t.Errorf("unexpected rate limit config: %+v", cfg.RateLimit)
+	}
+	if got := cfg.RateLimit.Routes["subscribe"]; got != (RateLimitRule{PerMinute: 2, Burst: 3}) {
+		t.Errorf("unexpected subscribe limit: %+v", got)
+	}
+	if got := cfg.RateLimit.Routes["weather"]; got != (RateLimitRule{PerMinute: 60, Burst: 20}) {
+		t.Errorf("unexpected weather limit: %+v", got)
+	}
+
+	cfg.OpenWeatherKey = "abc123"
+	cfg.RateLimit.Store = "memcached"
+	if err := cfg.Validate(); err == nil {
+		t.Errorf("expected error for unknown rate limit store")
+	}
+}
+
+func TestConfig_Validate_Auth(t *testing.T) {
+	cfg := &Config{OpenWeatherKey: "abc123", Auth: AuthConfig{Enabled: true, KeyStore: "postgres"}}
+	if err := cfg.Validate(); err == nil {
+		t.Errorf("expected error when the postgres key store has no database URL")
+	}
+
+	cfg.Auth.KeyStore = "ldap"
+	if err := cfg.Validate(); err == nil {
+		t.Errorf("expected error for unknown key store")
+	}
+
+	cfg.Auth.KeyStore = "file"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+}
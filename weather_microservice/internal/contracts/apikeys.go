@@ -0,0 +1,39 @@
+package contracts
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// Області доступу API ключів.
+const (
+	ScopeWeatherRead = "weather:read"
+	ScopeSubscribe   = "subscribe"
+)
+
+// APIKey — ключ клієнта публічного API. Сам секрет не зберігається, лише його хеш.
+type APIKey struct {
+	ID     string   `json:"id"`
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	// DailyQuota — скільки запитів на добу (UTC) дозволено ключу, 0 — без обмежень.
+	DailyQuota int64 `json:"daily_quota"`
+}
+
+// HasScope перевіряє чи ключ має область доступу.
+func (k APIKey) HasScope(scope string) bool {
+	return slices.Contains(k.Scopes, scope)
+}
+
+// APIKeyStore визначає інтерфейс сховища API ключів та їхнього використання.
+type APIKeyStore interface {
+	// Lookup повертає ключ за SHA-256 хешем секрету (hex)
+	// або apierrors.ErrInvalidAPIKey, якщо такого ключа немає.
+	Lookup(ctx context.Context, hash string) (APIKey, error)
+	// IncrementUsage рахує один запит ключа за добу day і повертає загальну кількість за цю добу.
+	IncrementUsage(ctx context.Context, keyID string, day time.Time) (int64, error)
+	// Usage повертає кількість запитів ключа за добу day.
+	Usage(ctx context.Context, keyID string, day time.Time) (int64, error)
+	Close() error
+}
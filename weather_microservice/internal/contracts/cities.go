@@ -0,0 +1,55 @@
+package contracts
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// City — кандидат у результатах пошуку міст.
+type City struct {
+	// ID — канонічний ідентифікатор міста, наприклад "kyiv,ua". Його можна
+	// передавати як назву міста в запитах погоди та підписках.
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Region      string  `json:"region,omitempty"`
+	Country     string  `json:"country,omitempty"`
+	CountryCode string  `json:"country_code"`
+	Lat         float64 `json:"lat"`
+	Lon         float64 `json:"lon"`
+}
+
+// CityID повертає канонічний ідентифікатор міста за назвою та ISO-кодом країни.
+func CityID(name, countryCode string) string {
+	return Location{City: name, CountryCode: countryCode}.Key()
+}
+
+// SplitCityID розбирає ідентифікатор виду "kyiv,ua" на назву та код країни.
+// Для звичайної назви міста код країни порожній.
+func SplitCityID(id string) (name, countryCode string) {
+	i := strings.LastIndex(id, ",")
+	if i < 0 {
+		return id, ""
+	}
+	code := strings.TrimSpace(id[i+1:])
+	if len(code) != 2 || !isLetters(code) {
+		return id, ""
+	}
+	return strings.TrimSpace(id[:i]), code
+}
+
+func isLetters(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// CityCache визначає інтерфейс для кешування результатів пошуку міст.
+type CityCache interface {
+	// GetCities повертає закешовані результати запиту або помилку кеш-місу.
+	GetCities(ctx context.Context, query string, limit int) ([]City, error)
+	SetCities(ctx context.Context, query string, limit int, cities []City, expiration time.Duration) error
+}
@@ -0,0 +1,62 @@
+package contracts
+
+import "strings"
+
+// Condition — канонічний код погодних умов, однаковий для всіх провайдерів.
+type Condition string
+
+const (
+	ConditionUnknown      Condition = "unknown"
+	ConditionClear        Condition = "clear"
+	ConditionPartlyCloudy Condition = "partly_cloudy"
+	ConditionCloudy       Condition = "cloudy"
+	ConditionFog          Condition = "fog"
+	ConditionHaze         Condition = "haze"
+	ConditionDrizzle      Condition = "drizzle"
+	ConditionRain         Condition = "rain"
+	ConditionFreezingRain Condition = "freezing_rain"
+	ConditionSleet        Condition = "sleet"
+	ConditionSnow         Condition = "snow"
+	ConditionThunderstorm Condition = "thunderstorm"
+	ConditionSquall       Condition = "squall"
+)
+
+// Severity — наскільки погодні умови небезпечні або незручні.
+type Severity string
+
+const (
+	SeverityNone     Severity = "none"
+	SeverityMinor    Severity = "minor"
+	SeverityModerate Severity = "moderate"
+	SeveritySevere   Severity = "severe"
+)
+
+// severityRanks впорядковує рівні серйозності для порівняння.
+var severityRanks = map[Severity]int{
+	SeverityNone:     0,
+	SeverityMinor:    1,
+	SeverityModerate: 2,
+	SeveritySevere:   3,
+}
+
+// Rank повертає порядковий номер рівня; невідомий рівень дорівнює SeverityNone.
+func (s Severity) Rank() int {
+	return severityRanks[s]
+}
+
+// ConditionIcon повертає ідентифікатор іконки для умов, наприклад
+// "freezing-rain". Ясне небо та мінлива хмарність мають окремі денні й нічні
+// варіанти: "clear-day", "partly-cloudy-night".
+func ConditionIcon(c Condition, isDay bool) string {
+	if c == "" {
+		c = ConditionUnknown
+	}
+	icon := strings.ReplaceAll(string(c), "_", "-")
+	if c == ConditionClear || c == ConditionPartlyCloudy {
+		if isDay {
+			return icon + "-day"
+		}
+		return icon + "-night"
+	}
+	return icon
+}
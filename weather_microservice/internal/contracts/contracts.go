@@ -1,5 +1,7 @@
 package contracts
 
+import "time"
+
 type EmailSenderProvider interface {
 	Send(to, subject, htmlBody string) error
 }
@@ -9,4 +11,65 @@ type WeatherData struct {
 	Temperature float64 `json:"temperature"`
 	Humidity    float64 `json:"humidity"`
 	Description string  `json:"description"`
+	// Condition and Severity are the provider-independent normalized conditions.
+	Condition Condition `json:"condition"`
+	Severity  Severity  `json:"severity"`
+	// Icon is an icon identifier such as "rain" or "clear-night".
+	Icon string `json:"icon"`
+	// FeelsLike is the apparent temperature, °C.
+	FeelsLike float64 `json:"feels_like"`
+	// WindSpeed is in m/s, WindDirection is in degrees the wind blows from.
+	WindSpeed     float64 `json:"wind_speed"`
+	WindDirection float64 `json:"wind_direction"`
+	// Pressure is the sea-level pressure, hPa.
+	Pressure float64 `json:"pressure"`
+	// Precipitation is the recent rain and snow amount, mm.
+	Precipitation float64 `json:"precipitation"`
+	// CloudCover is the sky coverage, %.
+	CloudCover float64 `json:"cloud_cover"`
+	// UVIndex is nil when the provider does not report it.
+	UVIndex *float64 `json:"uv_index,omitempty"`
+	// Visibility is in kilometers.
+	Visibility float64   `json:"visibility"`
+	Sunrise    time.Time `json:"sunrise"`
+	Sunset     time.Time `json:"sunset"`
+	// ObservedAt is when the provider measured the conditions.
+	ObservedAt time.Time `json:"observed_at"`
+	// UTCOffset is the city's offset from UTC in seconds, so that the times
+	// above can be shown in local time.
+	UTCOffset int `json:"utc_offset"`
+	// FetchedAt is when the data was received from a provider.
+	FetchedAt time.Time `json:"fetched_at"`
+	// Stale is set when the data is served from cache past its expiration.
+	Stale bool `json:"stale"`
+	// Provider is the name of the provider that returned the data.
+	Provider string `json:"provider,omitempty"`
+	// Providers lists the providers merged into consensus data, and Outliers
+	// those whose answers were discarded for disagreeing with the rest.
+	Providers []string `json:"providers,omitempty"`
+	Outliers  []string `json:"outliers,omitempty"`
+}
+
+// HourlyForecast represents a single forecast point.
+type HourlyForecast struct {
+	Time        time.Time `json:"time"`
+	Temperature float64   `json:"temperature"`
+	Humidity    float64   `json:"humidity"`
+	Description string    `json:"description"`
+}
+
+// DailyForecast represents the forecast aggregated for one calendar day.
+type DailyForecast struct {
+	Date           string  `json:"date"` // YYYY-MM-DD in the city's local time.
+	MinTemperature float64 `json:"min_temperature"`
+	MaxTemperature float64 `json:"max_temperature"`
+	Humidity       float64 `json:"humidity"`
+	Description    string  `json:"description"`
+}
+
+// ForecastData represents a multi-day forecast for a city.
+type ForecastData struct {
+	City   string           `json:"city"`
+	Hourly []HourlyForecast `json:"hourly"`
+	Daily  []DailyForecast  `json:"daily"`
 }
@@ -0,0 +1,25 @@
+package contracts
+
+import (
+	"context"
+	"time"
+)
+
+// Observation — одне збережене спостереження погоди від провайдера.
+type Observation struct {
+	City        string    `json:"city"`
+	Provider    string    `json:"provider"`
+	ObservedAt  time.Time `json:"observed_at"`
+	Temperature float64   `json:"temperature"`
+	Humidity    float64   `json:"humidity"`
+	Description string    `json:"description"`
+}
+
+// HistoryStore визначає інтерфейс сховища історичних спостережень погоди.
+type HistoryStore interface {
+	// Record зберігає спостереження для локації.
+	Record(ctx context.Context, loc Location, obs Observation) error
+	// History повертає спостереження з [from, to) за зростанням часу, не більше limit.
+	History(ctx context.Context, loc Location, from, to time.Time, limit int) ([]Observation, error)
+	Close() error
+}
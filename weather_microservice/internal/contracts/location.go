@@ -0,0 +1,65 @@
+package contracts
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Coordinates pins a place by latitude and longitude in decimal degrees.
+type Coordinates struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// Location identifies a place either by city name (optionally narrowed down
+// by an ISO 3166 country code) or by exact coordinates.
+// Coordinates take precedence over the city name when both are set.
+type Location struct {
+	City        string       `json:"city,omitempty"`
+	CountryCode string       `json:"country_code,omitempty"`
+	Coordinates *Coordinates `json:"coordinates,omitempty"`
+}
+
+// CityLocation creates a location from a free-text city name.
+func CityLocation(city string) Location {
+	return Location{City: city}
+}
+
+// CoordinatesLocation creates a location from latitude and longitude.
+func CoordinatesLocation(lat, lon float64) Location {
+	return Location{Coordinates: &Coordinates{Lat: lat, Lon: lon}}
+}
+
+// HasCoordinates reports whether the location is pinned by coordinates.
+func (l Location) HasCoordinates() bool {
+	return l.Coordinates != nil
+}
+
+// IsEmpty reports whether the location carries neither a city nor coordinates.
+func (l Location) IsEmpty() bool {
+	return !l.HasCoordinates() && strings.TrimSpace(l.City) == ""
+}
+
+// Key returns a normalized identifier of the location, suitable for cache keys.
+// Coordinates are rounded to 4 decimals (~11 m) so nearby lookups share a key.
+func (l Location) Key() string {
+	if l.HasCoordinates() {
+		return fmt.Sprintf("coord:%.4f,%.4f", l.Coordinates.Lat, l.Coordinates.Lon)
+	}
+	key := strings.ToLower(strings.TrimSpace(l.City))
+	if country := strings.ToLower(strings.TrimSpace(l.CountryCode)); country != "" {
+		key += "," + country
+	}
+	return key
+}
+
+// String returns a human-readable form of the location.
+func (l Location) String() string {
+	if l.HasCoordinates() {
+		return fmt.Sprintf("%.4f,%.4f", l.Coordinates.Lat, l.Coordinates.Lon)
+	}
+	if l.CountryCode != "" {
+		return l.City + "," + l.CountryCode
+	}
+	return l.City
+}
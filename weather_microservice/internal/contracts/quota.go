@@ -0,0 +1,31 @@
+package contracts
+
+import (
+	"context"
+	"time"
+)
+
+// ProviderQuota — використання бюджету викликів провайдера погоди
+// за поточні добу та місяць (UTC).
+type ProviderQuota struct {
+	Provider string      `json:"provider"`
+	Daily    QuotaPeriod `json:"daily"`
+	Monthly  QuotaPeriod `json:"monthly"`
+	// Exhausted — провайдер пропускається, доки бюджет не оновиться.
+	Exhausted bool `json:"exhausted"`
+}
+
+// QuotaPeriod — лічильник викликів провайдера за один період.
+type QuotaPeriod struct {
+	Used int64 `json:"used"`
+	// Limit — бюджет викликів за період, 0 означає без обмеження.
+	Limit int64 `json:"limit"`
+	// Remaining — залишок бюджету; відсутній, якщо обмеження немає.
+	Remaining *int64    `json:"remaining,omitempty"`
+	ResetsAt  time.Time `json:"resets_at"`
+}
+
+// ProviderQuotaReporter повертає стан бюджетів викликів усіх провайдерів.
+type ProviderQuotaReporter interface {
+	ProviderQuotas(ctx context.Context) ([]ProviderQuota, error)
+}
@@ -7,11 +7,49 @@ import (
 
 // WeatherCache визначає інтерфейс для кешування погоди.
 type WeatherCache interface {
-	Get(ctx context.Context, city string) (WeatherData, error)
-	Set(ctx context.Context, city string, data WeatherData, expiration time.Duration) error
-	Delete(ctx context.Context, city string) error
-	Exists(ctx context.Context, city string) (bool, error)
+	Get(ctx context.Context, loc Location) (WeatherData, error)
+	Set(ctx context.Context, loc Location, data WeatherData, expiration time.Duration) error
+	Delete(ctx context.Context, loc Location) error
+	Exists(ctx context.Context, loc Location) (bool, error)
 	Health(ctx context.Context) error
 	Close() error
 	GetStats(ctx context.Context) (map[string]interface{}, error)
 }
+
+// BatchWeatherCache визначає пакетне читання погоди кількох локацій.
+type BatchWeatherCache interface {
+	// GetMany повертає знайдені записи за ключем локації (Location.Key); відсутні пропускаються.
+	GetMany(ctx context.Context, locs []Location) (map[string]WeatherData, error)
+}
+
+// CacheEntry описує закешований запис погоди.
+type CacheEntry struct {
+	Key  string        `json:"key"`
+	Data WeatherData   `json:"data"`
+	TTL  time.Duration `json:"ttl"` // 0 — запис без терміну дії.
+}
+
+// CacheAdmin визначає адміністративні операції над кешем.
+type CacheAdmin interface {
+	// Inspect повертає запис погоди для локації; ok=false, якщо його немає.
+	Inspect(ctx context.Context, loc Location) (entry CacheEntry, ok bool, err error)
+	// InvalidateLocation видаляє погоду, прогнози та негативний кеш локації.
+	InvalidateLocation(ctx context.Context, loc Location) (int64, error)
+	// InvalidatePattern видаляє записи, ключ локації яких відповідає glob-шаблону.
+	InvalidatePattern(ctx context.Context, pattern string) (int64, error)
+	// Flush видаляє всі записи кешу погоди.
+	Flush(ctx context.Context) (int64, error)
+}
+
+// NegativeCache визначає інтерфейс для кешування відсутніх міст (ErrCityNotFound).
+type NegativeCache interface {
+	IsNotFound(ctx context.Context, loc Location) (bool, error)
+	SetNotFound(ctx context.Context, loc Location, expiration time.Duration) error
+	PurgeNotFound(ctx context.Context) (int64, error)
+}
+
+// ForecastCache визначає інтерфейс для кешування прогнозів.
+type ForecastCache interface {
+	GetForecast(ctx context.Context, loc Location, days int) (ForecastData, error)
+	SetForecast(ctx context.Context, loc Location, days int, data ForecastData, expiration time.Duration) error
+}
@@ -0,0 +1,114 @@
+package history
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"weather_microservice/internal/contracts"
+
+	_ "github.com/lib/pq" // Postgres driver for database/sql.
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS weather_observations (
+	id           BIGSERIAL PRIMARY KEY,
+	location_key TEXT NOT NULL,
+	city         TEXT NOT NULL,
+	provider     TEXT NOT NULL,
+	observed_at  TIMESTAMPTZ NOT NULL,
+	temperature  DOUBLE PRECISION NOT NULL,
+	humidity     DOUBLE PRECISION NOT NULL,
+	description  TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS weather_observations_location_time_idx
+	ON weather_observations (location_key, observed_at);`
+
+// PostgresStore keeps weather observations in Postgres.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// compile-time гарантія, що реалізує інтерфейс.
+var _ contracts.HistoryStore = (*PostgresStore)(nil)
+
+// NewPostgresStore creates a store on top of an open database.
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// OpenPostgresStore connects to Postgres and creates the observations table if needed.
+func OpenPostgresStore(ctx context.Context, databaseURL string) (*PostgresStore, error) {
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history database: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to connect to history database: %w", err)
+	}
+	store := NewPostgresStore(db)
+	if err := store.Migrate(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// Migrate creates the observations table and its index.
+func (s *PostgresStore) Migrate(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, schema); err != nil {
+		return fmt.Errorf("failed to migrate history database: %w", err)
+	}
+	return nil
+}
+
+// Record stores a weather observation.
+func (s *PostgresStore) Record(ctx context.Context, loc contracts.Location, obs contracts.Observation) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO weather_observations
+			(location_key, city, provider, observed_at, temperature, humidity, description)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		loc.Key(), obs.City, obs.Provider, obs.ObservedAt, obs.Temperature, obs.Humidity, obs.Description,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record observation: %w", err)
+	}
+	return nil
+}
+
+// History returns observations of a location in [from, to), oldest first.
+func (s *PostgresStore) History(ctx context.Context, loc contracts.Location, from, to time.Time, limit int) ([]contracts.Observation, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT city, provider, observed_at, temperature, humidity, description
+		FROM weather_observations
+		WHERE location_key = $1 AND observed_at >= $2 AND observed_at < $3
+		ORDER BY observed_at
+		LIMIT $4`,
+		loc.Key(), from, to, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query history: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	observations := make([]contracts.Observation, 0)
+	for rows.Next() {
+		var obs contracts.Observation
+		if err := rows.Scan(&obs.City, &obs.Provider, &obs.ObservedAt, &obs.Temperature, &obs.Humidity, &obs.Description); err != nil {
+			return nil, fmt.Errorf("failed to scan observation: %w", err)
+		}
+		obs.ObservedAt = obs.ObservedAt.UTC()
+		observations = append(observations, obs)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	return observations, nil
+}
+
+// Close closes the database connection.
+func (s *PostgresStore) Close() error {
+	return s.db.Close()
+}
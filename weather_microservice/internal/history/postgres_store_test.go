@@ -0,0 +1,60 @@
+package history
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"weather_microservice/internal/contracts"
+)
+
+func newMockStore(t *testing.T) (*PostgresStore, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return NewPostgresStore(db), mock
+}
+
+func TestPostgresStore_Record(t *testing.T) {
+	store, mock := newMockStore(t)
+	observedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec("INSERT INTO weather_observations").
+		WithArgs("kyiv,ua", "Kyiv,UA", "openmeteo", observedAt, 21.5, 40.0, "Clear sky").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := store.Record(context.Background(), contracts.Location{City: "Kyiv", CountryCode: "UA"}, contracts.Observation{
+		City:        "Kyiv,UA",
+		Provider:    "openmeteo",
+		ObservedAt:  observedAt,
+		Temperature: 21.5,
+		Humidity:    40,
+		Description: "Clear sky",
+	})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_History(t *testing.T) {
+	store, mock := newMockStore(t)
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	rows := sqlmock.NewRows([]string{"city", "provider", "observed_at", "temperature", "humidity", "description"}).
+		AddRow("Kyiv", "openweather", from.Add(time.Hour), 18.0, 55.0, "Cloudy").
+		AddRow("Kyiv", "weatherapi", from.Add(2*time.Hour), 19.5, 50.0, "Sunny")
+	mock.ExpectQuery("SELECT city, provider, observed_at").
+		WithArgs("kyiv", from, to, 100).
+		WillReturnRows(rows)
+
+	observations, err := store.History(context.Background(), contracts.CityLocation(" Kyiv "), from, to, 100)
+	require.NoError(t, err)
+	require.Len(t, observations, 2)
+	assert.Equal(t, "openweather", observations[0].Provider)
+	assert.Equal(t, from.Add(2*time.Hour), observations[1].ObservedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
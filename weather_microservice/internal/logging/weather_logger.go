@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"sync"
 	"weather_microservice/internal/contracts"
 )
 
 type WeatherLogger interface {
 	LogResponse(provider string, data contracts.WeatherData, err error)
+	LogForecastResponse(provider string, data contracts.ForecastData, err error)
 }
 
 type FileWeatherLogger struct {
@@ -23,6 +25,15 @@ func NewFileWeatherLogger(logFile string) *FileWeatherLogger {
 }
 
 func (l *FileWeatherLogger) LogResponse(provider string, data contracts.WeatherData, err error) {
+	l.writeEntry(provider, data, err)
+}
+
+func (l *FileWeatherLogger) LogForecastResponse(provider string, data contracts.ForecastData, err error) {
+	// Hourly points are too noisy for the log, keep only the daily summary.
+	l.writeEntry(provider+" forecast", data.Daily, err)
+}
+
+func (l *FileWeatherLogger) writeEntry(provider string, response interface{}, err error) {
 	logEntry := map[string]interface{}{
 		"provider": provider,
 		"success":  err == nil,
@@ -31,7 +42,7 @@ func (l *FileWeatherLogger) LogResponse(provider string, data contracts.WeatherD
 	if err != nil {
 		logEntry["error"] = err.Error()
 	} else {
-		logEntry["response"] = data
+		logEntry["response"] = response
 	}
 
 	logJSON, _ := json.Marshal(logEntry)
@@ -59,6 +70,7 @@ func (l *FileWeatherLogger) logToFile(logMessage string) {
 
 // MockLogger для тестування.
 type MockLogger struct {
+	mu              sync.Mutex
 	LoggedResponses []LogEntry
 }
 
@@ -75,6 +87,8 @@ func NewMockLogger() *MockLogger {
 }
 
 func (m *MockLogger) LogResponse(provider string, data contracts.WeatherData, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.LoggedResponses = append(m.LoggedResponses, LogEntry{
 		Provider: provider,
 		Data:     data,
@@ -85,12 +99,27 @@ func (m *MockLogger) LogResponse(provider string, data contracts.WeatherData, er
 	log.Printf("[MOCK] %s - Success: %t", provider, err == nil)
 }
 
+func (m *MockLogger) LogForecastResponse(provider string, data contracts.ForecastData, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.LoggedResponses = append(m.LoggedResponses, LogEntry{
+		Provider: provider,
+		Error:    err,
+	})
+
+	log.Printf("[MOCK] %s forecast - Success: %t", provider, err == nil)
+}
+
 // Helper methods for test assertions.
 func (m *MockLogger) GetLogCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return len(m.LoggedResponses)
 }
 
 func (m *MockLogger) GetLastLog() *LogEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if len(m.LoggedResponses) == 0 {
 		return nil
 	}
@@ -98,6 +127,8 @@ func (m *MockLogger) GetLastLog() *LogEntry {
 }
 
 func (m *MockLogger) GetLogsByProvider(provider string) []LogEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	var results []LogEntry
 	for _, entry := range m.LoggedResponses {
 		if entry.Provider == provider {
@@ -108,5 +139,7 @@ func (m *MockLogger) GetLogsByProvider(provider string) []LogEntry {
 }
 
 func (m *MockLogger) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.LoggedResponses = make([]LogEntry, 0)
 }
@@ -0,0 +1,55 @@
+package quota
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps counters in process memory, so budgets are per replica
+// and start over on restart.
+type MemoryStore struct {
+	mu      sync.Mutex
+	day     string
+	month   string
+	daily   map[string]int64
+	monthly map[string]int64
+}
+
+// NewMemoryStore creates an empty in-memory counter store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		daily:   make(map[string]int64),
+		monthly: make(map[string]int64),
+	}
+}
+
+func (s *MemoryStore) Increment(ctx context.Context, provider string, now time.Time) (Usage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rollover(now)
+	s.daily[provider]++
+	s.monthly[provider]++
+	return Usage{Daily: s.daily[provider], Monthly: s.monthly[provider]}, nil
+}
+
+func (s *MemoryStore) Usage(ctx context.Context, provider string, now time.Time) (Usage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rollover(now)
+	return Usage{Daily: s.daily[provider], Monthly: s.monthly[provider]}, nil
+}
+
+// rollover drops the counters of past periods. Must be called with mu held.
+func (s *MemoryStore) rollover(now time.Time) {
+	if day := dayKey(now); day != s.day {
+		s.day = day
+		clear(s.daily)
+	}
+	if month := monthKey(now); month != s.month {
+		s.month = month
+		clear(s.monthly)
+	}
+}
@@ -0,0 +1,15 @@
+package quota
+
+// Metrics collects provider budget metrics.
+type Metrics interface {
+	// SetUsage records the calls made to provider so far against its budget.
+	SetUsage(provider string, budget Budget, usage Usage)
+	// IncSkips counts calls not made because the provider's budget was exhausted.
+	IncSkips(provider string)
+}
+
+// NoopMetrics is an empty implementation used when metrics are not needed.
+type NoopMetrics struct{}
+
+func (NoopMetrics) SetUsage(provider string, budget Budget, usage Usage) {}
+func (NoopMetrics) IncSkips(provider string)                             {}
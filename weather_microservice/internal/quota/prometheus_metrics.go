@@ -0,0 +1,46 @@
+package quota
+
+import "github.com/prometheus/client_golang/prometheus"
+
+type PrometheusMetrics struct {
+	used      *prometheus.GaugeVec
+	remaining *prometheus.GaugeVec
+	skips     *prometheus.CounterVec
+}
+
+func NewPrometheusMetrics() *PrometheusMetrics {
+	return &PrometheusMetrics{
+		used: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "weather_provider_budget_used",
+			Help: "Calls made to a weather provider in the current period (day or month, UTC)",
+		}, []string{"provider", "period"}),
+		remaining: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "weather_provider_budget_remaining",
+			Help: "Calls left in the budget of a weather provider for the current period, only for limited periods",
+		}, []string{"provider", "period"}),
+		skips: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "weather_provider_budget_skips_total",
+			Help: "Total number of requests that skipped a provider because its call budget was exhausted",
+		}, []string{"provider"}),
+	}
+}
+
+func (m *PrometheusMetrics) Register() {
+	prometheus.MustRegister(m.used, m.remaining, m.skips)
+}
+
+func (m *PrometheusMetrics) SetUsage(provider string, budget Budget, usage Usage) {
+	m.set(provider, "day", budget.Daily, usage.Daily)
+	m.set(provider, "month", budget.Monthly, usage.Monthly)
+}
+
+func (m *PrometheusMetrics) set(provider, period string, limit, used int64) {
+	m.used.WithLabelValues(provider, period).Set(float64(used))
+	if limit > 0 {
+		m.remaining.WithLabelValues(provider, period).Set(float64(max(limit-used, 0)))
+	}
+}
+
+func (m *PrometheusMetrics) IncSkips(provider string) {
+	m.skips.WithLabelValues(provider).Inc()
+}
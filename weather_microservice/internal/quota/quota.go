@@ -0,0 +1,61 @@
+// Package quota counts calls to weather providers per day and month, so that
+// providers with free-tier limits are skipped before they start failing.
+// Periods are calendar days and months in UTC.
+package quota
+
+import (
+	"context"
+	"time"
+)
+
+// Budget limits the calls to a provider. A zero field means no limit.
+type Budget struct {
+	Daily   int64
+	Monthly int64
+}
+
+// Unlimited reports whether the budget lets every call through.
+func (b Budget) Unlimited() bool {
+	return b.Daily <= 0 && b.Monthly <= 0
+}
+
+// Exhausted reports whether usage has reached the daily or monthly limit.
+func (b Budget) Exhausted(usage Usage) bool {
+	return (b.Daily > 0 && usage.Daily >= b.Daily) ||
+		(b.Monthly > 0 && usage.Monthly >= b.Monthly)
+}
+
+// Usage is the number of calls made to a provider in the current day and month.
+type Usage struct {
+	Daily   int64
+	Monthly int64
+}
+
+// Store keeps call counters by provider and period.
+type Store interface {
+	// Increment counts one call to provider at now and returns the new usage.
+	Increment(ctx context.Context, provider string, now time.Time) (Usage, error)
+	// Usage returns the calls counted for provider in the day and month of now.
+	Usage(ctx context.Context, provider string, now time.Time) (Usage, error)
+}
+
+// dayKey and monthKey name the periods now falls into.
+func dayKey(now time.Time) string {
+	return now.UTC().Format("2006-01-02")
+}
+
+func monthKey(now time.Time) string {
+	return now.UTC().Format("2006-01")
+}
+
+// dayEnd returns when the daily counter of now resets.
+func dayEnd(now time.Time) time.Time {
+	y, m, d := now.UTC().Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+}
+
+// monthEnd returns when the monthly counter of now resets.
+func monthEnd(now time.Time) time.Time {
+	y, m, _ := now.UTC().Date()
+	return time.Date(y, m+1, 1, 0, 0, 0, 0, time.UTC)
+}
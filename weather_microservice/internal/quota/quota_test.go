@@ -0,0 +1,172 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"weather_microservice/internal/contracts"
+)
+
+func TestMemoryStore_CountsPerPeriod(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	now := time.Date(2025, 6, 29, 23, 0, 0, 0, time.UTC)
+
+	for range 2 {
+		_, err := store.Increment(ctx, "openweather", now)
+		require.NoError(t, err)
+	}
+	usage, err := store.Increment(ctx, "weatherapi", now)
+	require.NoError(t, err)
+	assert.Equal(t, Usage{Daily: 1, Monthly: 1}, usage)
+
+	usage, err = store.Usage(ctx, "openweather", now)
+	require.NoError(t, err)
+	assert.Equal(t, Usage{Daily: 2, Monthly: 2}, usage)
+
+	// A new day keeps the month.
+	usage, err = store.Increment(ctx, "openweather", now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, Usage{Daily: 1, Monthly: 3}, usage)
+
+	// A new month starts over.
+	usage, err = store.Usage(ctx, "openweather", now.Add(25*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, Usage{}, usage)
+}
+
+type MockScripter struct {
+	redis.Scripter
+	mock.Mock
+}
+
+func (m *MockScripter) EvalSha(ctx context.Context, sha1 string, keys []string, args ...interface{}) *redis.Cmd {
+	res := m.Called(keys, args)
+	return redis.NewCmdResult(res.Get(0), res.Error(1))
+}
+
+func TestRedisStore_Increment(t *testing.T) {
+	client := &MockScripter{}
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	client.On("EvalSha",
+		[]string{"quota:openweather:2025-06-15", "quota:openweather:2025-06"},
+		[]interface{}{
+			time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC).Unix(),
+			time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC).Unix(),
+		},
+	).Return([]interface{}{int64(3), int64(42)}, nil).Once()
+
+	usage, err := NewRedisStore(client, "quota:").Increment(context.Background(), "openweather", now)
+	require.NoError(t, err)
+	assert.Equal(t, Usage{Daily: 3, Monthly: 42}, usage)
+	client.AssertExpectations(t)
+}
+
+func TestRedisStore_UsageError(t *testing.T) {
+	client := &MockScripter{}
+	client.On("EvalSha", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+	_, err := NewRedisStore(client, "quota:").Usage(context.Background(), "openweather", time.Now())
+	require.Error(t, err)
+}
+
+type failingStore struct{}
+
+func (failingStore) Increment(ctx context.Context, provider string, now time.Time) (Usage, error) {
+	return Usage{}, errors.New("connection refused")
+}
+
+func (failingStore) Usage(ctx context.Context, provider string, now time.Time) (Usage, error) {
+	return Usage{}, errors.New("connection refused")
+}
+
+type recordingMetrics struct {
+	NoopMetrics
+	skips []string
+}
+
+func (m *recordingMetrics) IncSkips(provider string) {
+	m.skips = append(m.skips, provider)
+}
+
+func TestTracker_SkipsExhaustedProvider(t *testing.T) {
+	metrics := &recordingMetrics{}
+	tracker := NewTracker(NewMemoryStore(), metrics)
+	tracker.SetBudget("openweather", Budget{Daily: 2})
+	tracker.SetBudget("openmeteo", Budget{})
+	ctx := context.Background()
+
+	for range 2 {
+		require.True(t, tracker.Allow(ctx, "openweather"))
+		tracker.Record(ctx, "openweather")
+		require.True(t, tracker.Allow(ctx, "openmeteo"))
+		tracker.Record(ctx, "openmeteo")
+	}
+	assert.False(t, tracker.Allow(ctx, "openweather"))
+	assert.True(t, tracker.Allow(ctx, "openmeteo"))
+	assert.Equal(t, []string{"openweather"}, metrics.skips)
+
+	// Tomorrow the daily budget is back.
+	tracker.now = func() time.Time { return time.Now().Add(24 * time.Hour) }
+	assert.True(t, tracker.Allow(ctx, "openweather"))
+}
+
+func TestTracker_RecordOutlivesRequest(t *testing.T) {
+	store := NewMemoryStore()
+	tracker := NewTracker(store, nil)
+	tracker.SetBudget("openweather", Budget{Daily: 10})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tracker.Record(ctx, "openweather")
+
+	usage, err := store.Usage(context.Background(), "openweather", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), usage.Daily)
+}
+
+func TestTracker_FailsOpen(t *testing.T) {
+	tracker := NewTracker(failingStore{}, nil)
+	tracker.SetBudget("openweather", Budget{Daily: 1})
+
+	tracker.Record(context.Background(), "openweather")
+	assert.True(t, tracker.Allow(context.Background(), "openweather"))
+
+	_, err := tracker.ProviderQuotas(context.Background())
+	require.Error(t, err)
+}
+
+func TestTracker_ProviderQuotas(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	tracker := NewTracker(NewMemoryStore(), nil)
+	tracker.now = func() time.Time { return now }
+	tracker.SetBudget("weatherapi", Budget{Daily: 1, Monthly: 100})
+	tracker.SetBudget("openmeteo", Budget{})
+	tracker.Record(context.Background(), "weatherapi")
+	tracker.Record(context.Background(), "openmeteo")
+
+	quotas, err := tracker.ProviderQuotas(context.Background())
+	require.NoError(t, err)
+
+	zero, ninetyNine := int64(0), int64(99)
+	assert.Equal(t, []contracts.ProviderQuota{
+		{
+			Provider:  "weatherapi",
+			Daily:     contracts.QuotaPeriod{Used: 1, Limit: 1, Remaining: &zero, ResetsAt: time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)},
+			Monthly:   contracts.QuotaPeriod{Used: 1, Limit: 100, Remaining: &ninetyNine, ResetsAt: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+			Exhausted: true,
+		},
+		{
+			Provider: "openmeteo",
+			Daily:    contracts.QuotaPeriod{Used: 1, ResetsAt: time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)},
+			Monthly:  contracts.QuotaPeriod{Used: 1, ResetsAt: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}, quotas)
+}
@@ -0,0 +1,69 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// incrementScript counts a call in the daily and monthly counters at once.
+// Counters expire when their period ends.
+var incrementScript = redis.NewScript(`
+local daily = redis.call('INCR', KEYS[1])
+if daily == 1 then
+  redis.call('EXPIREAT', KEYS[1], ARGV[1])
+end
+local monthly = redis.call('INCR', KEYS[2])
+if monthly == 1 then
+  redis.call('EXPIREAT', KEYS[2], ARGV[2])
+end
+return {daily, monthly}
+`)
+
+// usageScript reads both counters, treating missing ones as zero.
+var usageScript = redis.NewScript(`
+return {tonumber(redis.call('GET', KEYS[1])) or 0, tonumber(redis.call('GET', KEYS[2])) or 0}
+`)
+
+// RedisStore keeps counters in Redis, so budgets are shared by all replicas
+// and survive restarts.
+type RedisStore struct {
+	client redis.Scripter
+	prefix string
+}
+
+// NewRedisStore creates a store that keeps counters under prefix, e.g.
+// "quota:openweather:2025-06-01" and "quota:openweather:2025-06".
+func NewRedisStore(client redis.Scripter, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+func (s *RedisStore) Increment(ctx context.Context, provider string, now time.Time) (Usage, error) {
+	res, err := incrementScript.Run(ctx, s.client, s.keys(provider, now), dayEnd(now).Unix(), monthEnd(now).Unix()).Int64Slice()
+	if err != nil {
+		return Usage{}, fmt.Errorf("provider quota %s: %w", provider, err)
+	}
+	return s.usage(provider, res)
+}
+
+func (s *RedisStore) Usage(ctx context.Context, provider string, now time.Time) (Usage, error) {
+	res, err := usageScript.Run(ctx, s.client, s.keys(provider, now)).Int64Slice()
+	if err != nil {
+		return Usage{}, fmt.Errorf("provider quota %s: %w", provider, err)
+	}
+	return s.usage(provider, res)
+}
+
+func (s *RedisStore) keys(provider string, now time.Time) []string {
+	key := s.prefix + provider + ":"
+	return []string{key + dayKey(now), key + monthKey(now)}
+}
+
+func (s *RedisStore) usage(provider string, res []int64) (Usage, error) {
+	if len(res) != 2 {
+		return Usage{}, fmt.Errorf("provider quota %s: unexpected reply %v", provider, res)
+	}
+	return Usage{Daily: res[0], Monthly: res[1]}, nil
+}
@@ -0,0 +1,116 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"weather_microservice/internal/contracts"
+)
+
+// recordTimeout bounds counting one call, which outlives canceled requests.
+// It is short because the request waits for it.
+const recordTimeout = 250 * time.Millisecond
+
+// Tracker counts provider calls and tells when a provider has used up its
+// budget. Budgets are soft: concurrent requests check the counters before
+// they are incremented, so a busy provider may overshoot by a few calls.
+// Configure budgets slightly below the provider limits.
+//
+// Store errors never block providers: the tracker logs them and lets the
+// call through.
+type Tracker struct {
+	store     Store
+	metrics   Metrics
+	now       func() time.Time
+	providers []string
+	budgets   map[string]Budget
+}
+
+// NewTracker creates a tracker keeping counters in store.
+func NewTracker(store Store, metrics Metrics) *Tracker {
+	if metrics == nil {
+		metrics = NoopMetrics{}
+	}
+	return &Tracker{
+		store:   store,
+		metrics: metrics,
+		now:     time.Now,
+		budgets: make(map[string]Budget),
+	}
+}
+
+// SetBudget tracks provider with the given budget; a zero budget only counts
+// calls. Budgets must be set before the tracker is used.
+func (t *Tracker) SetBudget(provider string, budget Budget) {
+	if _, ok := t.budgets[provider]; !ok {
+		t.providers = append(t.providers, provider)
+	}
+	t.budgets[provider] = budget
+}
+
+// Allow reports whether provider has budget left for one more call.
+func (t *Tracker) Allow(ctx context.Context, provider string) bool {
+	budget := t.budgets[provider]
+	if budget.Unlimited() {
+		return true
+	}
+	usage, err := t.store.Usage(ctx, provider, t.now())
+	if err != nil {
+		log.Printf("Failed to check call budget of %s, allowing the call: %v", provider, err)
+		return true
+	}
+	t.metrics.SetUsage(provider, budget, usage)
+	if budget.Exhausted(usage) {
+		t.metrics.IncSkips(provider)
+		return false
+	}
+	return true
+}
+
+// Record counts one call made to provider, also for providers without a
+// budget. The call is counted even when ctx is already canceled, since the
+// provider has received it.
+func (t *Tracker) Record(ctx context.Context, provider string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), recordTimeout)
+	defer cancel()
+
+	usage, err := t.store.Increment(ctx, provider, t.now())
+	if err != nil {
+		log.Printf("Failed to count call to %s: %v", provider, err)
+		return
+	}
+	t.metrics.SetUsage(provider, t.budgets[provider], usage)
+}
+
+// ProviderQuotas returns the usage and remaining budget of every tracked
+// provider, in the order they were added.
+func (t *Tracker) ProviderQuotas(ctx context.Context) ([]contracts.ProviderQuota, error) {
+	now := t.now()
+	quotas := make([]contracts.ProviderQuota, 0, len(t.providers))
+	for _, provider := range t.providers {
+		usage, err := t.store.Usage(ctx, provider, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read call budget of %s: %w", provider, err)
+		}
+		budget := t.budgets[provider]
+		t.metrics.SetUsage(provider, budget, usage)
+		quotas = append(quotas, contracts.ProviderQuota{
+			Provider:  provider,
+			Daily:     period(usage.Daily, budget.Daily, dayEnd(now)),
+			Monthly:   period(usage.Monthly, budget.Monthly, monthEnd(now)),
+			Exhausted: budget.Exhausted(usage),
+		})
+	}
+	return quotas, nil
+}
+
+func period(used, limit int64, resetsAt time.Time) contracts.QuotaPeriod {
+	p := contracts.QuotaPeriod{Used: used, Limit: max(limit, 0), ResetsAt: resetsAt}
+	if limit > 0 {
+		remaining := max(limit-used, 0)
+		p.Remaining = &remaining
+	}
+	return p
+}
@@ -0,0 +1,77 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepEvery is how many takes pass between removals of refilled buckets.
+const sweepEvery = 1024
+
+// MemoryStore keeps buckets in process memory, so limits are per replica.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*memoryBucket
+	takes   int
+	now     func() time.Time
+}
+
+type memoryBucket struct {
+	bucket
+	limit Limit
+}
+
+// NewMemoryStore creates an empty in-memory bucket store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]*memoryBucket),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.takes++
+	if s.takes%sweepEvery == 0 {
+		s.sweep(now)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &memoryBucket{bucket: bucket{tokens: float64(limit.Burst), last: now}}
+		s.buckets[key] = b
+	}
+	b.limit = limit
+	return b.take(now, limit), nil
+}
+
+func (s *MemoryStore) Peek(ctx context.Context, key string, limit Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		return Result{Allowed: true, Remaining: limit.Burst}, nil
+	}
+	return b.bucket.peek(s.now(), limit), nil
+}
+
+// sweep removes buckets that refilled completely; they are recreated full on demand.
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if b.full(now, b.limit) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// Len returns the number of tracked buckets.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.buckets)
+}
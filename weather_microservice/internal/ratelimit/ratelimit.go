@@ -0,0 +1,74 @@
+// Package ratelimit implements token bucket rate limiting with in-memory and
+// Redis-backed bucket stores.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit describes a token bucket: it refills at Rate tokens per second and
+// holds at most Burst tokens. Every request takes one token.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// PerMinute returns a limit of n requests per minute with the given burst.
+func PerMinute(n, burst int) Limit {
+	return Limit{Rate: float64(n) / 60, Burst: burst}
+}
+
+// Unlimited reports whether the limit lets every request through.
+func (l Limit) Unlimited() bool {
+	return l.Rate <= 0 || l.Burst <= 0
+}
+
+// Result is the outcome of taking a token.
+type Result struct {
+	Allowed bool
+	// Remaining is the number of whole tokens left in the bucket.
+	Remaining int
+	// RetryAfter is how long to wait for the next token when not allowed.
+	RetryAfter time.Duration
+}
+
+// Store keeps token buckets by key.
+type Store interface {
+	// Take takes one token from the bucket of key.
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+	// Peek reports whether the bucket of key has a token, without taking it.
+	Peek(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// bucket is the state of one token bucket.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// take refills the bucket up to now and tries to take one token from it.
+func (b *bucket) take(now time.Time, limit Limit) Result {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(float64(limit.Burst), b.tokens+elapsed*limit.Rate)
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return Result{Allowed: true, Remaining: int(b.tokens)}
+	}
+	wait := (1 - b.tokens) / limit.Rate
+	return Result{RetryAfter: time.Duration(math.Ceil(wait * float64(time.Second)))}
+}
+
+// peek reports what take would return at now, without changing the bucket.
+func (b bucket) peek(now time.Time, limit Limit) Result {
+	return b.take(now, limit)
+}
+
+// full reports whether the bucket has refilled completely by now.
+func (b *bucket) full(now time.Time, limit Limit) bool {
+	return b.tokens+now.Sub(b.last).Seconds()*limit.Rate >= float64(limit.Burst)
+}
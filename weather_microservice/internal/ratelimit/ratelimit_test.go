@@ -0,0 +1,117 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_TakeAndRefill(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	limit := PerMinute(60, 2)
+	ctx := context.Background()
+
+	for i := 1; i >= 0; i-- {
+		res, err := store.Take(ctx, "client", limit)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, i, res.Remaining)
+	}
+
+	res, err := store.Take(ctx, "client", limit)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, time.Second, res.RetryAfter)
+
+	// Other clients have their own buckets.
+	res, err = store.Take(ctx, "other", limit)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+
+	now = now.Add(time.Second)
+	res, err = store.Take(ctx, "client", limit)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+}
+
+func TestMemoryStore_Peek(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	limit := PerMinute(60, 1)
+	ctx := context.Background()
+
+	res, err := store.Peek(ctx, "client", limit)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Allowed: true, Remaining: 1}, res)
+
+	_, err = store.Take(ctx, "client", limit)
+	require.NoError(t, err)
+	for range 2 {
+		res, err = store.Peek(ctx, "client", limit)
+		require.NoError(t, err)
+		assert.False(t, res.Allowed)
+		assert.Equal(t, time.Second, res.RetryAfter)
+	}
+
+	now = now.Add(time.Second)
+	res, err = store.Take(ctx, "client", limit)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+}
+
+func TestMemoryStore_SweepsRefilledBuckets(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	limit := PerMinute(60, 5)
+
+	_, err := store.Take(context.Background(), "idle", limit)
+	require.NoError(t, err)
+
+	now = now.Add(time.Minute)
+	for i := 1; i < sweepEvery; i++ {
+		_, err := store.Take(context.Background(), "busy", limit)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, store.Len())
+}
+
+type MockScripter struct {
+	redis.Scripter
+	mock.Mock
+}
+
+func (m *MockScripter) EvalSha(ctx context.Context, sha1 string, keys []string, args ...interface{}) *redis.Cmd {
+	res := m.Called(keys, args)
+	return redis.NewCmdResult(res.Get(0), res.Error(1))
+}
+
+func TestRedisStore_Take(t *testing.T) {
+	client := &MockScripter{}
+	limit := PerMinute(60, 10)
+	client.On("EvalSha", []string{"ratelimit:weather:1.2.3.4"}, []interface{}{limit.Rate, limit.Burst}).
+		Return([]interface{}{int64(0), int64(0), int64(1500)}, nil).Once()
+
+	store := NewRedisStore(client, "ratelimit:")
+	res, err := store.Take(context.Background(), "weather:1.2.3.4", limit)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Allowed: false, Remaining: 0, RetryAfter: 1500 * time.Millisecond}, res)
+	client.AssertExpectations(t)
+}
+
+func TestRedisStore_TakeError(t *testing.T) {
+	client := &MockScripter{}
+	client.On("EvalSha", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+	_, err := NewRedisStore(client, "ratelimit:").Take(context.Background(), "weather:1.2.3.4", PerMinute(60, 10))
+	require.Error(t, err)
+}
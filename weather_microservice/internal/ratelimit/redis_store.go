@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// takeScript refills and takes from a bucket atomically, using the Redis
+// clock so every replica sees the same time.
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) + tonumber(clock[2]) / 1000000
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = burst
+  ts = now
+end
+if now > ts then
+  tokens = math.min(burst, tokens + (now - ts) * rate)
+end
+
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  wait = math.ceil((1 - tokens) / rate * 1000)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate * 1000))
+return {allowed, math.floor(tokens), wait}
+`)
+
+// peekScript refills a bucket like takeScript but leaves it unchanged.
+var peekScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) + tonumber(clock[2]) / 1000000
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  return {1, burst, 0}
+end
+if now > ts then
+  tokens = math.min(burst, tokens + (now - ts) * rate)
+end
+
+if tokens >= 1 then
+  return {1, math.floor(tokens), 0}
+end
+return {0, 0, math.ceil((1 - tokens) / rate * 1000)}
+`)
+
+// RedisStore keeps buckets in Redis, so limits hold across replicas.
+type RedisStore struct {
+	client redis.Scripter
+	prefix string
+}
+
+// NewRedisStore creates a store that keeps buckets under prefix.
+func NewRedisStore(client redis.Scripter, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+func (s *RedisStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	return s.run(ctx, takeScript, key, limit)
+}
+
+func (s *RedisStore) Peek(ctx context.Context, key string, limit Limit) (Result, error) {
+	return s.run(ctx, peekScript, key, limit)
+}
+
+func (s *RedisStore) run(ctx context.Context, script *redis.Script, key string, limit Limit) (Result, error) {
+	res, err := script.Run(ctx, s.client, []string{s.prefix + key}, limit.Rate, limit.Burst).Int64Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("rate limit bucket %s: %w", key, err)
+	}
+	if len(res) != 3 {
+		return Result{}, fmt.Errorf("rate limit bucket %s: unexpected reply %v", key, res)
+	}
+	return Result{
+		Allowed:    res[0] == 1,
+		Remaining:  int(max(res[1], 0)),
+		RetryAfter: time.Duration(res[2]) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,142 @@
+// Package errmap translates domain errors into Connect codes and HTTP statuses,
+// so that both APIs report the same failure the same way.
+package errmap
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	"weather_microservice/internal/apierrors"
+)
+
+// Domain is the ErrorInfo domain of errors returned by the weather service.
+const Domain = "weather.v1"
+
+// ReasonHeader carries the machine-readable reason of HTTP errors.
+const ReasonHeader = "X-Error-Reason"
+
+// statusClientClosedRequest is the de facto status of requests the client gave up on.
+const statusClientClosedRequest = 499
+
+// Class describes how an error is reported to API clients.
+type Class struct {
+	Code   connect.Code
+	Status int
+	// Reason is a stable machine-readable identifier, e.g. "CITY_NOT_FOUND".
+	Reason string
+	// Message is safe to show to clients, unlike the error text which may
+	// contain provider URLs.
+	Message string
+}
+
+// Internal reports whether the error is the server's fault rather than the client's.
+func (c Class) Internal() bool {
+	return c.Status >= http.StatusInternalServerError
+}
+
+type rule struct {
+	match func(error) bool
+	class Class
+}
+
+func is(target error) func(error) bool {
+	return func(err error) bool { return errors.Is(err, target) }
+}
+
+// rules are checked in order, so specific causes come before the generic
+// "all providers failed" that wraps them.
+var rules = []rule{
+	{is(apierrors.ErrCityNotFound), Class{connect.CodeNotFound, http.StatusNotFound, "CITY_NOT_FOUND", "City not found"}},
+	{is(apierrors.ErrInvalidCity), Class{connect.CodeInvalidArgument, http.StatusBadRequest, "INVALID_CITY", "Invalid city"}},
+	{is(apierrors.ErrInvalidCoordinates), Class{connect.CodeInvalidArgument, http.StatusBadRequest, "INVALID_COORDINATES", "Coordinates are out of range"}},
+	{is(apierrors.ErrInvalidForecastDays), Class{connect.CodeInvalidArgument, http.StatusBadRequest, "INVALID_FORECAST_DAYS", "Days parameter is out of range"}},
+	{is(apierrors.ErrInvalidTimeRange), Class{connect.CodeInvalidArgument, http.StatusBadRequest, "INVALID_TIME_RANGE", "From must be before to"}},
+	{is(apierrors.ErrBatchTooLarge), Class{connect.CodeInvalidArgument, http.StatusBadRequest, "BATCH_TOO_LARGE", "Too many locations in batch"}},
+	{is(apierrors.ErrInvalidPattern), Class{connect.CodeInvalidArgument, http.StatusBadRequest, "INVALID_PATTERN", "Invalid cache key pattern"}},
+	{is(apierrors.ErrInvalidCityQuery), Class{connect.CodeInvalidArgument, http.StatusBadRequest, "INVALID_CITY_QUERY", "Search query must be 2 to 100 characters long"}},
+	{is(apierrors.ErrCacheMiss), Class{connect.CodeNotFound, http.StatusNotFound, "CACHE_MISS", "Cache entry not found"}},
+	{is(apierrors.ErrMissingAPIKey), Class{connect.CodeUnauthenticated, http.StatusUnauthorized, "API_KEY_MISSING", "Unauthorized"}},
+	{is(apierrors.ErrInvalidAPIKey), Class{connect.CodeUnauthenticated, http.StatusUnauthorized, "API_KEY_INVALID", "Unauthorized"}},
+	{is(apierrors.ErrInsufficientScope), Class{connect.CodePermissionDenied, http.StatusForbidden, "API_KEY_SCOPE", "API key is not allowed to access this endpoint"}},
+	{is(apierrors.ErrAPIQuotaExceeded), Class{connect.CodeResourceExhausted, http.StatusTooManyRequests, "API_KEY_QUOTA_EXCEEDED", "API key quota exceeded"}},
+	{is(apierrors.ErrAuthUnavailable), Class{connect.CodeUnavailable, http.StatusServiceUnavailable, "AUTH_UNAVAILABLE", "Authentication is unavailable"}},
+	{is(apierrors.ErrHistoryDisabled), Class{connect.CodeUnavailable, http.StatusServiceUnavailable, "HISTORY_DISABLED", "Weather history is disabled"}},
+	{is(apierrors.ErrProviderQuotaDisabled), Class{connect.CodeUnavailable, http.StatusServiceUnavailable, "PROVIDER_QUOTA_DISABLED", "Provider quota tracking is disabled"}},
+	{is(apierrors.ErrCacheUnavailable), Class{connect.CodeUnavailable, http.StatusServiceUnavailable, "CACHE_UNAVAILABLE", "Cache is not available"}},
+	{is(apierrors.ErrGeocodingUnavailable), Class{connect.CodeUnavailable, http.StatusServiceUnavailable, "CITY_SEARCH_UNAVAILABLE", "City search is not available"}},
+	{is(context.Canceled), Class{connect.CodeCanceled, statusClientClosedRequest, "CANCELED", "Request canceled"}},
+	{isTimeout, Class{connect.CodeDeadlineExceeded, http.StatusGatewayTimeout, "PROVIDER_TIMEOUT", "Weather providers did not respond in time"}},
+	{is(apierrors.ErrProviderBudgetExhausted), Class{connect.CodeUnavailable, http.StatusServiceUnavailable, "PROVIDER_BUDGET_EXHAUSTED", "Weather provider call budgets are exhausted"}},
+	{is(apierrors.ErrAllProvidersFailed), Class{connect.CodeUnavailable, http.StatusServiceUnavailable, "PROVIDERS_UNAVAILABLE", "Weather providers are unavailable"}},
+}
+
+var internalClass = Class{connect.CodeInternal, http.StatusInternalServerError, "INTERNAL", "Internal server error"}
+
+// isTimeout matches exceeded deadlines and network timeouts, such as the
+// http.Client timeout of a provider request.
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var timeout interface{ Timeout() bool }
+	return errors.As(err, &timeout) && timeout.Timeout()
+}
+
+// Classify returns how err is reported to clients. Unknown errors are internal.
+func Classify(err error) Class {
+	for _, r := range rules {
+		if r.match(err) {
+			return r.class
+		}
+	}
+	return internalClass
+}
+
+// ToConnect converts err into a Connect error with an ErrorInfo detail.
+// Clients get the public message only: error text may quote provider URLs
+// with API keys, even for client errors such as canceled requests.
+func ToConnect(err error) *connect.Error {
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		return connectErr
+	}
+
+	class := Classify(err)
+	cerr := connect.NewError(class.Code, public(err, class))
+	if detail, derr := connect.NewErrorDetail(&errdetails.ErrorInfo{Reason: class.Reason, Domain: Domain}); derr == nil {
+		cerr.AddDetail(detail)
+	}
+	return cerr
+}
+
+// Message returns the error text for clients, see ToConnect.
+func Message(err error) string {
+	return public(err, Classify(err)).Error()
+}
+
+// public hides err behind the public message, logging server errors instead.
+func public(err error, class Class) error {
+	if class.Internal() {
+		log.Printf("Request failed: %v", err)
+	}
+	return errors.New(class.Message)
+}
+
+// WriteHTTP responds with the status and public message of err, putting the
+// reason into the X-Error-Reason header.
+func WriteHTTP(w http.ResponseWriter, err error) {
+	class := Classify(err)
+	if class.Internal() {
+		log.Printf("Request failed: %v", err)
+	}
+	if class.Code == connect.CodeUnauthenticated {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="weather"`)
+	}
+	w.Header().Set(ReasonHeader, class.Reason)
+	http.Error(w, class.Message, class.Status)
+}
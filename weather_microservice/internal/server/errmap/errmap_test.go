@@ -0,0 +1,122 @@
+package errmap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	"weather_microservice/internal/apierrors"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string { return "i/o timeout" }
+func (timeoutError) Timeout() bool { return true }
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		code   connect.Code
+		status int
+		reason string
+	}{
+		{"city not found", apierrors.ErrCityNotFound, connect.CodeNotFound, http.StatusNotFound, "CITY_NOT_FOUND"},
+		{"invalid city", fmt.Errorf("%w: empty", apierrors.ErrInvalidCity), connect.CodeInvalidArgument, http.StatusBadRequest, "INVALID_CITY"},
+		{"invalid API key", apierrors.ErrInvalidAPIKey, connect.CodeUnauthenticated, http.StatusUnauthorized, "API_KEY_INVALID"},
+		{"quota exceeded", apierrors.ErrAPIQuotaExceeded, connect.CodeResourceExhausted, http.StatusTooManyRequests, "API_KEY_QUOTA_EXCEEDED"},
+		{"history disabled", apierrors.ErrHistoryDisabled, connect.CodeUnavailable, http.StatusServiceUnavailable, "HISTORY_DISABLED"},
+		{"canceled", context.Canceled, connect.CodeCanceled, statusClientClosedRequest, "CANCELED"},
+		{"deadline", fmt.Errorf("%w, last error from openweather: %w", apierrors.ErrAllProvidersFailed, context.DeadlineExceeded), connect.CodeDeadlineExceeded, http.StatusGatewayTimeout, "PROVIDER_TIMEOUT"},
+		{"network timeout", fmt.Errorf("%w, last error from openweather: %w", apierrors.ErrAllProvidersFailed, timeoutError{}), connect.CodeDeadlineExceeded, http.StatusGatewayTimeout, "PROVIDER_TIMEOUT"},
+		{"city not found by all providers", fmt.Errorf("%w: %w", apierrors.ErrAllProvidersFailed, apierrors.ErrCityNotFound), connect.CodeNotFound, http.StatusNotFound, "CITY_NOT_FOUND"},
+		{"budgets exhausted", fmt.Errorf("%w, last error from weatherapi: %w", apierrors.ErrAllProvidersFailed, apierrors.ErrProviderBudgetExhausted), connect.CodeUnavailable, http.StatusServiceUnavailable, "PROVIDER_BUDGET_EXHAUSTED"},
+		{"all providers failed", fmt.Errorf("%w, last error from openweather: boom", apierrors.ErrAllProvidersFailed), connect.CodeUnavailable, http.StatusServiceUnavailable, "PROVIDERS_UNAVAILABLE"},
+		{"unknown", errors.New("boom"), connect.CodeInternal, http.StatusInternalServerError, "INTERNAL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			class := Classify(tt.err)
+			assert.Equal(t, tt.code, class.Code)
+			assert.Equal(t, tt.status, class.Status)
+			assert.Equal(t, tt.reason, class.Reason)
+		})
+	}
+}
+
+func TestToConnect_ClientErrorGetsPublicMessage(t *testing.T) {
+	cerr := ToConnect(fmt.Errorf("%w: empty", apierrors.ErrInvalidCity))
+
+	assert.Equal(t, connect.CodeInvalidArgument, cerr.Code())
+	assert.Equal(t, "Invalid city", cerr.Message())
+
+	require.Len(t, cerr.Details(), 1)
+	value, err := cerr.Details()[0].Value()
+	require.NoError(t, err)
+	info, ok := value.(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, "INVALID_CITY", info.GetReason())
+	assert.Equal(t, Domain, info.GetDomain())
+}
+
+func TestToConnect_ServerErrorHidesMessage(t *testing.T) {
+	err := fmt.Errorf("%w, last error from openweather: GET https://api.example.com/?appid=secret: boom", apierrors.ErrAllProvidersFailed)
+
+	cerr := ToConnect(err)
+
+	assert.Equal(t, connect.CodeUnavailable, cerr.Code())
+	assert.Equal(t, "Weather providers are unavailable", cerr.Message())
+	assert.NotContains(t, Message(err), "secret")
+}
+
+func TestToConnect_CanceledHidesProviderURL(t *testing.T) {
+	err := fmt.Errorf("failed to get weather from OpenWeather: %w", &url.Error{
+		Op:  "Get",
+		URL: "https://api.openweathermap.org/data/2.5/weather?q=Kyiv&appid=secret",
+		Err: context.Canceled,
+	})
+
+	cerr := ToConnect(err)
+
+	assert.Equal(t, connect.CodeCanceled, cerr.Code())
+	assert.Equal(t, "Request canceled", cerr.Message())
+	assert.NotContains(t, Message(err), "secret")
+}
+
+func TestToConnect_PassesConnectErrorThrough(t *testing.T) {
+	original := connect.NewError(connect.CodeFailedPrecondition, errors.New("custom"))
+
+	assert.Same(t, original, ToConnect(fmt.Errorf("wrapped: %w", original)))
+}
+
+func TestWriteHTTP(t *testing.T) {
+	t.Run("unauthenticated", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		WriteHTTP(w, apierrors.ErrMissingAPIKey)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, "API_KEY_MISSING", w.Header().Get(ReasonHeader))
+		assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+		assert.Equal(t, "Unauthorized\n", w.Body.String())
+	})
+
+	t.Run("internal", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		WriteHTTP(w, errors.New("dial tcp 10.0.0.1:6379: connection refused"))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, "INTERNAL", w.Header().Get(ReasonHeader))
+		assert.Empty(t, w.Header().Get("WWW-Authenticate"))
+		assert.Equal(t, "Internal server error\n", w.Body.String())
+	})
+}
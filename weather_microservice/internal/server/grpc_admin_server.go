@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"errors"
+	weatherv1 "weather_microservice/gen/go/weather/v1"
+	"weather_microservice/gen/go/weather/v1/weatherv1connect"
+	"weather_microservice/internal/server/errmap"
+	"weather_microservice/internal/weather_service"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type GRPCAdminServer struct {
+	weatherv1connect.UnimplementedWeatherAdminServiceHandler
+	service weather_service.WeatherService
+}
+
+func NewGRPCAdminServer(service weather_service.WeatherService) *GRPCAdminServer {
+	return &GRPCAdminServer{service: service}
+}
+
+func (s *GRPCAdminServer) GetCacheStats(
+	ctx context.Context,
+	_ *connect.Request[weatherv1.GetCacheStatsRequest],
+) (*connect.Response[weatherv1.GetCacheStatsResponse], error) {
+	stats, err := s.service.CacheStats(ctx)
+	if err != nil {
+		return nil, errmap.ToConnect(err)
+	}
+	converted, err := structpb.NewStruct(stats)
+	if err != nil {
+		return nil, errmap.ToConnect(err)
+	}
+	return connect.NewResponse(&weatherv1.GetCacheStatsResponse{Stats: converted}), nil
+}
+
+func (s *GRPCAdminServer) InspectCacheEntry(
+	ctx context.Context,
+	r *connect.Request[weatherv1.InspectCacheEntryRequest],
+) (*connect.Response[weatherv1.InspectCacheEntryResponse], error) {
+	loc := toLocation(r.Msg.City, r.Msg.CountryCode, r.Msg.Coordinates)
+	entry, err := s.service.InspectCache(ctx, loc)
+	if err != nil {
+		return nil, errmap.ToConnect(err)
+	}
+
+	return connect.NewResponse(&weatherv1.InspectCacheEntryResponse{
+		Key:  entry.Key,
+		Data: toWeatherResponse(entry.Data),
+		Ttl:  durationpb.New(entry.TTL),
+	}), nil
+}
+
+func (s *GRPCAdminServer) InvalidateCache(
+	ctx context.Context,
+	r *connect.Request[weatherv1.InvalidateCacheRequest],
+) (*connect.Response[weatherv1.InvalidateCacheResponse], error) {
+	var (
+		deleted int64
+		err     error
+	)
+	switch target := r.Msg.Target.(type) {
+	case *weatherv1.InvalidateCacheRequest_Pattern:
+		deleted, err = s.service.InvalidatePattern(ctx, target.Pattern)
+	case *weatherv1.InvalidateCacheRequest_Location:
+		loc := toLocation(target.Location.GetCity(), target.Location.GetCountryCode(), target.Location.GetCoordinates())
+		deleted, err = s.service.InvalidateLocation(ctx, loc)
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("location or pattern is required"))
+	}
+	if err != nil {
+		return nil, errmap.ToConnect(err)
+	}
+	return connect.NewResponse(&weatherv1.InvalidateCacheResponse{Deleted: deleted}), nil
+}
+
+func (s *GRPCAdminServer) FlushCache(
+	ctx context.Context,
+	_ *connect.Request[weatherv1.FlushCacheRequest],
+) (*connect.Response[weatherv1.FlushCacheResponse], error) {
+	deleted, err := s.service.FlushCache(ctx)
+	if err != nil {
+		return nil, errmap.ToConnect(err)
+	}
+	return connect.NewResponse(&weatherv1.FlushCacheResponse{Deleted: deleted}), nil
+}
+
+func (s *GRPCAdminServer) PurgeNotFound(
+	ctx context.Context,
+	_ *connect.Request[weatherv1.PurgeNotFoundRequest],
+) (*connect.Response[weatherv1.PurgeNotFoundResponse], error) {
+	purged, err := s.service.PurgeNotFound(ctx)
+	if err != nil {
+		return nil, errmap.ToConnect(err)
+	}
+	return connect.NewResponse(&weatherv1.PurgeNotFoundResponse{Purged: purged}), nil
+}
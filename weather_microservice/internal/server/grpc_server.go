@@ -2,11 +2,15 @@ package server
 
 import (
 	"context"
+	"time"
 	weatherv1 "weather_microservice/gen/go/weather/v1"
 	"weather_microservice/gen/go/weather/v1/weatherv1connect"
+	"weather_microservice/internal/contracts"
+	"weather_microservice/internal/server/errmap"
 	"weather_microservice/internal/weather_service"
 
 	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type GRPCWeatherServer struct {
@@ -21,15 +25,217 @@ func (s *GRPCWeatherServer) GetWeather(
 	ctx context.Context,
 	r *connect.Request[weatherv1.GetWeatherRequest],
 ) (*connect.Response[weatherv1.GetWeatherResponse], error) {
-	data, err := s.service.GetWeather(ctx, r.Msg.City)
+	loc := toLocation(r.Msg.City, r.Msg.CountryCode, r.Msg.Coordinates)
+	data, err := s.service.GetWeather(ctx, loc)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, errmap.ToConnect(err)
 	}
 
-	res := &weatherv1.GetWeatherResponse{
-		Temperature: data.Temperature,
-		Humidity:    data.Humidity,
-		Description: data.Description,
+	return connect.NewResponse(toWeatherResponse(data)), nil
+}
+
+func (s *GRPCWeatherServer) GetWeatherBatch(
+	ctx context.Context,
+	r *connect.Request[weatherv1.GetWeatherBatchRequest],
+) (*connect.Response[weatherv1.GetWeatherBatchResponse], error) {
+	locs := make([]contracts.Location, 0, len(r.Msg.Cities)+len(r.Msg.Locations))
+	for _, city := range r.Msg.Cities {
+		locs = append(locs, contracts.CityLocation(city))
+	}
+	for _, l := range r.Msg.Locations {
+		locs = append(locs, toLocation(l.City, l.CountryCode, l.Coordinates))
+	}
+
+	results, err := s.service.GetWeatherBatch(ctx, locs)
+	if err != nil {
+		return nil, errmap.ToConnect(err)
+	}
+
+	res := &weatherv1.GetWeatherBatchResponse{
+		Results: make([]*weatherv1.WeatherBatchResult, 0, len(results)),
+	}
+	for _, result := range results {
+		item := &weatherv1.WeatherBatchResult{
+			Location: fromLocation(result.Location),
+		}
+		if result.Err != nil {
+			item.ErrorCode = errmap.Classify(result.Err).Code.String()
+			item.Error = errmap.Message(result.Err)
+		} else {
+			item.Weather = toWeatherResponse(result.Data)
+		}
+		res.Results = append(res.Results, item)
 	}
 	return connect.NewResponse(res), nil
 }
+
+func (s *GRPCWeatherServer) WatchWeather(
+	ctx context.Context,
+	r *connect.Request[weatherv1.WatchWeatherRequest],
+	stream *connect.ServerStream[weatherv1.GetWeatherResponse],
+) error {
+	loc := toLocation(r.Msg.City, r.Msg.CountryCode, r.Msg.Coordinates)
+	updates, err := s.service.WatchWeather(ctx, loc)
+	if err != nil {
+		return errmap.ToConnect(err)
+	}
+	for data := range updates {
+		if err := stream.Send(toWeatherResponse(data)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toWeatherResponse converts domain weather data into the API message.
+func toWeatherResponse(data contracts.WeatherData) *weatherv1.GetWeatherResponse {
+	return &weatherv1.GetWeatherResponse{
+		Temperature:   data.Temperature,
+		Humidity:      data.Humidity,
+		Description:   data.Description,
+		Condition:     string(data.Condition),
+		Severity:      string(data.Severity),
+		Icon:          data.Icon,
+		Stale:         data.Stale,
+		FeelsLike:     data.FeelsLike,
+		WindSpeed:     data.WindSpeed,
+		WindDirection: data.WindDirection,
+		Pressure:      data.Pressure,
+		Precipitation: data.Precipitation,
+		CloudCover:    data.CloudCover,
+		UvIndex:       data.UVIndex,
+		Visibility:    data.Visibility,
+		FetchedAt:     optionalTimestamp(data.FetchedAt),
+		Sunrise:       optionalTimestamp(data.Sunrise),
+		Sunset:        optionalTimestamp(data.Sunset),
+		ObservedAt:    optionalTimestamp(data.ObservedAt),
+		Providers:     data.Providers,
+		Outliers:      data.Outliers,
+	}
+}
+
+// optionalTimestamp leaves unknown times unset instead of encoding year 1.
+func optionalTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func (s *GRPCWeatherServer) GetForecast(
+	ctx context.Context,
+	r *connect.Request[weatherv1.GetForecastRequest],
+) (*connect.Response[weatherv1.GetForecastResponse], error) {
+	loc := toLocation(r.Msg.City, r.Msg.CountryCode, r.Msg.Coordinates)
+	data, err := s.service.GetForecast(ctx, loc, int(r.Msg.Days))
+	if err != nil {
+		return nil, errmap.ToConnect(err)
+	}
+
+	res := &weatherv1.GetForecastResponse{
+		City:   data.City,
+		Hourly: make([]*weatherv1.HourlyForecast, 0, len(data.Hourly)),
+		Daily:  make([]*weatherv1.DailyForecast, 0, len(data.Daily)),
+	}
+	for _, h := range data.Hourly {
+		res.Hourly = append(res.Hourly, &weatherv1.HourlyForecast{
+			Time:        timestamppb.New(h.Time),
+			Temperature: h.Temperature,
+			Humidity:    h.Humidity,
+			Description: h.Description,
+		})
+	}
+	for _, d := range data.Daily {
+		res.Daily = append(res.Daily, &weatherv1.DailyForecast{
+			Date:           d.Date,
+			MinTemperature: d.MinTemperature,
+			MaxTemperature: d.MaxTemperature,
+			Humidity:       d.Humidity,
+			Description:    d.Description,
+		})
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (s *GRPCWeatherServer) GetWeatherHistory(
+	ctx context.Context,
+	r *connect.Request[weatherv1.GetWeatherHistoryRequest],
+) (*connect.Response[weatherv1.GetWeatherHistoryResponse], error) {
+	loc := toLocation(r.Msg.City, r.Msg.CountryCode, r.Msg.Coordinates)
+	var from, to time.Time
+	if r.Msg.From != nil {
+		from = r.Msg.From.AsTime()
+	}
+	if r.Msg.To != nil {
+		to = r.Msg.To.AsTime()
+	}
+
+	observations, err := s.service.GetHistory(ctx, loc, from, to)
+	if err != nil {
+		return nil, errmap.ToConnect(err)
+	}
+
+	res := &weatherv1.GetWeatherHistoryResponse{
+		Observations: make([]*weatherv1.WeatherObservation, 0, len(observations)),
+	}
+	for _, o := range observations {
+		res.Observations = append(res.Observations, &weatherv1.WeatherObservation{
+			City:        o.City,
+			Provider:    o.Provider,
+			ObservedAt:  timestamppb.New(o.ObservedAt),
+			Temperature: o.Temperature,
+			Humidity:    o.Humidity,
+			Description: o.Description,
+		})
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (s *GRPCWeatherServer) SearchCities(
+	ctx context.Context,
+	r *connect.Request[weatherv1.SearchCitiesRequest],
+) (*connect.Response[weatherv1.SearchCitiesResponse], error) {
+	cities, err := s.service.SearchCities(ctx, r.Msg.Query, int(r.Msg.Limit))
+	if err != nil {
+		return nil, errmap.ToConnect(err)
+	}
+
+	res := &weatherv1.SearchCitiesResponse{
+		Cities: make([]*weatherv1.City, 0, len(cities)),
+	}
+	for _, c := range cities {
+		res.Cities = append(res.Cities, &weatherv1.City{
+			Id:          c.ID,
+			Name:        c.Name,
+			Region:      c.Region,
+			Country:     c.Country,
+			CountryCode: c.CountryCode,
+			Coordinates: &weatherv1.Coordinates{Lat: c.Lat, Lon: c.Lon},
+		})
+	}
+	return connect.NewResponse(res), nil
+}
+
+// toLocation converts request location fields into the domain location.
+func toLocation(city, countryCode string, coords *weatherv1.Coordinates) contracts.Location {
+	loc := contracts.Location{
+		City:        city,
+		CountryCode: countryCode,
+	}
+	if coords != nil {
+		loc.Coordinates = &contracts.Coordinates{Lat: coords.Lat, Lon: coords.Lon}
+	}
+	return loc
+}
+
+// fromLocation converts a domain location back into request location fields.
+func fromLocation(loc contracts.Location) *weatherv1.GetWeatherRequest {
+	req := &weatherv1.GetWeatherRequest{
+		City:        loc.City,
+		CountryCode: loc.CountryCode,
+	}
+	if loc.Coordinates != nil {
+		req.Coordinates = &weatherv1.Coordinates{Lat: loc.Coordinates.Lat, Lon: loc.Coordinates.Lon}
+	}
+	return req
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"weather_microservice/internal/contracts"
+	"weather_microservice/internal/server/errmap"
+	"weather_microservice/internal/weather_service"
+)
+
+// AdminHandler handles cache and provider administration requests.
+type AdminHandler struct {
+	weatherService weather_service.WeatherService
+}
+
+// NewAdminHandler creates a new admin handler.
+func NewAdminHandler(weatherService weather_service.WeatherService) AdminHandler {
+	return AdminHandler{
+		weatherService: weatherService,
+	}
+}
+
+// PurgeNotFound removes all cached unknown cities.
+func (h AdminHandler) PurgeNotFound(w http.ResponseWriter, r *http.Request) {
+	purged, err := h.weatherService.PurgeNotFound(r.Context())
+	if err != nil {
+		errmap.WriteHTTP(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]int64{"purged": purged}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// cacheEntryResponse is the JSON view of a cached weather entry.
+type cacheEntryResponse struct {
+	Key        string                `json:"key"`
+	Data       contracts.WeatherData `json:"data"`
+	TTLSeconds float64               `json:"ttl_seconds"`
+}
+
+// GetCacheStats returns structured cache statistics.
+func (h AdminHandler) GetCacheStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.weatherService.CacheStats(r.Context())
+	if err != nil {
+		errmap.WriteHTTP(w, err)
+		return
+	}
+	writeJSON(w, stats)
+}
+
+// InspectCacheEntry returns the cached weather of a location with its remaining TTL.
+func (h AdminHandler) InspectCacheEntry(w http.ResponseWriter, r *http.Request) {
+	loc, err := parseLocation(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entry, err := h.weatherService.InspectCache(r.Context(), loc)
+	if err != nil {
+		errmap.WriteHTTP(w, err)
+		return
+	}
+	writeJSON(w, cacheEntryResponse{
+		Key:        entry.Key,
+		Data:       entry.Data,
+		TTLSeconds: entry.TTL.Seconds(),
+	})
+}
+
+// InvalidateCache removes cached entries of a location, or of all locations
+// matching the "pattern" query parameter.
+func (h AdminHandler) InvalidateCache(w http.ResponseWriter, r *http.Request) {
+	var (
+		deleted int64
+		err     error
+	)
+	if pattern := r.URL.Query().Get("pattern"); pattern != "" {
+		deleted, err = h.weatherService.InvalidatePattern(r.Context(), pattern)
+	} else {
+		loc, parseErr := parseLocation(r)
+		if parseErr != nil {
+			http.Error(w, parseErr.Error(), http.StatusBadRequest)
+			return
+		}
+		deleted, err = h.weatherService.InvalidateLocation(r.Context(), loc)
+	}
+	if err != nil {
+		errmap.WriteHTTP(w, err)
+		return
+	}
+	writeJSON(w, map[string]int64{"deleted": deleted})
+}
+
+// FlushCache removes every cached weather entry.
+func (h AdminHandler) FlushCache(w http.ResponseWriter, r *http.Request) {
+	deleted, err := h.weatherService.FlushCache(r.Context())
+	if err != nil {
+		errmap.WriteHTTP(w, err)
+		return
+	}
+	writeJSON(w, map[string]int64{"deleted": deleted})
+}
+
+// providerQuotasResponse is the JSON view of provider call budgets.
+type providerQuotasResponse struct {
+	Providers []contracts.ProviderQuota `json:"providers"`
+}
+
+// GetProviderQuotas returns the calls made to each provider today and this
+// month with the budget left.
+func (h AdminHandler) GetProviderQuotas(w http.ResponseWriter, r *http.Request) {
+	quotas, err := h.weatherService.ProviderQuotas(r.Context())
+	if err != nil {
+		errmap.WriteHTTP(w, err)
+		return
+	}
+	writeJSON(w, providerQuotasResponse{Providers: quotas})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"weather_microservice/internal/apikeys"
+	"weather_microservice/internal/server/errmap"
+)
+
+// APIKeyHandler handles API key administration requests.
+type APIKeyHandler struct {
+	auth *apikeys.Authenticator
+}
+
+// NewAPIKeyHandler creates a new API key handler; auth is nil when API keys are disabled.
+func NewAPIKeyHandler(auth *apikeys.Authenticator) APIKeyHandler {
+	return APIKeyHandler{auth: auth}
+}
+
+type apiKeyUsageResponse struct {
+	KeyID    string `json:"key_id"`
+	Day      string `json:"day"`
+	Requests int64  `json:"requests"`
+}
+
+// GetUsage returns how many requests a key made on ?day=YYYY-MM-DD (UTC), today by default.
+func (h APIKeyHandler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	if h.auth == nil {
+		http.Error(w, "API key authentication is disabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	day := time.Now().UTC()
+	if raw := r.URL.Query().Get("day"); raw != "" {
+		parsed, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			http.Error(w, "Day parameter must be a date", http.StatusBadRequest)
+			return
+		}
+		day = parsed
+	}
+
+	keyID := r.PathValue("id")
+	requests, err := h.auth.Usage(r.Context(), keyID, day)
+	if err != nil {
+		errmap.WriteHTTP(w, err)
+		return
+	}
+
+	writeJSON(w, apiKeyUsageResponse{
+		KeyID:    keyID,
+		Day:      day.Format(time.DateOnly),
+		Requests: requests,
+	})
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"weather_microservice/internal/contracts"
+	"weather_microservice/internal/server/errmap"
+)
+
+type citiesResponse struct {
+	Query  string           `json:"query"`
+	Cities []contracts.City `json:"cities"`
+}
+
+// SearchCities suggests cities for "q", e.g. for autocompletion of city inputs.
+// The optional "limit" parameter caps the number of suggestions.
+func (h WeatherHandler) SearchCities(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		http.Error(w, "Query parameter q is required", http.StatusBadRequest)
+		return
+	}
+
+	var limit int
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		var err error
+		limit, err = strconv.Atoi(raw)
+		if err != nil {
+			http.Error(w, "Limit parameter must be a number", http.StatusBadRequest)
+			return
+		}
+	}
+
+	cities, err := h.weatherService.SearchCities(r.Context(), query, limit)
+	if err != nil {
+		errmap.WriteHTTP(w, err)
+		return
+	}
+	if cities == nil {
+		cities = []contracts.City{}
+	}
+
+	writeJSON(w, citiesResponse{Query: query, Cities: cities})
+}
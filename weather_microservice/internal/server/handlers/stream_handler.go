@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"weather_microservice/internal/server/errmap"
+)
+
+// streamKeepAlive is how often an idle event stream gets a comment line,
+// so proxies do not close it.
+const streamKeepAlive = 15 * time.Second
+
+// StreamWeather streams weather updates as server-sent events: the current
+// weather first, then every refresh or change, each as a "weather" event.
+func (h WeatherHandler) StreamWeather(w http.ResponseWriter, r *http.Request) {
+	loc, err := parseLocation(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	updates, err := h.weatherService.WatchWeather(r.Context(), loc)
+	if err != nil {
+		errmap.WriteHTTP(w, err)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case data, ok := <-updates:
+			if !ok {
+				return
+			}
+			payload, err := json.Marshal(data)
+			if err != nil {
+				log.Printf("Failed to encode weather event: %v", err)
+				return
+			}
+			if _, err := fmt.Fprintf(w, "event: weather\ndata: %s\n\n", payload); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
-	"weather_microservice/internal/apierrors"
+	"strconv"
+	"time"
 	"weather_microservice/internal/contracts"
+	"weather_microservice/internal/server/errmap"
 	"weather_microservice/internal/weather_service"
 )
 
@@ -28,31 +30,156 @@ func (h WeatherHandler) GetWeather(w http.ResponseWriter, r *http.Request) { //
 		return
 	}
 
-	city := r.URL.Query().Get("city")
-	if city == "" {
-		http.Error(w, "City parameter is required", http.StatusBadRequest)
+	loc, err := parseLocation(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	weather, err := h.weatherService.GetWeather(r.Context(), city)
+	weather, err := h.weatherService.GetWeather(r.Context(), loc)
 	if err != nil {
-		switch {
-		case errors.Is(err, apierrors.ErrCityNotFound):
-			http.Error(w, "City not found", http.StatusNotFound)
-		default:
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
-		}
+		errmap.WriteHTTP(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if weather.Stale {
+		w.Header().Set("X-Weather-Stale", "true")
+	}
+	if err := json.NewEncoder(w).Encode(weather); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetForecast handles forecast requests.
+func (h WeatherHandler) GetForecast(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	loc, err := parseLocation(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	response := contracts.WeatherData{
-		Temperature: weather.Temperature,
-		Humidity:    weather.Humidity,
-		Description: weather.Description,
+
+	days := 1
+	if rawDays := r.URL.Query().Get("days"); rawDays != "" {
+		parsed, err := strconv.Atoi(rawDays)
+		if err != nil {
+			http.Error(w, "Days parameter must be a number", http.StatusBadRequest)
+			return
+		}
+		days = parsed
+	}
+
+	forecast, err := h.weatherService.GetForecast(r.Context(), loc, days)
+	if err != nil {
+		errmap.WriteHTTP(w, err)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(response); err != nil {
+	if err := json.NewEncoder(w).Encode(forecast); err != nil {
 		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 		return
 	}
 }
+
+type historyResponse struct {
+	City         string                  `json:"city"`
+	From         time.Time               `json:"from"`
+	To           time.Time               `json:"to"`
+	Observations []contracts.Observation `json:"observations"`
+}
+
+// GetHistory handles requests for stored weather observations.
+func (h WeatherHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	loc, err := parseLocation(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	from, err := parseTime(r.URL.Query().Get("from"))
+	if err != nil {
+		http.Error(w, "From parameter must be an RFC 3339 time or a date", http.StatusBadRequest)
+		return
+	}
+	to, err := parseTime(r.URL.Query().Get("to"))
+	if err != nil {
+		http.Error(w, "To parameter must be an RFC 3339 time or a date", http.StatusBadRequest)
+		return
+	}
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+	if from.IsZero() {
+		from = to.Add(-weather_service.DefaultHistoryPeriod)
+	}
+
+	observations, err := h.weatherService.GetHistory(r.Context(), loc, from, to)
+	if err != nil {
+		errmap.WriteHTTP(w, err)
+		return
+	}
+	if observations == nil {
+		observations = []contracts.Observation{}
+	}
+
+	writeJSON(w, historyResponse{
+		City:         loc.String(),
+		From:         from,
+		To:           to,
+		Observations: observations,
+	})
+}
+
+// parseTime accepts an RFC 3339 time or a plain date; an empty value yields the zero time.
+func parseTime(raw string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Parse(time.DateOnly, raw)
+}
+
+var (
+	errMissingLocation       = errors.New("city or lat/lon parameters are required")
+	errIncompleteCoordinates = errors.New("both lat and lon parameters are required")
+	errInvalidLat            = errors.New("lat parameter must be a number")
+	errInvalidLon            = errors.New("lon parameter must be a number")
+)
+
+// parseLocation reads the location from "lat"/"lon" or "city"/"country" query parameters.
+func parseLocation(r *http.Request) (contracts.Location, error) {
+	query := r.URL.Query()
+	rawLat, rawLon := query.Get("lat"), query.Get("lon")
+
+	if rawLat != "" || rawLon != "" {
+		if rawLat == "" || rawLon == "" {
+			return contracts.Location{}, errIncompleteCoordinates
+		}
+		lat, err := strconv.ParseFloat(rawLat, 64)
+		if err != nil {
+			return contracts.Location{}, errInvalidLat
+		}
+		lon, err := strconv.ParseFloat(rawLon, 64)
+		if err != nil {
+			return contracts.Location{}, errInvalidLon
+		}
+		loc := contracts.CoordinatesLocation(lat, lon)
+		loc.CountryCode = query.Get("country")
+		return loc, nil
+	}
+
+	city := query.Get("city")
+	if city == "" {
+		return contracts.Location{}, errMissingLocation
+	}
+	return contracts.Location{City: city, CountryCode: query.Get("country")}, nil
+}
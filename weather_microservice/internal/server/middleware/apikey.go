@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/apikeys"
+	"weather_microservice/internal/server/errmap"
+)
+
+// APIKeyAuth allows only requests carrying an API key with scope, taken from
+// "X-API-Key: <key>" or "Authorization: Bearer <key>". The authenticated key
+// is put into the request context. A nil authenticator disables the check.
+// Requests are counted against key quotas by APIKeyUsage.
+func APIKeyAuth(auth *apikeys.Authenticator, scope string) Middleware {
+	return func(next http.Handler) http.Handler {
+		if auth == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, err := auth.Authenticate(r.Context(), apiKeyFromHeader(r.Header), scope)
+			if err != nil {
+				errmap.WriteHTTP(w, authError(err))
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(apikeys.NewContext(r.Context(), key)))
+		})
+	}
+}
+
+// APIKeyUsage counts requests authenticated by APIKeyAuth against the daily
+// quota of their key. It goes after rate limits, so rejected requests do not
+// use the quota up.
+func APIKeyUsage(auth *apikeys.Authenticator) Middleware {
+	return func(next http.Handler) http.Handler {
+		if auth == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if key, ok := apikeys.FromContext(r.Context()); ok {
+				if err := auth.CountUsage(r.Context(), key); err != nil {
+					errmap.WriteHTTP(w, authError(err))
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// authError reports failures of the key store itself, e.g. Redis being down,
+// as authentication being unavailable rather than an internal error.
+func authError(err error) error {
+	if errmap.Classify(err).Internal() {
+		return fmt.Errorf("%w: %w", apierrors.ErrAuthUnavailable, err)
+	}
+	return err
+}
+
+// apiKeyFromHeader returns the API key of a request, preferring X-API-Key.
+func apiKeyFromHeader(h http.Header) string {
+	if key := h.Get("X-API-Key"); key != "" {
+		return key
+	}
+	key, _ := strings.CutPrefix(h.Get("Authorization"), "Bearer ")
+	return strings.TrimSpace(key)
+}
@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"connectrpc.com/connect"
+
+	"weather_microservice/internal/apikeys"
+	"weather_microservice/internal/contracts"
+	"weather_microservice/internal/ratelimit"
+	"weather_microservice/internal/server/errmap"
+)
+
+// AdminAuthInterceptor is the Connect counterpart of AdminAuth.
+func AdminAuthInterceptor(token string) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if token == "" {
+				return nil, connect.NewError(connect.CodePermissionDenied, errors.New("admin API is disabled"))
+			}
+			if !validBearer(req.Header().Get("Authorization"), token) {
+				return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("unauthorized"))
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// ConnectLimits are the rate limits applied by APIKeyInterceptor, see
+// RateLimit and AuthFailureLimit. A nil store disables its limit.
+type ConnectLimits struct {
+	Store        ratelimit.Store
+	Route        string
+	Limit        ratelimit.Limit
+	FailureStore ratelimit.Store
+	Failures     ratelimit.Limit
+	TrustProxy   bool
+}
+
+// APIKeyInterceptor is the Connect counterpart of AuthFailureLimit,
+// APIKeyAuth, RateLimit and APIKeyUsage, applied in that order. Unlike a
+// unary interceptor it also guards streaming RPCs. A nil authenticator
+// disables the key checks, leaving the per-IP rate limit.
+func APIKeyInterceptor(auth *apikeys.Authenticator, scope string, limits ConnectLimits) connect.Interceptor {
+	if limits.Limit.Unlimited() {
+		limits.Store = nil
+	}
+	if auth == nil || limits.Failures.Unlimited() {
+		limits.FailureStore = nil
+	}
+	return &apiKeyInterceptor{auth: auth, scope: scope, limits: limits}
+}
+
+type apiKeyInterceptor struct {
+	auth   *apikeys.Authenticator
+	scope  string
+	limits ConnectLimits
+}
+
+func (i *apiKeyInterceptor) disabled() bool {
+	return i.auth == nil && i.limits.Store == nil
+}
+
+func (i *apiKeyInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	if i.disabled() {
+		return next
+	}
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		ctx, err := i.guard(ctx, req.Header(), req.Peer().Addr)
+		if err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *apiKeyInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *apiKeyInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	if i.disabled() {
+		return next
+	}
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, err := i.guard(ctx, conn.RequestHeader(), conn.Peer().Addr)
+		if err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+// guard checks the API key of a request from remoteAddr, applies the rate
+// limit and counts the request against the key quota. The key is put into
+// the returned context.
+func (i *apiKeyInterceptor) guard(ctx context.Context, header http.Header, remoteAddr string) (context.Context, error) {
+	ip := peerIP(header, remoteAddr, i.limits.TrustProxy)
+	if i.auth != nil {
+		key, err := i.authenticate(ctx, header, ip)
+		if err != nil {
+			return ctx, err
+		}
+		ctx = apikeys.NewContext(ctx, key)
+	}
+	if err := i.rateLimit(ctx, ip); err != nil {
+		return ctx, err
+	}
+	if key, ok := apikeys.FromContext(ctx); ok && i.auth != nil {
+		if err := i.auth.CountUsage(ctx, key); err != nil {
+			return ctx, errmap.ToConnect(authError(err))
+		}
+	}
+	return ctx, nil
+}
+
+// authenticate checks the API key of a request from ip. Like
+// AuthFailureLimit, it refuses IPs that used up their failed checks before
+// asking the key store and counts only rejected keys.
+func (i *apiKeyInterceptor) authenticate(ctx context.Context, header http.Header, ip string) (contracts.APIKey, error) {
+	store, limit := i.limits.FailureStore, i.limits.Failures
+	if store != nil {
+		res, err := store.Peek(ctx, authFailureKey(ip), limit)
+		if err != nil {
+			log.Printf("Rate limiter unavailable, allowing request: %v", err)
+		} else if !res.Allowed {
+			return contracts.APIKey{}, tooManyRequests(res, "too many failed authentication attempts")
+		}
+	}
+
+	key, err := i.auth.Authenticate(ctx, apiKeyFromHeader(header), i.scope)
+	if err == nil {
+		return key, nil
+	}
+	cerr := errmap.ToConnect(authError(err))
+	if store != nil && cerr.Code() == connect.CodeUnauthenticated {
+		if _, err := store.Take(ctx, authFailureKey(ip), limit); err != nil {
+			log.Printf("Failed to count failed authentication: %v", err)
+		}
+	}
+	return contracts.APIKey{}, cerr
+}
+
+// rateLimit takes a token of the client of ctx, identified by its key or ip.
+func (i *apiKeyInterceptor) rateLimit(ctx context.Context, ip string) error {
+	if i.limits.Store == nil {
+		return nil
+	}
+	res, err := i.limits.Store.Take(ctx, i.limits.Route+":"+limitKey(ctx, ip), i.limits.Limit)
+	if err != nil {
+		log.Printf("Rate limiter unavailable, allowing request: %v", err)
+		return nil
+	}
+	if !res.Allowed {
+		return tooManyRequests(res, "too many requests")
+	}
+	return nil
+}
+
+// tooManyRequests is the Connect counterpart of a 429 with Retry-After.
+func tooManyRequests(res ratelimit.Result, message string) *connect.Error {
+	cerr := connect.NewError(connect.CodeResourceExhausted, errors.New(message))
+	cerr.Meta().Set("Retry-After", retryAfter(res))
+	return cerr
+}
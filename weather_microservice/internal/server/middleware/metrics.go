@@ -0,0 +1,14 @@
+package middleware
+
+import "time"
+
+// Metrics collects HTTP request metrics.
+type Metrics interface {
+	// ObserveHTTPRequest records a served request; route is the matched mux pattern.
+	ObserveHTTPRequest(method, route string, status int, duration time.Duration)
+}
+
+// NoopMetrics is an empty implementation used when metrics are not needed.
+type NoopMetrics struct{}
+
+func (NoopMetrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {}
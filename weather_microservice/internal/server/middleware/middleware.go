@@ -1,9 +1,11 @@
 package middleware
 
 import (
+	"crypto/subtle"
 	"log"
 	"net/http"
 	"runtime/debug"
+	"strings"
 	"time"
 )
 
@@ -24,8 +26,8 @@ func CORS() Middleware {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Origin, Authorization, Content-Type, Accept")
-			w.Header().Set("Access-Control-Expose-Headers", "Content-Length")
+			w.Header().Set("Access-Control-Allow-Headers", "Origin, Authorization, Content-Type, Accept, X-API-Key")
+			w.Header().Set("Access-Control-Expose-Headers", "Content-Length, Retry-After, X-RateLimit-Limit, X-RateLimit-Remaining")
 			w.Header().Set("Access-Control-Allow-Credentials", "true")
 			w.Header().Set("Access-Control-Max-Age", "43200")
 
@@ -39,8 +41,11 @@ func CORS() Middleware {
 	}
 }
 
-// Logging logs HTTP requests.
-func Logging() Middleware {
+// Logging logs HTTP requests and records their status and duration.
+func Logging(metrics Metrics) Middleware {
+	if metrics == nil {
+		metrics = NoopMetrics{}
+	}
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
@@ -48,11 +53,22 @@ func Logging() Middleware {
 			lw := &loggingWriter{ResponseWriter: w, statusCode: http.StatusOK}
 			next.ServeHTTP(lw, r)
 
-			log.Printf("%s %s %d %v", r.Method, r.URL.Path, lw.statusCode, time.Since(start))
+			duration := time.Since(start)
+			log.Printf("%s %s %d %v", r.Method, r.URL.Path, lw.statusCode, duration)
+			metrics.ObserveHTTPRequest(r.Method, routeLabel(r), lw.statusCode, duration)
 		})
 	}
 }
 
+// routeLabel returns the mux pattern the request matched, so metrics are not
+// labeled with raw paths like /api/confirm/<token>.
+func routeLabel(r *http.Request) string {
+	if r.Pattern == "" {
+		return "unmatched"
+	}
+	return r.Pattern
+}
+
 // Recovery recovers from panics.
 func Recovery() Middleware {
 	return func(next http.Handler) http.Handler {
@@ -68,6 +84,42 @@ func Recovery() Middleware {
 	}
 }
 
+// NoWriteTimeout lifts the server write timeout for long-lived streaming responses.
+func NoWriteTimeout() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+				log.Printf("Failed to lift write timeout of %s: %v", r.URL.Path, err)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// validBearer reports whether the Authorization header carries the expected bearer token.
+func validBearer(header, token string) bool {
+	provided, ok := strings.CutPrefix(header, "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1
+}
+
+// AdminAuth allows only requests carrying "Authorization: Bearer <token>".
+// Admin endpoints are disabled when no token is configured.
+func AdminAuth(token string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token == "" {
+				http.Error(w, "Admin API is disabled", http.StatusForbidden)
+				return
+			}
+			if !validBearer(r.Header.Get("Authorization"), token) {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 type loggingWriter struct {
 	http.ResponseWriter
 	statusCode int
@@ -77,3 +129,8 @@ func (lw *loggingWriter) WriteHeader(code int) {
 	lw.statusCode = code
 	lw.ResponseWriter.WriteHeader(code)
 }
+
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to flush streams.
+func (lw *loggingWriter) Unwrap() http.ResponseWriter {
+	return lw.ResponseWriter
+}
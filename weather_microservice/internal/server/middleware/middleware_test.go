@@ -1,9 +1,22 @@
 package middleware
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"weather_microservice/internal/apikeys"
+	"weather_microservice/internal/config"
+	"weather_microservice/internal/contracts"
+	"weather_microservice/internal/ratelimit"
 )
 
 func TestChain(t *testing.T) {
@@ -102,8 +115,8 @@ func TestCORS(t *testing.T) {
 				expectedHeaders := map[string]string{
 					"Access-Control-Allow-Origin":      "*",
 					"Access-Control-Allow-Methods":     "GET, POST, PUT, PATCH, DELETE, OPTIONS",
-					"Access-Control-Allow-Headers":     "Origin, Authorization, Content-Type, Accept",
-					"Access-Control-Expose-Headers":    "Content-Length",
+					"Access-Control-Allow-Headers":     "Origin, Authorization, Content-Type, Accept, X-API-Key",
+					"Access-Control-Expose-Headers":    "Content-Length, Retry-After, X-RateLimit-Limit, X-RateLimit-Remaining",
 					"Access-Control-Allow-Credentials": "true",
 					"Access-Control-Max-Age":           "43200",
 				}
@@ -126,7 +139,7 @@ func TestLogging(t *testing.T) {
 		}
 	})
 
-	loggingHandler := Logging()(baseHandler)
+	loggingHandler := Logging(nil)(baseHandler)
 
 	req := httptest.NewRequest(http.MethodGet, "/test", nil)
 	w := httptest.NewRecorder()
@@ -258,3 +271,466 @@ func TestMiddlewareOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestAdminAuth(t *testing.T) {
+	baseHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name   string
+		token  string
+		header string
+		want   int
+	}{
+		{name: "disabled without token", token: "", header: "Bearer ", want: http.StatusForbidden},
+		{name: "missing header", token: "secret", header: "", want: http.StatusUnauthorized},
+		{name: "wrong token", token: "secret", header: "Bearer nope", want: http.StatusUnauthorized},
+		{name: "valid token", token: "secret", header: "Bearer secret", want: http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/admin/cache/not-found", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			AdminAuth(tt.token)(baseHandler).ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
+
+func TestAdminAuthInterceptor(t *testing.T) {
+	next := func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	tests := []struct {
+		name   string
+		token  string
+		header string
+		want   connect.Code
+	}{
+		{name: "disabled without token", token: "", header: "Bearer ", want: connect.CodePermissionDenied},
+		{name: "missing header", token: "secret", header: "", want: connect.CodeUnauthenticated},
+		{name: "wrong token", token: "secret", header: "Bearer nope", want: connect.CodeUnauthenticated},
+		{name: "valid token", token: "secret", header: "Bearer secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := connect.NewRequest(&emptypb.Empty{})
+			if tt.header != "" {
+				req.Header().Set("Authorization", tt.header)
+			}
+
+			_, err := AdminAuthInterceptor(tt.token)(next)(context.Background(), req)
+
+			if tt.want == 0 {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if code := connect.CodeOf(err); code != tt.want {
+				t.Errorf("expected code %v, got %v", tt.want, code)
+			}
+		})
+	}
+}
+
+// recordingMetrics remembers observed HTTP requests.
+type recordingMetrics struct {
+	routes   []string
+	statuses []int
+}
+
+func (m *recordingMetrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	m.routes = append(m.routes, method+" "+route)
+	m.statuses = append(m.statuses, status)
+}
+
+func TestLogging_RecordsMetrics(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/confirm/{token}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	metrics := &recordingMetrics{}
+	handler := Logging(metrics)(mux)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/confirm/abc", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/nope", nil))
+
+	// Routes are labeled with the mux pattern, not the raw path.
+	wantRoutes := []string{"GET GET /api/confirm/{token}", "GET unmatched"}
+	for i, want := range wantRoutes {
+		if metrics.routes[i] != want {
+			t.Errorf("expected route %q, got %q", want, metrics.routes[i])
+		}
+		if metrics.statuses[i] != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", metrics.statuses[i])
+		}
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	baseHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := RateLimit(ratelimit.NewMemoryStore(), "weather", ratelimit.PerMinute(1, 2), false)(baseHandler)
+
+	request := func(remoteAddr, apiKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/weather?city=Kyiv", nil)
+		req.RemoteAddr = remoteAddr
+		if apiKey != "" {
+			req = req.WithContext(apikeys.NewContext(req.Context(), contracts.APIKey{ID: apiKey}))
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	for i := 0; i < 2; i++ {
+		if w := request("10.0.0.1:1234", ""); w.Code != http.StatusOK {
+			t.Fatalf("request %d: expected status 200, got %d", i, w.Code)
+		}
+	}
+
+	w := request("10.0.0.1:5678", "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "60" {
+		t.Errorf("expected Retry-After 60, got %q", got)
+	}
+
+	if w := request("10.0.0.2:1234", ""); w.Code != http.StatusOK {
+		t.Errorf("another IP: expected status 200, got %d", w.Code)
+	}
+	if w := request("10.0.0.1:1234", "client-key"); w.Code != http.StatusOK {
+		t.Errorf("authenticated key: expected status 200, got %d", w.Code)
+	}
+}
+
+func TestRateLimit_DefaultConfigLetsSchedulerBurstThrough(t *testing.T) {
+	t.Setenv("RATE_LIMIT_ENABLED", "")
+	cfg := config.Load()
+
+	// Like bootstrap.InitRateLimitStore, a disabled limiter has no store.
+	var store ratelimit.Store
+	if cfg.RateLimit.Enabled {
+		store = ratelimit.NewMemoryStore()
+	}
+	rule := cfg.RateLimit.Routes["weather"]
+	handler := RateLimit(store, "weather", ratelimit.PerMinute(rule.PerMinute, rule.Burst), cfg.RateLimit.TrustProxy)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+
+	// The scheduler fetches weather for every hourly subscription from one
+	// IP with 10 workers.
+	const workers, subscriptions = 10, 500
+	codes := make(chan int, subscriptions)
+	var wg sync.WaitGroup
+	for worker := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range subscriptions / workers {
+				req := httptest.NewRequest(http.MethodGet, "/api/weather?city=Kyiv", nil)
+				req.RemoteAddr = fmt.Sprintf("10.0.0.7:%d", 40000+worker)
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, req)
+				codes <- w.Code
+			}
+		}()
+	}
+	wg.Wait()
+	close(codes)
+
+	for code := range codes {
+		if code != http.StatusOK {
+			t.Fatalf("expected every scheduler request to pass, got status %d", code)
+		}
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+
+	if got := clientIP(req, false); got != "10.0.0.1" {
+		t.Errorf("expected remote address, got %q", got)
+	}
+	if got := clientIP(req, true); got != "203.0.113.7" {
+		t.Errorf("expected forwarded address, got %q", got)
+	}
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	auth := apikeys.NewAuthenticator(apikeys.NewFileStore(map[string]contracts.APIKey{
+		apikeys.Hash("reader"): {ID: "reader", Scopes: []string{contracts.ScopeWeatherRead}},
+		apikeys.Hash("capped"): {ID: "capped", Scopes: []string{contracts.ScopeWeatherRead}, DailyQuota: 1},
+	}))
+	var authenticated string
+	baseHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, _ := apikeys.FromContext(r.Context())
+		authenticated = key.ID
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name   string
+		scope  string
+		header string
+		value  string
+		want   int
+	}{
+		{name: "missing key", scope: contracts.ScopeWeatherRead, want: http.StatusUnauthorized},
+		{name: "unknown key", scope: contracts.ScopeWeatherRead, header: "X-API-Key", value: "nope", want: http.StatusUnauthorized},
+		{name: "x-api-key header", scope: contracts.ScopeWeatherRead, header: "X-API-Key", value: "reader", want: http.StatusOK},
+		{name: "bearer header", scope: contracts.ScopeWeatherRead, header: "Authorization", value: "Bearer reader", want: http.StatusOK},
+		{name: "missing scope", scope: contracts.ScopeSubscribe, header: "X-API-Key", value: "reader", want: http.StatusForbidden},
+		{name: "within quota", scope: contracts.ScopeWeatherRead, header: "X-API-Key", value: "capped", want: http.StatusOK},
+		{name: "over quota", scope: contracts.ScopeWeatherRead, header: "X-API-Key", value: "capped", want: http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/weather?city=Kyiv", nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			w := httptest.NewRecorder()
+
+			Chain(baseHandler, APIKeyAuth(auth, tt.scope), APIKeyUsage(auth)).ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+	if authenticated != "capped" {
+		t.Errorf("expected authenticated key in context, got %q", authenticated)
+	}
+}
+
+func TestAPIKeyUsage_NotCountedWhenRateLimited(t *testing.T) {
+	auth := apikeys.NewAuthenticator(apikeys.NewFileStore(map[string]contracts.APIKey{
+		apikeys.Hash("capped"): {ID: "capped", Scopes: []string{contracts.ScopeWeatherRead}, DailyQuota: 2},
+	}))
+	handler := Chain(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+		APIKeyAuth(auth, contracts.ScopeWeatherRead),
+		RateLimit(ratelimit.NewMemoryStore(), "weather", ratelimit.PerMinute(1, 1), false),
+		APIKeyUsage(auth),
+	)
+
+	var codes []int
+	for range 3 {
+		req := httptest.NewRequest(http.MethodGet, "/api/weather?city=Kyiv", nil)
+		req.Header.Set("X-API-Key", "capped")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		codes = append(codes, w.Code)
+	}
+	if codes[0] != http.StatusOK || codes[1] != http.StatusTooManyRequests || codes[2] != http.StatusTooManyRequests {
+		t.Fatalf("unexpected statuses %v", codes)
+	}
+
+	used, err := auth.Usage(context.Background(), "capped", time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if used != 1 {
+		t.Errorf("expected only the allowed request to count, got %d", used)
+	}
+}
+
+func TestAuthFailureLimit(t *testing.T) {
+	lookups := 0
+	auth := apikeys.NewAuthenticator(countingKeyStore{
+		APIKeyStore: apikeys.NewFileStore(map[string]contracts.APIKey{
+			apikeys.Hash("reader"): {ID: "reader", Scopes: []string{contracts.ScopeWeatherRead}},
+		}),
+		lookups: &lookups,
+	})
+	handler := Chain(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+		AuthFailureLimit(ratelimit.NewMemoryStore(), ratelimit.PerMinute(1, 2), false),
+		APIKeyAuth(auth, contracts.ScopeWeatherRead),
+	)
+	request := func(remoteAddr, key string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/weather?city=Kyiv", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-API-Key", key)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Valid keys do not use the limit up.
+	for range 5 {
+		if code := request("10.0.0.1:1234", "reader"); code != http.StatusOK {
+			t.Fatalf("valid key: expected status 200, got %d", code)
+		}
+	}
+	for range 2 {
+		if code := request("10.0.0.1:1234", "guess"); code != http.StatusUnauthorized {
+			t.Fatalf("guess: expected status 401, got %d", code)
+		}
+	}
+
+	before := lookups
+	if code := request("10.0.0.1:1234", "guess"); code != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429 after failed attempts, got %d", code)
+	}
+	if lookups != before {
+		t.Errorf("expected the key store not to be asked once the limit is used up")
+	}
+	if code := request("10.0.0.2:1234", "guess"); code != http.StatusUnauthorized {
+		t.Errorf("another IP: expected status 401, got %d", code)
+	}
+}
+
+// countingKeyStore counts key lookups.
+type countingKeyStore struct {
+	contracts.APIKeyStore
+	lookups *int
+}
+
+func (s countingKeyStore) Lookup(ctx context.Context, hash string) (contracts.APIKey, error) {
+	*s.lookups++
+	return s.APIKeyStore.Lookup(ctx, hash)
+}
+
+func TestAPIKeyInterceptor(t *testing.T) {
+	auth := apikeys.NewAuthenticator(apikeys.NewFileStore(map[string]contracts.APIKey{
+		apikeys.Hash("reader"): {ID: "reader", Scopes: []string{contracts.ScopeWeatherRead}},
+	}))
+	next := connect.UnaryFunc(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	})
+
+	tests := []struct {
+		name  string
+		scope string
+		key   string
+		want  connect.Code
+	}{
+		{name: "missing key", scope: contracts.ScopeWeatherRead, want: connect.CodeUnauthenticated},
+		{name: "missing scope", scope: contracts.ScopeSubscribe, key: "reader", want: connect.CodePermissionDenied},
+		{name: "valid key", scope: contracts.ScopeWeatherRead, key: "reader"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := connect.NewRequest(&emptypb.Empty{})
+			if tt.key != "" {
+				req.Header().Set("X-API-Key", tt.key)
+			}
+
+			_, err := APIKeyInterceptor(auth, tt.scope, ConnectLimits{}).WrapUnary(next)(context.Background(), req)
+
+			if tt.want == 0 {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if got := connect.CodeOf(err); got != tt.want {
+				t.Errorf("expected code %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAPIKeyInterceptor_Limits(t *testing.T) {
+	lookups := 0
+	auth := apikeys.NewAuthenticator(countingKeyStore{
+		APIKeyStore: apikeys.NewFileStore(map[string]contracts.APIKey{
+			apikeys.Hash("reader"): {ID: "reader", Scopes: []string{contracts.ScopeWeatherRead}, DailyQuota: 10},
+		}),
+		lookups: &lookups,
+	})
+	interceptor := APIKeyInterceptor(auth, contracts.ScopeWeatherRead, ConnectLimits{
+		Store:        ratelimit.NewMemoryStore(),
+		Route:        "grpc",
+		Limit:        ratelimit.PerMinute(1, 2),
+		FailureStore: ratelimit.NewMemoryStore(),
+		Failures:     ratelimit.PerMinute(1, 2),
+		TrustProxy:   true,
+	})
+	call := interceptor.WrapUnary(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	})
+	request := func(ip, key string) error {
+		req := connect.NewRequest(&emptypb.Empty{})
+		req.Header().Set("X-Forwarded-For", ip)
+		req.Header().Set("X-API-Key", key)
+		_, err := call(context.Background(), req)
+		return err
+	}
+
+	for range 2 {
+		if code := connect.CodeOf(request("203.0.113.7", "guess")); code != connect.CodeUnauthenticated {
+			t.Fatalf("guess: expected code %v, got %v", connect.CodeUnauthenticated, code)
+		}
+	}
+	before := lookups
+	err := request("203.0.113.7", "guess")
+	if code := connect.CodeOf(err); code != connect.CodeResourceExhausted {
+		t.Fatalf("expected code %v after failed attempts, got %v", connect.CodeResourceExhausted, code)
+	}
+	if lookups != before {
+		t.Errorf("expected the key store not to be asked once the limit is used up")
+	}
+	var cerr *connect.Error
+	if !errors.As(err, &cerr) || cerr.Meta().Get("Retry-After") == "" {
+		t.Errorf("expected Retry-After metadata, got %v", err)
+	}
+
+	// The key has its own limit, and refused calls do not use its quota up.
+	for range 2 {
+		if err := request("203.0.113.8", "reader"); err != nil {
+			t.Fatalf("valid key: expected no error, got %v", err)
+		}
+	}
+	if code := connect.CodeOf(request("203.0.113.9", "reader")); code != connect.CodeResourceExhausted {
+		t.Fatalf("expected code %v over the rate limit, got %v", connect.CodeResourceExhausted, code)
+	}
+	used, err := auth.Usage(context.Background(), "reader", time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if used != 2 {
+		t.Errorf("expected 2 counted calls, got %d", used)
+	}
+}
+
+func TestLogging_AllowsFlushing(t *testing.T) {
+	handler := Logging(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := http.NewResponseController(w).Flush(); err != nil {
+			t.Errorf("expected flush to reach the underlying writer, got %v", err)
+		}
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/weather/stream", nil))
+
+	if !w.Flushed {
+		t.Error("expected response to be flushed")
+	}
+}
@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"weather_microservice/internal/apikeys"
+	"weather_microservice/internal/ratelimit"
+)
+
+// RateLimit limits every client of a route to limit using token buckets in
+// store. Clients are told when to retry with a 429 and a Retry-After header.
+// When the store fails, requests are let through.
+func RateLimit(store ratelimit.Store, route string, limit ratelimit.Limit, trustProxy bool) Middleware {
+	return func(next http.Handler) http.Handler {
+		if store == nil || limit.Unlimited() {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			res, err := store.Take(r.Context(), route+":"+clientKey(r, trustProxy), limit)
+			if err != nil {
+				log.Printf("Rate limiter unavailable, allowing request: %v", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit.Burst))
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			if !res.Allowed {
+				w.Header().Set("Retry-After", retryAfter(res))
+				http.Error(w, "Too many requests", http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// AuthFailureLimit limits failed API key checks of every client IP to limit.
+// Once an IP has used it up, its requests get a 429 before the key store is
+// asked, so keys cannot be guessed at full speed. Requests with a valid key
+// do not count. It goes in front of APIKeyAuth.
+func AuthFailureLimit(store ratelimit.Store, limit ratelimit.Limit, trustProxy bool) Middleware {
+	return func(next http.Handler) http.Handler {
+		if store == nil || limit.Unlimited() {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := authFailureKey(clientIP(r, trustProxy))
+			res, err := store.Peek(r.Context(), key, limit)
+			if err != nil {
+				log.Printf("Rate limiter unavailable, allowing request: %v", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !res.Allowed {
+				w.Header().Set("Retry-After", retryAfter(res))
+				http.Error(w, "Too many failed authentication attempts", http.StatusTooManyRequests)
+				return
+			}
+
+			lw := &loggingWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(lw, r)
+			if lw.statusCode == http.StatusUnauthorized {
+				if _, err := store.Take(r.Context(), key, limit); err != nil {
+					log.Printf("Failed to count failed authentication: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// authFailureKey names the bucket of failed key checks of ip.
+func authFailureKey(ip string) string {
+	return "auth-failures:ip:" + ip
+}
+
+// retryAfter formats the wait of res in whole seconds for Retry-After.
+func retryAfter(res ratelimit.Result) string {
+	return strconv.Itoa(int(math.Ceil(res.RetryAfter.Seconds())))
+}
+
+// clientKey identifies the client by its authenticated API key or, without
+// one, by its IP. Only keys checked by APIKeyAuth count, so clients cannot
+// escape their limit by sending made-up keys.
+func clientKey(r *http.Request, trustProxy bool) string {
+	return limitKey(r.Context(), clientIP(r, trustProxy))
+}
+
+// limitKey identifies the client by the API key in ctx or by ip.
+func limitKey(ctx context.Context, ip string) string {
+	if key, ok := apikeys.FromContext(ctx); ok {
+		return "key:" + key.ID
+	}
+	return "ip:" + ip
+}
+
+// clientIP returns the remote address of the request. Behind a trusted proxy
+// the first address of X-Forwarded-For is used instead.
+func clientIP(r *http.Request, trustProxy bool) string {
+	return peerIP(r.Header, r.RemoteAddr, trustProxy)
+}
+
+// peerIP is clientIP for a request with header sent from remoteAddr.
+func peerIP(header http.Header, remoteAddr string, trustProxy bool) string {
+	if trustProxy {
+		if forwarded := header.Get("X-Forwarded-For"); forwarded != "" {
+			first, _, _ := strings.Cut(forwarded, ",")
+			if ip := strings.TrimSpace(first); ip != "" {
+				return ip
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
@@ -4,8 +4,14 @@ package server
 import (
 	"net/http"
 
+	"connectrpc.com/connect"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"weather_microservice/internal/apikeys"
 	"weather_microservice/internal/client"
 	"weather_microservice/internal/config"
+	"weather_microservice/internal/contracts"
+	"weather_microservice/internal/ratelimit"
 	"weather_microservice/internal/server/handlers"
 	"weather_microservice/internal/server/middleware"
 	"weather_microservice/internal/weather_service"
@@ -15,33 +21,130 @@ type Router struct {
 	mux                 *http.ServeMux
 	weatherHandler      handlers.WeatherHandler
 	subscriptionHandler handlers.SubscriptionHandler
+	adminHandler        handlers.AdminHandler
+	apiKeyHandler       handlers.APIKeyHandler
+	adminToken          string
+	rateLimits          config.RateLimitConfig
+	rateLimitStore      ratelimit.Store
+	authFailureStore    ratelimit.Store
+	auth                *apikeys.Authenticator
 }
 
-func NewRouter(cfg *config.Config, weatherService weather_service.WeatherService) http.Handler {
+// NewRouter creates the HTTP API. A nil rateLimitStore disables rate limiting
+// and a nil auth disables API keys. Failed key checks are limited whenever
+// keys are enabled, in memory if rate limiting is disabled.
+func NewRouter(
+	cfg *config.Config,
+	weatherService weather_service.WeatherService,
+	rateLimitStore ratelimit.Store,
+	auth *apikeys.Authenticator,
+) http.Handler {
 	subscriptionClient := client.NewSubscriptionClient(cfg.SubscriptionServiceURL)
 
 	router := &Router{
 		mux:                 http.NewServeMux(),
 		weatherHandler:      handlers.NewWeatherHandler(weatherService),
 		subscriptionHandler: handlers.NewSubscriptionHandler(subscriptionClient),
+		adminHandler:        handlers.NewAdminHandler(weatherService),
+		apiKeyHandler:       handlers.NewAPIKeyHandler(auth),
+		adminToken:          cfg.AdminToken,
+		rateLimits:          cfg.RateLimit,
+		rateLimitStore:      rateLimitStore,
+		auth:                auth,
+		authFailureStore:    authFailureStore(rateLimitStore, auth),
 	}
 
 	router.setupRoutes()
 
+	httpMetrics := middleware.NewPrometheusMetrics()
+	httpMetrics.Register()
+
 	return middleware.Chain(
 		router.mux,
 		middleware.CORS(),
-		middleware.Logging(),
+		middleware.Logging(httpMetrics),
 		middleware.Recovery(),
 	)
 }
 
 func (r *Router) setupRoutes() {
-	// Weather route
-	r.mux.HandleFunc("GET /api/weather", r.weatherHandler.GetWeather)
+	// Weather routes
+	r.mux.Handle("GET /api/weather", r.protect("weather", contracts.ScopeWeatherRead, r.weatherHandler.GetWeather))
+	r.mux.Handle("GET /api/forecast", r.protect("forecast", contracts.ScopeWeatherRead, r.weatherHandler.GetForecast))
+	r.mux.Handle("GET /api/weather/history", r.protect("history", contracts.ScopeWeatherRead, r.weatherHandler.GetHistory))
+	r.mux.Handle("GET /api/weather/stream", middleware.NoWriteTimeout()(
+		r.protect("stream", contracts.ScopeWeatherRead, r.weatherHandler.StreamWeather),
+	))
+	r.mux.Handle("GET /api/cities/search", r.protect("cities", contracts.ScopeWeatherRead, r.weatherHandler.SearchCities))
 
 	// Subscription routes
-	r.mux.HandleFunc("POST /api/subscribe", r.subscriptionHandler.Subscribe)
+	r.mux.Handle("POST /api/subscribe", r.protect("subscribe", contracts.ScopeSubscribe, r.subscriptionHandler.Subscribe))
 	r.mux.HandleFunc("GET /api/confirm/{token}", r.subscriptionHandler.Confirm)
 	r.mux.HandleFunc("GET /api/unsubscribe/{token}", r.subscriptionHandler.Unsubscribe)
+
+	// Prometheus metrics of all registered collectors
+	r.mux.Handle("GET /metrics", promhttp.Handler())
+
+	// Admin routes
+	admin := middleware.AdminAuth(r.adminToken)
+	r.mux.Handle("GET /admin/cache/stats", admin(http.HandlerFunc(r.adminHandler.GetCacheStats)))
+	r.mux.Handle("GET /admin/cache/entries", admin(http.HandlerFunc(r.adminHandler.InspectCacheEntry)))
+	r.mux.Handle("DELETE /admin/cache/entries", admin(http.HandlerFunc(r.adminHandler.InvalidateCache)))
+	r.mux.Handle("DELETE /admin/cache", admin(http.HandlerFunc(r.adminHandler.FlushCache)))
+	r.mux.Handle("DELETE /admin/cache/not-found", admin(http.HandlerFunc(r.adminHandler.PurgeNotFound)))
+	r.mux.Handle("GET /admin/api-keys/{id}/usage", admin(http.HandlerFunc(r.apiKeyHandler.GetUsage)))
+	r.mux.Handle("GET /admin/providers/quota", admin(http.HandlerFunc(r.adminHandler.GetProviderQuotas)))
+}
+
+// NewAPIKeyInterceptor guards the Connect API like protect guards HTTP
+// routes, limiting it as the "grpc" route.
+func NewAPIKeyInterceptor(cfg *config.Config, rateLimitStore ratelimit.Store, auth *apikeys.Authenticator) connect.Interceptor {
+	rule := cfg.RateLimit.Routes["grpc"]
+	failures := cfg.RateLimit.Routes["auth_failures"]
+	return middleware.APIKeyInterceptor(auth, contracts.ScopeWeatherRead, middleware.ConnectLimits{
+		Store:        rateLimitStore,
+		Route:        "grpc",
+		Limit:        ratelimit.PerMinute(rule.PerMinute, rule.Burst),
+		FailureStore: authFailureStore(rateLimitStore, auth),
+		Failures:     ratelimit.PerMinute(failures.PerMinute, failures.Burst),
+		TrustProxy:   cfg.RateLimit.TrustProxy,
+	})
+}
+
+// authFailureStore returns the store of failed key checks: the rate limit
+// store, or memory when rate limiting is disabled. Without auth there are no
+// checks to limit.
+func authFailureStore(rateLimitStore ratelimit.Store, auth *apikeys.Authenticator) ratelimit.Store {
+	if auth == nil {
+		return nil
+	}
+	if rateLimitStore == nil {
+		return ratelimit.NewMemoryStore()
+	}
+	return rateLimitStore
+}
+
+// protect requires an API key with scope for handler and applies the rate
+// limit of route. Keys are checked first, so limits are kept per key, behind
+// the per-IP limit of failed checks. Requests count against key quotas only
+// once they pass the rate limit.
+func (r *Router) protect(route, scope string, handler http.HandlerFunc) http.Handler {
+	rule := r.rateLimits.Routes[route]
+	failures := r.rateLimits.Routes["auth_failures"]
+	return middleware.Chain(
+		handler,
+		middleware.AuthFailureLimit(
+			r.authFailureStore,
+			ratelimit.PerMinute(failures.PerMinute, failures.Burst),
+			r.rateLimits.TrustProxy,
+		),
+		middleware.APIKeyAuth(r.auth, scope),
+		middleware.RateLimit(
+			r.rateLimitStore,
+			route,
+			ratelimit.PerMinute(rule.PerMinute, rule.Burst),
+			r.rateLimits.TrustProxy,
+		),
+		middleware.APIKeyUsage(r.auth),
+	)
 }
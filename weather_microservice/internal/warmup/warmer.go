@@ -0,0 +1,166 @@
+package warmup
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"weather_microservice/internal/contracts"
+)
+
+// runWindow is how long after the scheduled run warmed data must stay fresh,
+// so every subscription of the run gets a cache hit.
+const runWindow = time.Minute
+
+// CitySource lists cities of confirmed subscriptions for a frequency.
+type CitySource interface {
+	ListCities(ctx context.Context, frequency string) ([]string, error)
+}
+
+// Refresher makes sure the cache holds weather that stays fresh until the given time.
+// It reports whether the providers were called.
+type Refresher interface {
+	WarmWeather(ctx context.Context, loc contracts.Location, freshUntil time.Time) (bool, error)
+}
+
+// Config describes when and how the cache is warmed.
+type Config struct {
+	// Lead is how long before a scheduled run the cache is warmed.
+	Lead time.Duration
+	// DailyHour is the local hour of the daily run.
+	DailyHour int
+	// Concurrency limits parallel provider lookups.
+	Concurrency int
+	// CacheTTL is how long cached weather stays fresh.
+	CacheTTL time.Duration
+}
+
+// Result summarizes one warm-up.
+type Result struct {
+	Cities    int
+	Refreshed int
+	Fresh     int
+	Failed    int
+}
+
+// Warmer pre-fetches weather for subscribed cities shortly before the
+// scheduler's hourly and daily runs, so sends never stampede the providers.
+type Warmer struct {
+	source    CitySource
+	refresher Refresher
+	cfg       Config
+	lead      time.Duration
+	now       func() time.Time
+}
+
+// NewWarmer creates a cache warmer. The lead time is shortened when the cache
+// TTL is too short for data warmed that early to survive the run.
+func NewWarmer(source CitySource, refresher Refresher, cfg Config) *Warmer {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+	lead := cfg.Lead
+	if cfg.CacheTTL > 0 && lead+runWindow > cfg.CacheTTL {
+		lead = max(cfg.CacheTTL-runWindow, cfg.CacheTTL/2)
+		log.Printf("Cache warm-up lead %v does not fit cache TTL %v, using %v", cfg.Lead, cfg.CacheTTL, lead)
+	}
+	return &Warmer{
+		source:    source,
+		refresher: refresher,
+		cfg:       cfg,
+		lead:      lead,
+		now:       time.Now,
+	}
+}
+
+// Run warms the cache before every scheduled run until ctx is done.
+func (w *Warmer) Run(ctx context.Context) {
+	for {
+		run := w.nextRun(w.now())
+		timer := time.NewTimer(run.Add(-w.lead).Sub(w.now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		res, err := w.WarmUp(ctx, run)
+		if err != nil {
+			log.Printf("Cache warm-up for %s failed: %v", run.Format("15:04"), err)
+			continue
+		}
+		log.Printf("Cache warm-up for %s: %d cities, %d refreshed, %d already fresh, %d failed",
+			run.Format("15:04"), res.Cities, res.Refreshed, res.Fresh, res.Failed)
+	}
+}
+
+// WarmUp refreshes weather of every city subscribed for the given run.
+func (w *Warmer) WarmUp(ctx context.Context, run time.Time) (Result, error) {
+	frequencies := []string{"hourly"}
+	if run.Hour() == w.cfg.DailyHour {
+		frequencies = append(frequencies, "daily")
+	}
+
+	seen := make(map[string]bool)
+	var locations []contracts.Location
+	for _, frequency := range frequencies {
+		cities, err := w.source.ListCities(ctx, frequency)
+		if err != nil {
+			return Result{}, err
+		}
+		for _, city := range cities {
+			loc := contracts.CityLocation(city)
+			if loc.IsEmpty() || seen[loc.Key()] {
+				continue
+			}
+			seen[loc.Key()] = true
+			locations = append(locations, loc)
+		}
+	}
+
+	freshUntil := run.Add(runWindow)
+	var refreshed, fresh, failed atomic.Int32
+	sem := make(chan struct{}, w.cfg.Concurrency)
+	var wg sync.WaitGroup
+	for _, loc := range locations {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(loc contracts.Location) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			ok, err := w.refresher.WarmWeather(ctx, loc, freshUntil)
+			switch {
+			case err != nil:
+				failed.Add(1)
+				log.Printf("Cache warm-up for %s failed: %v", loc, err)
+			case ok:
+				refreshed.Add(1)
+			default:
+				fresh.Add(1)
+			}
+		}(loc)
+	}
+	wg.Wait()
+
+	return Result{
+		Cities:    len(locations),
+		Refreshed: int(refreshed.Load()),
+		Fresh:     int(fresh.Load()),
+		Failed:    int(failed.Load()),
+	}, nil
+}
+
+// nextRun returns the first full hour that can still be warmed in time.
+func (w *Warmer) nextRun(now time.Time) time.Time {
+	next := now.Add(time.Hour)
+	run := time.Date(next.Year(), next.Month(), next.Day(), next.Hour(), 0, 0, 0, next.Location())
+	for !run.Add(-w.lead).After(now) {
+		run = run.Add(time.Hour)
+	}
+	return run
+}
@@ -0,0 +1,116 @@
+package warmup
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"weather_microservice/internal/contracts"
+)
+
+type stubSource struct {
+	cities map[string][]string
+	err    error
+}
+
+func (s stubSource) ListCities(ctx context.Context, frequency string) ([]string, error) {
+	return s.cities[frequency], s.err
+}
+
+type recordingRefresher struct {
+	mu         sync.Mutex
+	warmed     []string
+	freshUntil time.Time
+	fresh      map[string]bool
+	failing    map[string]bool
+}
+
+func (r *recordingRefresher) WarmWeather(ctx context.Context, loc contracts.Location, freshUntil time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.warmed = append(r.warmed, loc.Key())
+	r.freshUntil = freshUntil
+	if r.failing[loc.Key()] {
+		return true, errors.New("providers down")
+	}
+	return !r.fresh[loc.Key()], nil
+}
+
+func TestWarmer_WarmUpHourly(t *testing.T) {
+	source := stubSource{cities: map[string][]string{
+		"hourly": {"Kyiv", "kyiv", "Lviv", "Odesa"},
+		"daily":  {"Kharkiv"},
+	}}
+	refresher := &recordingRefresher{
+		fresh:   map[string]bool{"lviv": true},
+		failing: map[string]bool{"odesa": true},
+	}
+	w := NewWarmer(source, refresher, Config{Lead: 2 * time.Minute, DailyHour: 8, Concurrency: 2, CacheTTL: 10 * time.Minute})
+
+	run := time.Date(2025, 6, 24, 14, 0, 0, 0, time.UTC)
+	res, err := w.WarmUp(context.Background(), run)
+
+	require.NoError(t, err)
+	assert.Equal(t, Result{Cities: 3, Refreshed: 1, Fresh: 1, Failed: 1}, res)
+	sort.Strings(refresher.warmed)
+	assert.Equal(t, []string{"kyiv", "lviv", "odesa"}, refresher.warmed)
+	assert.Equal(t, run.Add(runWindow), refresher.freshUntil)
+}
+
+func TestWarmer_WarmUpIncludesDailyAtDailyHour(t *testing.T) {
+	source := stubSource{cities: map[string][]string{
+		"hourly": {"Kyiv"},
+		"daily":  {"Kharkiv", "KYIV"},
+	}}
+	refresher := &recordingRefresher{}
+	w := NewWarmer(source, refresher, Config{Lead: 2 * time.Minute, DailyHour: 8, Concurrency: 1, CacheTTL: 10 * time.Minute})
+
+	res, err := w.WarmUp(context.Background(), time.Date(2025, 6, 24, 8, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Cities)
+	sort.Strings(refresher.warmed)
+	assert.Equal(t, []string{"kharkiv", "kyiv"}, refresher.warmed)
+}
+
+func TestWarmer_WarmUpSourceError(t *testing.T) {
+	w := NewWarmer(stubSource{err: errors.New("unavailable")}, &recordingRefresher{}, Config{Concurrency: 1})
+
+	_, err := w.WarmUp(context.Background(), time.Now())
+	require.Error(t, err)
+}
+
+func TestWarmer_NextRun(t *testing.T) {
+	w := NewWarmer(stubSource{}, &recordingRefresher{}, Config{Lead: 2 * time.Minute, CacheTTL: 10 * time.Minute})
+
+	tests := []struct {
+		now  time.Time
+		want time.Time
+	}{
+		{now: time.Date(2025, 6, 24, 13, 10, 0, 0, time.UTC), want: time.Date(2025, 6, 24, 14, 0, 0, 0, time.UTC)},
+		{now: time.Date(2025, 6, 24, 13, 57, 59, 0, time.UTC), want: time.Date(2025, 6, 24, 14, 0, 0, 0, time.UTC)},
+		// Too late to warm 14:00 in time.
+		{now: time.Date(2025, 6, 24, 13, 58, 0, 0, time.UTC), want: time.Date(2025, 6, 24, 15, 0, 0, 0, time.UTC)},
+		{now: time.Date(2025, 6, 24, 23, 30, 0, 0, time.UTC), want: time.Date(2025, 6, 25, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, w.nextRun(tt.now), "now=%v", tt.now)
+	}
+}
+
+func TestNewWarmer_LeadFitsCacheTTL(t *testing.T) {
+	w := NewWarmer(stubSource{}, &recordingRefresher{}, Config{Lead: 5 * time.Minute, CacheTTL: 4 * time.Minute})
+	assert.Equal(t, 3*time.Minute, w.lead)
+
+	w = NewWarmer(stubSource{}, &recordingRefresher{}, Config{Lead: 5 * time.Minute, CacheTTL: time.Minute})
+	assert.Equal(t, 30*time.Second, w.lead)
+
+	w = NewWarmer(stubSource{}, &recordingRefresher{}, Config{Lead: 2 * time.Minute, CacheTTL: 10 * time.Minute})
+	assert.Equal(t, 2*time.Minute, w.lead)
+}
@@ -0,0 +1,95 @@
+package weather_service
+
+import (
+	"context"
+	"log"
+
+	"golang.org/x/sync/errgroup"
+
+	api_errors "weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+)
+
+const (
+	// MaxBatchSize limits locations in one batch lookup.
+	MaxBatchSize = 100
+	// batchConcurrency limits concurrent lookups of cache misses in one batch.
+	batchConcurrency = 8
+)
+
+// BatchResult is the weather of one location of a batch, or why it failed.
+type BatchResult struct {
+	Location contracts.Location
+	Data     contracts.WeatherData
+	Err      error
+}
+
+// GetWeatherBatch looks up the weather of several locations. Duplicate
+// locations are looked up once and reported once, in the order of their
+// first appearance. Cache hits are read in bulk and misses are fetched
+// concurrently. A failing location only fails its own result.
+func (s WeatherService) GetWeatherBatch(ctx context.Context, locs []contracts.Location) ([]BatchResult, error) {
+	unique := make([]contracts.Location, 0, len(locs))
+	seen := make(map[string]bool, len(locs))
+	for _, loc := range locs {
+		key := loc.Key()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, loc)
+	}
+	if len(unique) > MaxBatchSize {
+		return nil, api_errors.ErrBatchTooLarge
+	}
+
+	results := make([]BatchResult, len(unique))
+	valid := make([]contracts.Location, 0, len(unique))
+	for i, loc := range unique {
+		results[i].Location = loc
+		if err := validateLocation(loc); err != nil {
+			results[i].Err = err
+			continue
+		}
+		valid = append(valid, loc)
+	}
+
+	cached := s.getCachedMany(ctx, valid)
+
+	var g errgroup.Group
+	g.SetLimit(batchConcurrency)
+	for i := range results {
+		if results[i].Err != nil {
+			continue
+		}
+		g.Go(func() error {
+			loc := results[i].Location
+			data, ok := cached[loc.Key()]
+			results[i].Data, results[i].Err = s.resolveWeather(ctx, loc, data, ok)
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return results, nil
+}
+
+// getCachedMany reads cached weather of locations in bulk when the cache
+// supports it, and one by one otherwise. Cache errors are treated as misses.
+func (s WeatherService) getCachedMany(ctx context.Context, locs []contracts.Location) map[string]contracts.WeatherData {
+	if batch, ok := s.cache.(contracts.BatchWeatherCache); ok {
+		cached, err := batch.GetMany(ctx, locs)
+		if err == nil {
+			return cached
+		}
+		log.Printf("Batch cache lookup failed, fetching from providers: %v", err)
+		return map[string]contracts.WeatherData{}
+	}
+
+	cached := make(map[string]contracts.WeatherData, len(locs))
+	for _, loc := range locs {
+		if data, err := s.cache.Get(ctx, loc); err == nil {
+			cached[loc.Key()] = data
+		}
+	}
+	return cached
+}
@@ -0,0 +1,72 @@
+package weather_service
+
+import (
+	"context"
+
+	api_errors "weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+)
+
+// InspectCache returns the cached weather entry of a location.
+// It returns ErrCacheMiss when the location is not cached.
+func (s WeatherService) InspectCache(ctx context.Context, loc contracts.Location) (contracts.CacheEntry, error) {
+	if err := validateLocation(loc); err != nil {
+		return contracts.CacheEntry{}, err
+	}
+	admin, err := s.cacheAdmin()
+	if err != nil {
+		return contracts.CacheEntry{}, err
+	}
+	entry, ok, err := admin.Inspect(ctx, loc)
+	if err != nil {
+		return contracts.CacheEntry{}, err
+	}
+	if !ok {
+		return contracts.CacheEntry{}, api_errors.ErrCacheMiss
+	}
+	return entry, nil
+}
+
+// InvalidateLocation removes cached weather, forecasts and the not found marker of a location.
+func (s WeatherService) InvalidateLocation(ctx context.Context, loc contracts.Location) (int64, error) {
+	if err := validateLocation(loc); err != nil {
+		return 0, err
+	}
+	admin, err := s.cacheAdmin()
+	if err != nil {
+		return 0, err
+	}
+	return admin.InvalidateLocation(ctx, loc)
+}
+
+// InvalidatePattern removes cached entries whose location key matches the glob pattern.
+func (s WeatherService) InvalidatePattern(ctx context.Context, pattern string) (int64, error) {
+	admin, err := s.cacheAdmin()
+	if err != nil {
+		return 0, err
+	}
+	return admin.InvalidatePattern(ctx, pattern)
+}
+
+// FlushCache removes every cached weather entry.
+func (s WeatherService) FlushCache(ctx context.Context) (int64, error) {
+	admin, err := s.cacheAdmin()
+	if err != nil {
+		return 0, err
+	}
+	return admin.Flush(ctx)
+}
+
+// CacheStats returns statistics of the weather cache.
+func (s WeatherService) CacheStats(ctx context.Context) (map[string]interface{}, error) {
+	return s.cache.GetStats(ctx)
+}
+
+// cacheAdmin returns the admin view of the weather cache, if it supports one.
+func (s WeatherService) cacheAdmin() (contracts.CacheAdmin, error) {
+	admin, ok := s.cache.(contracts.CacheAdmin)
+	if !ok {
+		return nil, api_errors.ErrCacheUnavailable
+	}
+	return admin, nil
+}
@@ -0,0 +1,63 @@
+package weather_service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	api_errors "weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+)
+
+const (
+	// DefaultCitySearchLimit is used when a city search has no limit.
+	DefaultCitySearchLimit = 5
+	// MaxCitySearchLimit caps the results of one city search.
+	MaxCitySearchLimit = 10
+	// minCityQueryLength avoids asking providers for every first keystroke.
+	minCityQueryLength = 2
+	maxCityQueryLength = 100
+)
+
+// SetCityCache enables caching city search results for expiration.
+func (s *WeatherService) SetCityCache(cache contracts.CityCache, expiration time.Duration) {
+	s.cityCache = cache
+	s.cityExpiration = expiration
+}
+
+// SearchCities returns cities matching the beginning of their name, best match
+// first. A non-positive limit means DefaultCitySearchLimit; larger limits than
+// MaxCitySearchLimit are capped.
+func (s WeatherService) SearchCities(ctx context.Context, query string, limit int) ([]contracts.City, error) {
+	query = strings.TrimSpace(query)
+	if n := utf8.RuneCountInString(query); n < minCityQueryLength || n > maxCityQueryLength {
+		return nil, fmt.Errorf("%w: must be %d to %d characters long", api_errors.ErrInvalidCityQuery, minCityQueryLength, maxCityQueryLength)
+	}
+	if limit <= 0 {
+		limit = DefaultCitySearchLimit
+	}
+	limit = min(limit, MaxCitySearchLimit)
+
+	if s.cityCache != nil {
+		if cities, err := s.cityCache.GetCities(ctx, query, limit); err == nil {
+			return cities, nil
+		}
+	}
+
+	key := fmt.Sprintf("cities:%s:%d", strings.ToLower(query), limit)
+	return coalesce(ctx, s, "cities", key, func(ctx context.Context) ([]contracts.City, error) {
+		cities, err := s.weatherChain.SearchCities(ctx, query, limit)
+		if err != nil {
+			return nil, err
+		}
+		if s.cityCache != nil {
+			if err := s.cityCache.SetCities(ctx, query, limit, cities, s.cityExpiration); err != nil {
+				log.Printf("Failed to cache cities for %q: %v", query, err)
+			}
+		}
+		return cities, nil
+	})
+}
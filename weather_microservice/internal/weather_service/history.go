@@ -0,0 +1,42 @@
+package weather_service
+
+import (
+	"context"
+	"time"
+
+	api_errors "weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+)
+
+const (
+	// DefaultHistoryPeriod is used when a history request has no start time.
+	DefaultHistoryPeriod = 24 * time.Hour
+	// MaxHistoryPoints limits observations returned by one history request.
+	MaxHistoryPoints = 1000
+)
+
+// SetHistory enables reading stored weather observations.
+func (s *WeatherService) SetHistory(history contracts.HistoryStore) {
+	s.history = history
+}
+
+// GetHistory returns stored observations of a location in [from, to).
+// A zero to means now, a zero from means DefaultHistoryPeriod before to.
+func (s WeatherService) GetHistory(ctx context.Context, loc contracts.Location, from, to time.Time) ([]contracts.Observation, error) {
+	if err := validateLocation(loc); err != nil {
+		return nil, err
+	}
+	if s.history == nil {
+		return nil, api_errors.ErrHistoryDisabled
+	}
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+	if from.IsZero() {
+		from = to.Add(-DefaultHistoryPeriod)
+	}
+	if !from.Before(to) {
+		return nil, api_errors.ErrInvalidTimeRange
+	}
+	return s.history.History(ctx, loc, from, to, MaxHistoryPoints)
+}
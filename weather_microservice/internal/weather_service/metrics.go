@@ -0,0 +1,15 @@
+package weather_service
+
+// Metrics collects weather service metrics.
+type Metrics interface {
+	IncUpstreamRequests(operation string)
+	IncCoalescedRequests(operation string)
+	IncStaleResponses(operation, reason string)
+}
+
+// NoopMetrics is an empty implementation used when metrics are not needed.
+type NoopMetrics struct{}
+
+func (NoopMetrics) IncUpstreamRequests(operation string)       {}
+func (NoopMetrics) IncCoalescedRequests(operation string)      {}
+func (NoopMetrics) IncStaleResponses(operation, reason string) {}
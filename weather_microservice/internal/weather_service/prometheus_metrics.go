@@ -0,0 +1,46 @@
+package weather_service
+
+import "github.com/prometheus/client_golang/prometheus"
+
+type PrometheusMetrics struct {
+	upstreamRequests  *prometheus.CounterVec
+	coalescedRequests *prometheus.CounterVec
+	staleResponses    *prometheus.CounterVec
+}
+
+func NewPrometheusMetrics() *PrometheusMetrics {
+	return &PrometheusMetrics{
+		upstreamRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "weather_service_upstream_requests_total",
+			Help: "Total number of lookups that went to the provider chain after a cache miss",
+		}, []string{"operation"}),
+		coalescedRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "weather_service_coalesced_requests_total",
+			Help: "Total number of lookups served by an identical in-flight provider chain call",
+		}, []string{"operation"}),
+		staleResponses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "weather_service_stale_responses_total",
+			Help: "Total number of responses served from expired cache entries (reason: revalidate, error)",
+		}, []string{"operation", "reason"}),
+	}
+}
+
+func (m *PrometheusMetrics) Register() {
+	prometheus.MustRegister(
+		m.upstreamRequests,
+		m.coalescedRequests,
+		m.staleResponses,
+	)
+}
+
+func (m *PrometheusMetrics) IncUpstreamRequests(operation string) {
+	m.upstreamRequests.WithLabelValues(operation).Inc()
+}
+
+func (m *PrometheusMetrics) IncCoalescedRequests(operation string) {
+	m.coalescedRequests.WithLabelValues(operation).Inc()
+}
+
+func (m *PrometheusMetrics) IncStaleResponses(operation, reason string) {
+	m.staleResponses.WithLabelValues(operation, reason).Inc()
+}
@@ -0,0 +1,23 @@
+package weather_service
+
+import (
+	"context"
+
+	api_errors "weather_microservice/internal/apierrors"
+	"weather_microservice/internal/contracts"
+)
+
+// SetProviderQuotas enables reporting of provider call budgets.
+func (s *WeatherService) SetProviderQuotas(reporter contracts.ProviderQuotaReporter) {
+	s.providerQuotas = reporter
+}
+
+// ProviderQuotas returns the calls made to each provider in the current day
+// and month with the budget left. It returns ErrProviderQuotaDisabled when
+// calls are not tracked.
+func (s WeatherService) ProviderQuotas(ctx context.Context) ([]contracts.ProviderQuota, error) {
+	if s.providerQuotas == nil {
+		return nil, api_errors.ErrProviderQuotaDisabled
+	}
+	return s.providerQuotas.ProviderQuotas(ctx)
+}
@@ -0,0 +1,128 @@
+package weather_service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"weather_microservice/internal/contracts"
+)
+
+// WatchPollInterval is how often watchers re-read the weather of their
+// location, picking up refreshes made by other replicas and refreshing
+// expired entries themselves.
+const WatchPollInterval = 30 * time.Second
+
+// WatchWeather streams the weather of a location until ctx is done, starting
+// with the current weather. A new value is sent whenever the weather is
+// refreshed or changes. The channel is closed when ctx is done.
+func (s WeatherService) WatchWeather(ctx context.Context, loc contracts.Location) (<-chan contracts.WeatherData, error) {
+	current, err := s.GetWeather(ctx, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshed, unsubscribe := s.updates.subscribe(loc.Key())
+	out := make(chan contracts.WeatherData, 1)
+	out <- current
+
+	go func() {
+		defer close(out)
+		defer unsubscribe()
+
+		ticker := time.NewTicker(WatchPollInterval)
+		defer ticker.Stop()
+
+		last := current
+		for {
+			var next contracts.WeatherData
+			select {
+			case <-ctx.Done():
+				return
+			case next = <-refreshed:
+			case <-ticker.C:
+				data, err := s.GetWeather(ctx, loc)
+				if err != nil {
+					if ctx.Err() == nil {
+						log.Printf("Failed to refresh watched weather for %s: %v", loc, err)
+					}
+					continue
+				}
+				next = data
+			}
+			if sameWeather(last, next) {
+				continue
+			}
+			select {
+			case out <- next:
+				last = next
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// sameWeather reports whether b carries nothing new compared to a.
+func sameWeather(a, b contracts.WeatherData) bool {
+	return a.FetchedAt.Equal(b.FetchedAt) &&
+		a.ObservedAt.Equal(b.ObservedAt) &&
+		a.Stale == b.Stale &&
+		a.Temperature == b.Temperature &&
+		a.Humidity == b.Humidity &&
+		a.Description == b.Description
+}
+
+// updateHub delivers freshly fetched weather to watchers of a location.
+type updateHub struct {
+	mu   sync.Mutex
+	subs map[string]map[chan contracts.WeatherData]struct{}
+}
+
+func newUpdateHub() *updateHub {
+	return &updateHub{subs: make(map[string]map[chan contracts.WeatherData]struct{})}
+}
+
+// subscribe registers a watcher of the location key.
+func (h *updateHub) subscribe(key string) (<-chan contracts.WeatherData, func()) {
+	ch := make(chan contracts.WeatherData, 1)
+	if h == nil {
+		return ch, func() {}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subs[key] == nil {
+		h.subs[key] = make(map[chan contracts.WeatherData]struct{})
+	}
+	h.subs[key][ch] = struct{}{}
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subs[key], ch)
+		if len(h.subs[key]) == 0 {
+			delete(h.subs, key)
+		}
+	}
+}
+
+// publish hands data to every watcher of the location key without blocking;
+// a watcher that has not picked up the previous value gets the newer one instead.
+func (h *updateHub) publish(key string, data contracts.WeatherData) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs[key] {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- data
+	}
+}
@@ -2,57 +2,272 @@ package weather_service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
+	"golang.org/x/sync/singleflight"
+
 	api_errors "weather_microservice/internal/apierrors"
 	"weather_microservice/internal/chain"
 	"weather_microservice/internal/contracts"
 )
 
+// MaxForecastDays is the longest forecast every provider can serve.
+const MaxForecastDays = 5
+
+// StalePolicy controls how long cached weather may still be served after its
+// expiration, in the spirit of RFC 5861 stale-while-revalidate and stale-if-error.
+type StalePolicy struct {
+	// WhileRevalidate serves expired data right away and refreshes it in the background.
+	WhileRevalidate time.Duration
+	// IfError serves expired data when every provider in the chain fails.
+	IfError time.Duration
+}
+
+// retention is how long past expiration an entry has to be kept in the cache.
+func (p StalePolicy) retention() time.Duration {
+	return max(p.WhileRevalidate, p.IfError, 0)
+}
+
 // WeatherServiceProvider defines the interface for weather service.
 type WeatherServiceProvider interface {
-	GetWeather(ctx context.Context, city string) (contracts.WeatherData, error)
+	GetWeather(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error)
+	GetForecast(ctx context.Context, loc contracts.Location, days int) (contracts.ForecastData, error)
 }
 
 // WeatherService implements WeatherServiceProvider using Chain of Responsibility.
 type WeatherService struct {
-	weatherChain    *chain.WeatherChain
-	cache           contracts.WeatherCache
-	cacheExpiration time.Duration
+	weatherChain       *chain.WeatherChain
+	cache              contracts.WeatherCache
+	forecastCache      contracts.ForecastCache
+	negativeCache      contracts.NegativeCache
+	cacheExpiration    time.Duration
+	forecastExpiration time.Duration
+	notFoundExpiration time.Duration
+	stale              StalePolicy
+	// inflight coalesces concurrent cache misses for the same location,
+	// so one provider chain call serves all waiters.
+	inflight *singleflight.Group
+	metrics  Metrics
+	history  contracts.HistoryStore
+	// updates delivers refreshed weather to WatchWeather callers.
+	updates        *updateHub
+	cityCache      contracts.CityCache
+	cityExpiration time.Duration
+	providerQuotas contracts.ProviderQuotaReporter
 }
 
 // NewWeatherService creates a new weatherService with the provided chain.
 func NewWeatherService(
 	weatherChain *chain.WeatherChain,
 	cache contracts.WeatherCache,
+	forecastCache contracts.ForecastCache,
+	negativeCache contracts.NegativeCache,
 	cacheExpiration time.Duration,
+	forecastExpiration time.Duration,
+	notFoundExpiration time.Duration,
+	stale StalePolicy,
+	metrics Metrics,
 ) WeatherService {
+	if metrics == nil {
+		metrics = NoopMetrics{}
+	}
 	return WeatherService{
-		weatherChain:    weatherChain,
-		cache:           cache,
-		cacheExpiration: cacheExpiration,
+		weatherChain:       weatherChain,
+		cache:              cache,
+		forecastCache:      forecastCache,
+		negativeCache:      negativeCache,
+		cacheExpiration:    cacheExpiration,
+		forecastExpiration: forecastExpiration,
+		notFoundExpiration: notFoundExpiration,
+		stale:              stale,
+		inflight:           &singleflight.Group{},
+		metrics:            metrics,
+		updates:            newUpdateHub(),
 	}
 }
 
-// GetWeather retrieves weather data for a city using the chain of providers.
-func (s WeatherService) GetWeather(ctx context.Context, city string) (contracts.WeatherData, error) {
+// GetWeather retrieves weather data for a location using the chain of providers.
+func (s WeatherService) GetWeather(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error) {
 	// Validate input.
-	if city == "" {
-		return contracts.WeatherData{}, api_errors.ErrInvalidCity
+	if err := validateLocation(loc); err != nil {
+		return contracts.WeatherData{}, err
 	}
 
 	// Try getting from cache.
-	if cachedData, err := s.cache.Get(ctx, city); err == nil {
-		return cachedData, nil
+	cachedData, err := s.cache.Get(ctx, loc)
+	return s.resolveWeather(ctx, loc, cachedData, err == nil)
+}
+
+// resolveWeather serves cached data while it is fresh enough and goes to the
+// providers otherwise, falling back to stale data when they fail.
+func (s WeatherService) resolveWeather(ctx context.Context, loc contracts.Location, cachedData contracts.WeatherData, hasCached bool) (contracts.WeatherData, error) {
+	if hasCached {
+		// Entries written without a timestamp are treated as fresh until Redis expires them.
+		age := time.Since(cachedData.FetchedAt)
+		switch {
+		case cachedData.FetchedAt.IsZero() || age <= s.cacheExpiration:
+			return cachedData, nil
+		case age <= s.cacheExpiration+s.stale.WhileRevalidate:
+			s.metrics.IncStaleResponses("weather", "revalidate")
+			go func() {
+				_, _ = s.fetchWeather(context.WithoutCancel(ctx), loc)
+			}()
+			cachedData.Stale = true
+			return cachedData, nil
+		}
+		hasCached = age <= s.cacheExpiration+s.stale.IfError
+	}
+
+	if !hasCached && s.isKnownNotFound(ctx, loc) {
+		return contracts.WeatherData{}, api_errors.ErrCityNotFound
 	}
 
 	// Use the chain to get weather data if cache miss or cache disabled.
-	data, err := s.weatherChain.GetWeather(ctx, city)
-	if err != nil {
-		return contracts.WeatherData{}, err
+	data, err := s.fetchWeather(ctx, loc)
+	if err != nil && hasCached && ctx.Err() == nil {
+		s.metrics.IncStaleResponses("weather", "error")
+		cachedData.Stale = true
+		return cachedData, nil
+	}
+	return data, err
+}
+
+// fetchWeather gets fresh data from the provider chain and stores it in cache.
+func (s WeatherService) fetchWeather(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error) {
+	return coalesce(ctx, s, "weather", "weather:"+loc.Key(), func(ctx context.Context) (contracts.WeatherData, error) {
+		data, err := s.weatherChain.GetWeather(ctx, loc)
+		if err != nil {
+			s.rememberNotFound(ctx, loc, err)
+			return contracts.WeatherData{}, err
+		}
+		data.FetchedAt = time.Now().UTC()
+		data.Stale = false
+		// The cache implementation will handle whether caching is enabled or not.
+		// The entry outlives its expiration so it can still be served stale.
+		_ = s.cache.Set(ctx, loc, data, s.cacheExpiration+s.stale.retention())
+		s.updates.publish(loc.Key(), data)
+		return data, nil
+	})
+}
+
+// GetForecast retrieves a multi-day forecast for a location using the chain of providers.
+func (s WeatherService) GetForecast(ctx context.Context, loc contracts.Location, days int) (contracts.ForecastData, error) {
+	if err := validateLocation(loc); err != nil {
+		return contracts.ForecastData{}, err
+	}
+	if days < 1 || days > MaxForecastDays {
+		return contracts.ForecastData{}, api_errors.ErrInvalidForecastDays
+	}
+
+	if cachedData, err := s.forecastCache.GetForecast(ctx, loc, days); err == nil {
+		return cachedData, nil
+	}
+
+	if s.isKnownNotFound(ctx, loc) {
+		return contracts.ForecastData{}, api_errors.ErrCityNotFound
 	}
-	// The cache implementation will handle whether caching is enabled or not.
-	_ = s.cache.Set(ctx, city, data, s.cacheExpiration)
 
-	return data, nil
+	key := fmt.Sprintf("forecast:%s:%d", loc.Key(), days)
+	return coalesce(ctx, s, "forecast", key, func(ctx context.Context) (contracts.ForecastData, error) {
+		data, err := s.weatherChain.GetForecast(ctx, loc, days)
+		if err != nil {
+			s.rememberNotFound(ctx, loc, err)
+			return contracts.ForecastData{}, err
+		}
+		_ = s.forecastCache.SetForecast(ctx, loc, days, data, s.forecastExpiration)
+		return data, nil
+	})
+}
+
+// WarmWeather makes sure the cache holds weather for the location that stays
+// fresh until freshUntil, calling the providers only when it would not.
+// It reports whether the providers were called.
+func (s WeatherService) WarmWeather(ctx context.Context, loc contracts.Location, freshUntil time.Time) (bool, error) {
+	if err := validateLocation(loc); err != nil {
+		return false, err
+	}
+	if cachedData, err := s.cache.Get(ctx, loc); err == nil && !cachedData.FetchedAt.IsZero() &&
+		cachedData.FetchedAt.Add(s.cacheExpiration).After(freshUntil) {
+		return false, nil
+	}
+	if _, err := s.fetchWeather(ctx, loc); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// PurgeNotFound removes all cached unknown locations.
+func (s WeatherService) PurgeNotFound(ctx context.Context) (int64, error) {
+	if s.negativeCache == nil {
+		return 0, nil
+	}
+	return s.negativeCache.PurgeNotFound(ctx)
+}
+
+// isKnownNotFound reports whether the location was recently not found by any provider.
+// Cache errors are treated as a miss.
+func (s WeatherService) isKnownNotFound(ctx context.Context, loc contracts.Location) bool {
+	if s.negativeCache == nil {
+		return false
+	}
+	notFound, err := s.negativeCache.IsNotFound(ctx, loc)
+	return err == nil && notFound
+}
+
+// rememberNotFound caches the location when no provider knows it,
+// so repeated lookups do not walk the whole chain.
+func (s WeatherService) rememberNotFound(ctx context.Context, loc contracts.Location, err error) {
+	if s.negativeCache == nil || !errors.Is(err, api_errors.ErrCityNotFound) {
+		return
+	}
+	_ = s.negativeCache.SetNotFound(ctx, loc, s.notFoundExpiration)
+}
+
+// coalesce runs fetch once per key for all concurrent callers.
+// The shared call is detached from the first caller's cancellation, so one
+// client hanging up does not fail the others; each caller still stops
+// waiting as soon as its own context is done.
+func coalesce[T any](ctx context.Context, s WeatherService, operation, key string, fetch func(context.Context) (T, error)) (T, error) {
+	if s.inflight == nil {
+		return fetch(ctx)
+	}
+
+	leader := false
+	ch := s.inflight.DoChan(key, func() (any, error) {
+		leader = true
+		s.metrics.IncUpstreamRequests(operation)
+		return fetch(context.WithoutCancel(ctx))
+	})
+
+	select {
+	case res := <-ch:
+		if !leader {
+			s.metrics.IncCoalescedRequests(operation)
+		}
+		if res.Err != nil {
+			var zero T
+			return zero, res.Err
+		}
+		return res.Val.(T), nil
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// validateLocation checks that a location names a city or carries valid coordinates.
+func validateLocation(loc contracts.Location) error {
+	if loc.HasCoordinates() {
+		lat, lon := loc.Coordinates.Lat, loc.Coordinates.Lon
+		if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+			return api_errors.ErrInvalidCoordinates
+		}
+		return nil
+	}
+	if loc.IsEmpty() {
+		return api_errors.ErrInvalidCity
+	}
+	return nil
 }
@@ -0,0 +1,566 @@
+package weather_service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"weather_microservice/internal/apierrors"
+	"weather_microservice/internal/cache"
+	"weather_microservice/internal/chain"
+	"weather_microservice/internal/contracts"
+	"weather_microservice/internal/logging"
+	"weather_microservice/internal/weather_service"
+)
+
+// blockingProvider holds every call until release is closed.
+type blockingProvider struct {
+	calls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func (p *blockingProvider) FetchWeather(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error) {
+	if p.calls.Add(1) == 1 {
+		close(p.started)
+	}
+	<-p.release
+	return contracts.WeatherData{Temperature: 20, Humidity: 50, Description: "Clear"}, nil
+}
+
+func (p *blockingProvider) FetchForecast(ctx context.Context, loc contracts.Location, days int) (contracts.ForecastData, error) {
+	return contracts.ForecastData{}, nil
+}
+
+type countingMetrics struct {
+	upstream, coalesced atomic.Int32
+}
+
+func (m *countingMetrics) IncUpstreamRequests(operation string)       { m.upstream.Add(1) }
+func (m *countingMetrics) IncCoalescedRequests(operation string)      { m.coalesced.Add(1) }
+func (m *countingMetrics) IncStaleResponses(operation, reason string) {}
+
+func newTestService(provider chain.WeatherAPIProvider, metrics weather_service.Metrics) weather_service.WeatherService {
+	weatherChain := chain.NewWeatherChain(logging.NewMockLogger())
+	weatherChain.AddHandler(chain.NewBaseWeatherHandler(provider, "stub"))
+	return weather_service.NewWeatherService(
+		weatherChain,
+		cache.NoopWeatherCache{},
+		cache.NoopWeatherCache{},
+		cache.NoopWeatherCache{},
+		time.Minute,
+		time.Minute,
+		time.Minute,
+		weather_service.StalePolicy{},
+		metrics,
+	)
+}
+
+func TestWeatherService_CoalescesConcurrentLookups(t *testing.T) {
+	provider := &blockingProvider{started: make(chan struct{}), release: make(chan struct{})}
+	metrics := &countingMetrics{}
+	svc := newTestService(provider, metrics)
+
+	// The first lookup becomes the leader and blocks inside the provider.
+	const waiters = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, waiters+1)
+	lookup := func(city string) {
+		defer wg.Done()
+		data, err := svc.GetWeather(context.Background(), contracts.CityLocation(city))
+		if err == nil && data.Temperature != 20 {
+			t.Errorf("unexpected temperature %v", data.Temperature)
+		}
+		errs <- err
+	}
+	wg.Add(1)
+	go lookup("Kyiv")
+	<-provider.started
+
+	// Differently spelled names normalize to the same key.
+	wg.Add(waiters)
+	for i := 0; i < waiters; i++ {
+		go lookup(" KYIV ")
+	}
+	require.Eventually(t, func() bool {
+		return metrics.upstream.Load() == 1
+	}, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond) // let waiters join the in-flight call
+	close(provider.release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), provider.calls.Load())
+	assert.Equal(t, int32(1), metrics.upstream.Load())
+	assert.Equal(t, int32(waiters), metrics.coalesced.Load())
+}
+
+func TestWeatherService_WaiterCancellationDoesNotAffectOthers(t *testing.T) {
+	provider := &blockingProvider{started: make(chan struct{}), release: make(chan struct{})}
+	svc := newTestService(provider, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := svc.GetWeather(ctx, contracts.CityLocation("Lviv"))
+		leaderErr <- err
+	}()
+	<-provider.started
+
+	waiterErr := make(chan error, 1)
+	go func() {
+		_, err := svc.GetWeather(context.Background(), contracts.CityLocation("Lviv"))
+		waiterErr <- err
+	}()
+	time.Sleep(20 * time.Millisecond) // let the waiter join the in-flight call
+
+	cancel()
+	require.ErrorIs(t, <-leaderErr, context.Canceled)
+
+	close(provider.release)
+	require.NoError(t, <-waiterErr)
+	assert.Equal(t, int32(1), provider.calls.Load())
+}
+
+// memoryCache is a minimal in-memory WeatherCache for service tests.
+type memoryCache struct {
+	mu      sync.Mutex
+	entries map[string]contracts.WeatherData
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{entries: make(map[string]contracts.WeatherData)}
+}
+
+func (c *memoryCache) Get(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, ok := c.entries[loc.Key()]
+	if !ok {
+		return contracts.WeatherData{}, errors.New("cache miss")
+	}
+	return data, nil
+}
+
+func (c *memoryCache) Set(ctx context.Context, loc contracts.Location, data contracts.WeatherData, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[loc.Key()] = data
+	return nil
+}
+
+func (c *memoryCache) Delete(ctx context.Context, loc contracts.Location) error { return nil }
+func (c *memoryCache) Exists(ctx context.Context, loc contracts.Location) (bool, error) {
+	return false, nil
+}
+func (c *memoryCache) Health(ctx context.Context) error { return nil }
+func (c *memoryCache) Close() error                     { return nil }
+func (c *memoryCache) GetStats(ctx context.Context) (map[string]interface{}, error) {
+	return nil, nil
+}
+
+// stubProvider returns fixed data or a fixed error.
+type stubProvider struct {
+	calls atomic.Int32
+	data  contracts.WeatherData
+	err   error
+}
+
+func (p *stubProvider) FetchWeather(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error) {
+	p.calls.Add(1)
+	return p.data, p.err
+}
+
+func (p *stubProvider) FetchForecast(ctx context.Context, loc contracts.Location, days int) (contracts.ForecastData, error) {
+	return contracts.ForecastData{}, p.err
+}
+
+func newStaleTestService(provider chain.WeatherAPIProvider, weatherCache contracts.WeatherCache) weather_service.WeatherService {
+	weatherChain := chain.NewWeatherChain(logging.NewMockLogger())
+	weatherChain.AddHandler(chain.NewBaseWeatherHandler(provider, "stub"))
+	return weather_service.NewWeatherService(
+		weatherChain,
+		weatherCache,
+		cache.NoopWeatherCache{},
+		cache.NoopWeatherCache{},
+		10*time.Minute,
+		time.Minute,
+		time.Minute,
+		weather_service.StalePolicy{WhileRevalidate: 5 * time.Minute, IfError: time.Hour},
+		nil,
+	)
+}
+
+func cachedAt(weatherCache *memoryCache, city string, age time.Duration) {
+	_ = weatherCache.Set(context.Background(), contracts.CityLocation(city), contracts.WeatherData{
+		Temperature: 10,
+		Description: "Cached",
+		FetchedAt:   time.Now().Add(-age),
+	}, 0)
+}
+
+func TestWeatherService_FreshCacheHit(t *testing.T) {
+	weatherCache := newMemoryCache()
+	cachedAt(weatherCache, "Kyiv", time.Minute)
+	provider := &stubProvider{}
+	svc := newStaleTestService(provider, weatherCache)
+
+	data, err := svc.GetWeather(context.Background(), contracts.CityLocation("Kyiv"))
+
+	require.NoError(t, err)
+	assert.False(t, data.Stale)
+	assert.Equal(t, "Cached", data.Description)
+	assert.Equal(t, int32(0), provider.calls.Load())
+}
+
+func TestWeatherService_StaleWhileRevalidate(t *testing.T) {
+	weatherCache := newMemoryCache()
+	cachedAt(weatherCache, "Kyiv", 12*time.Minute)
+	provider := &stubProvider{data: contracts.WeatherData{Temperature: 20, Description: "Fresh"}}
+	svc := newStaleTestService(provider, weatherCache)
+
+	data, err := svc.GetWeather(context.Background(), contracts.CityLocation("Kyiv"))
+
+	require.NoError(t, err)
+	assert.True(t, data.Stale)
+	assert.Equal(t, "Cached", data.Description)
+
+	// The background refresh replaces the entry with fresh data.
+	require.Eventually(t, func() bool {
+		cached, _ := weatherCache.Get(context.Background(), contracts.CityLocation("Kyiv"))
+		return cached.Description == "Fresh" && !cached.Stale && time.Since(cached.FetchedAt) < time.Minute
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int32(1), provider.calls.Load())
+}
+
+func TestWeatherService_StaleIfError(t *testing.T) {
+	weatherCache := newMemoryCache()
+	cachedAt(weatherCache, "Kyiv", 30*time.Minute)
+	provider := &stubProvider{err: errors.New("provider down")}
+	svc := newStaleTestService(provider, weatherCache)
+
+	data, err := svc.GetWeather(context.Background(), contracts.CityLocation("Kyiv"))
+
+	require.NoError(t, err)
+	assert.True(t, data.Stale)
+	assert.Equal(t, "Cached", data.Description)
+	assert.Equal(t, int32(1), provider.calls.Load())
+}
+
+func TestWeatherService_TooStaleReturnsError(t *testing.T) {
+	weatherCache := newMemoryCache()
+	cachedAt(weatherCache, "Kyiv", 2*time.Hour)
+	provider := &stubProvider{err: errors.New("provider down")}
+	svc := newStaleTestService(provider, weatherCache)
+
+	_, err := svc.GetWeather(context.Background(), contracts.CityLocation("Kyiv"))
+	require.Error(t, err)
+}
+
+func TestWeatherService_ExpiredEntryRefreshedWhenProvidersUp(t *testing.T) {
+	weatherCache := newMemoryCache()
+	cachedAt(weatherCache, "Kyiv", 30*time.Minute)
+	provider := &stubProvider{data: contracts.WeatherData{Temperature: 20, Description: "Fresh"}}
+	svc := newStaleTestService(provider, weatherCache)
+
+	data, err := svc.GetWeather(context.Background(), contracts.CityLocation("Kyiv"))
+
+	require.NoError(t, err)
+	assert.False(t, data.Stale)
+	assert.Equal(t, "Fresh", data.Description)
+	assert.False(t, data.FetchedAt.IsZero())
+}
+
+// memoryNegativeCache is a minimal in-memory NegativeCache for service tests.
+type memoryNegativeCache struct {
+	mu       sync.Mutex
+	notFound map[string]bool
+}
+
+func (c *memoryNegativeCache) IsNotFound(ctx context.Context, loc contracts.Location) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.notFound[loc.Key()], nil
+}
+
+func (c *memoryNegativeCache) SetNotFound(ctx context.Context, loc contracts.Location, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.notFound[loc.Key()] = true
+	return nil
+}
+
+func (c *memoryNegativeCache) PurgeNotFound(ctx context.Context) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	purged := int64(len(c.notFound))
+	c.notFound = make(map[string]bool)
+	return purged, nil
+}
+
+func TestWeatherService_CachesUnknownCities(t *testing.T) {
+	provider := &stubProvider{err: apierrors.ErrCityNotFound}
+	negativeCache := &memoryNegativeCache{notFound: make(map[string]bool)}
+	weatherChain := chain.NewWeatherChain(logging.NewMockLogger())
+	weatherChain.AddHandler(chain.NewBaseWeatherHandler(provider, "stub"))
+	svc := weather_service.NewWeatherService(
+		weatherChain,
+		cache.NoopWeatherCache{},
+		cache.NoopWeatherCache{},
+		negativeCache,
+		time.Minute,
+		time.Minute,
+		time.Minute,
+		weather_service.StalePolicy{},
+		nil,
+	)
+
+	for i := 0; i < 3; i++ {
+		_, err := svc.GetWeather(context.Background(), contracts.CityLocation("Atlantis"))
+		require.ErrorIs(t, err, apierrors.ErrCityNotFound)
+	}
+	_, err := svc.GetForecast(context.Background(), contracts.CityLocation("atlantis"), 1)
+	require.ErrorIs(t, err, apierrors.ErrCityNotFound)
+	assert.Equal(t, int32(1), provider.calls.Load(), "unknown city must hit the providers once")
+
+	purged, err := svc.PurgeNotFound(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	_, err = svc.GetWeather(context.Background(), contracts.CityLocation("Atlantis"))
+	require.ErrorIs(t, err, apierrors.ErrCityNotFound)
+	assert.Equal(t, int32(2), provider.calls.Load())
+}
+
+func TestWeatherService_WarmWeather(t *testing.T) {
+	weatherCache := newMemoryCache()
+	provider := &stubProvider{data: contracts.WeatherData{Temperature: 20, Description: "Fresh"}}
+	svc := newStaleTestService(provider, weatherCache)
+	ctx := context.Background()
+
+	// Fetched 2 minutes ago with a 10 minute TTL: fresh for another 8 minutes.
+	cachedAt(weatherCache, "Kyiv", 2*time.Minute)
+
+	refreshed, err := svc.WarmWeather(ctx, contracts.CityLocation("Kyiv"), time.Now().Add(5*time.Minute))
+	require.NoError(t, err)
+	assert.False(t, refreshed)
+	assert.Equal(t, int32(0), provider.calls.Load())
+
+	// Would expire before the run ends: refresh now.
+	refreshed, err = svc.WarmWeather(ctx, contracts.CityLocation("Kyiv"), time.Now().Add(9*time.Minute))
+	require.NoError(t, err)
+	assert.True(t, refreshed)
+	assert.Equal(t, int32(1), provider.calls.Load())
+
+	cached, err := weatherCache.Get(ctx, contracts.CityLocation("Kyiv"))
+	require.NoError(t, err)
+	assert.Equal(t, "Fresh", cached.Description)
+}
+
+type fakeHistory struct {
+	from, to time.Time
+	limit    int
+}
+
+func (h *fakeHistory) Record(ctx context.Context, loc contracts.Location, obs contracts.Observation) error {
+	return nil
+}
+
+func (h *fakeHistory) History(ctx context.Context, loc contracts.Location, from, to time.Time, limit int) ([]contracts.Observation, error) {
+	h.from, h.to, h.limit = from, to, limit
+	return []contracts.Observation{{City: loc.City, Provider: "stub", ObservedAt: from}}, nil
+}
+
+func (h *fakeHistory) Close() error { return nil }
+
+func TestWeatherService_GetHistory(t *testing.T) {
+	svc := newTestService(&stubProvider{}, nil)
+	ctx := context.Background()
+	kyiv := contracts.CityLocation("Kyiv")
+
+	_, err := svc.GetHistory(ctx, kyiv, time.Time{}, time.Time{})
+	require.ErrorIs(t, err, apierrors.ErrHistoryDisabled)
+
+	store := &fakeHistory{}
+	svc.SetHistory(store)
+
+	to := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	observations, err := svc.GetHistory(ctx, kyiv, time.Time{}, to)
+	require.NoError(t, err)
+	require.Len(t, observations, 1)
+	assert.Equal(t, to.Add(-weather_service.DefaultHistoryPeriod), store.from)
+	assert.Equal(t, to, store.to)
+	assert.Equal(t, weather_service.MaxHistoryPoints, store.limit)
+
+	_, err = svc.GetHistory(ctx, kyiv, to, to)
+	require.ErrorIs(t, err, apierrors.ErrInvalidTimeRange)
+
+	_, err = svc.GetHistory(ctx, contracts.CityLocation(" "), time.Time{}, time.Time{})
+	require.ErrorIs(t, err, apierrors.ErrInvalidCity)
+}
+
+// cityProvider knows every city except Atlantis and counts lookups per city.
+type cityProvider struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (p *cityProvider) FetchWeather(ctx context.Context, loc contracts.Location) (contracts.WeatherData, error) {
+	p.mu.Lock()
+	p.calls[loc.City]++
+	p.mu.Unlock()
+	if loc.City == "Atlantis" {
+		return contracts.WeatherData{}, apierrors.ErrCityNotFound
+	}
+	return contracts.WeatherData{Description: "Fresh " + loc.City}, nil
+}
+
+func (p *cityProvider) FetchForecast(ctx context.Context, loc contracts.Location, days int) (contracts.ForecastData, error) {
+	return contracts.ForecastData{}, nil
+}
+
+func TestWeatherService_GetWeatherBatch(t *testing.T) {
+	ctx := context.Background()
+	weatherCache := cache.NewMemoryCache(10, time.Hour, nil)
+	require.NoError(t, weatherCache.Set(ctx, contracts.CityLocation("Kyiv"), contracts.WeatherData{
+		Description: "Cached Kyiv",
+		FetchedAt:   time.Now(),
+	}, time.Hour))
+	provider := &cityProvider{calls: map[string]int{}}
+	svc := newStaleTestService(provider, weatherCache)
+
+	results, err := svc.GetWeatherBatch(ctx, []contracts.Location{
+		contracts.CityLocation("Kyiv"),
+		contracts.CityLocation("Lviv"),
+		contracts.CityLocation("lviv "),
+		contracts.CityLocation("Atlantis"),
+		contracts.CoordinatesLocation(100, 0),
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	assert.Equal(t, "Cached Kyiv", results[0].Data.Description)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, "Fresh Lviv", results[1].Data.Description)
+	assert.NoError(t, results[1].Err)
+	assert.ErrorIs(t, results[2].Err, apierrors.ErrCityNotFound)
+	assert.ErrorIs(t, results[3].Err, apierrors.ErrInvalidCoordinates)
+	assert.Equal(t, map[string]int{"Lviv": 1, "Atlantis": 1}, provider.calls)
+
+	tooMany := make([]contracts.Location, weather_service.MaxBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = contracts.CoordinatesLocation(float64(i%90), float64(i))
+	}
+	_, err = svc.GetWeatherBatch(ctx, tooMany)
+	require.ErrorIs(t, err, apierrors.ErrBatchTooLarge)
+}
+
+func TestWeatherService_WatchWeather(t *testing.T) {
+	weatherCache := newMemoryCache()
+	provider := &stubProvider{data: contracts.WeatherData{Temperature: 20, Description: "Fresh"}}
+	svc := newStaleTestService(provider, weatherCache)
+	cachedAt(weatherCache, "Kyiv", time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates, err := svc.WatchWeather(ctx, contracts.CityLocation("Kyiv"))
+	require.NoError(t, err)
+
+	first := <-updates
+	assert.Equal(t, "Cached", first.Description)
+
+	// A refresh made by anyone is pushed to watchers.
+	_, err = svc.WarmWeather(context.Background(), contracts.CityLocation("Kyiv"), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	select {
+	case next := <-updates:
+		assert.Equal(t, "Fresh", next.Description)
+	case <-time.After(time.Second):
+		t.Fatal("refreshed weather was not pushed")
+	}
+
+	cancel()
+	select {
+	case _, ok := <-updates:
+		assert.False(t, ok, "updates must be closed when the watcher is done")
+	case <-time.After(time.Second):
+		t.Fatal("updates were not closed")
+	}
+
+	_, err = svc.WatchWeather(context.Background(), contracts.CityLocation(""))
+	require.ErrorIs(t, err, apierrors.ErrInvalidCity)
+}
+
+// countingGeocoder returns one city per query and counts calls.
+type countingGeocoder struct {
+	calls atomic.Int32
+}
+
+func (g *countingGeocoder) SearchCities(ctx context.Context, query string, limit int) ([]contracts.City, error) {
+	g.calls.Add(1)
+	return []contracts.City{{ID: "kyiv,ua", Name: "Kyiv", CountryCode: "UA"}}, nil
+}
+
+// memoryCityCache keeps city searches in a map keyed by query and limit.
+type memoryCityCache struct {
+	entries map[string][]contracts.City
+}
+
+func (c *memoryCityCache) GetCities(ctx context.Context, query string, limit int) ([]contracts.City, error) {
+	cities, ok := c.entries[fmt.Sprintf("%s:%d", query, limit)]
+	if !ok {
+		return nil, apierrors.ErrCacheMiss
+	}
+	return cities, nil
+}
+
+func (c *memoryCityCache) SetCities(ctx context.Context, query string, limit int, cities []contracts.City, expiration time.Duration) error {
+	c.entries[fmt.Sprintf("%s:%d", query, limit)] = cities
+	return nil
+}
+
+func TestWeatherService_SearchCities(t *testing.T) {
+	ctx := context.Background()
+	geocoder := &countingGeocoder{}
+	weatherChain := chain.NewWeatherChain(logging.NewMockLogger())
+	weatherChain.AddGeocoder("stub", geocoder)
+	svc := weather_service.NewWeatherService(
+		weatherChain,
+		cache.NoopWeatherCache{},
+		cache.NoopWeatherCache{},
+		cache.NoopWeatherCache{},
+		time.Minute,
+		time.Minute,
+		time.Minute,
+		weather_service.StalePolicy{},
+		nil,
+	)
+	cityCache := &memoryCityCache{entries: map[string][]contracts.City{}}
+	svc.SetCityCache(cityCache, time.Hour)
+
+	cities, err := svc.SearchCities(ctx, " Kyiv ", 0)
+	require.NoError(t, err)
+	require.Len(t, cities, 1)
+	assert.Equal(t, "kyiv,ua", cities[0].ID)
+	assert.Contains(t, cityCache.entries, fmt.Sprintf("Kyiv:%d", weather_service.DefaultCitySearchLimit))
+
+	_, err = svc.SearchCities(ctx, "Kyiv", 0)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), geocoder.calls.Load())
+
+	_, err = svc.SearchCities(ctx, "Kyiv", 1000)
+	require.NoError(t, err)
+	assert.Contains(t, cityCache.entries, fmt.Sprintf("Kyiv:%d", weather_service.MaxCitySearchLimit))
+
+	_, err = svc.SearchCities(ctx, " K ", 5)
+	require.ErrorIs(t, err, apierrors.ErrInvalidCityQuery)
+}